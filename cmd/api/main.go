@@ -8,13 +8,19 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"inventory-app/internal/application/usecases"
+	"inventory-app/internal/domain/entities"
 	"inventory-app/internal/domain/services"
 	"inventory-app/internal/infrastructure/config"
 	"inventory-app/internal/infrastructure/database"
 	"inventory-app/internal/infrastructure/database/postgres"
+	"inventory-app/internal/infrastructure/events"
 	httpInfra "inventory-app/internal/infrastructure/http"
+	"inventory-app/internal/infrastructure/jobs"
+	"inventory-app/internal/infrastructure/notify"
 	"inventory-app/internal/interfaces/handlers"
+	"inventory-app/internal/interfaces/middleware"
 	"inventory-app/pkg/logger"
 )
 
@@ -41,24 +47,275 @@ func main() {
 	}
 	defer db.Close()
 
+	// Work across tenants runs on connections acting as the system role, which row-level security exempts
+	systemDB, err := database.NewSystemConnection(cfg)
+	if err != nil {
+		appLogger.Fatal("Failed to connect to database as the system role", appLogger.WithField("error", err))
+	}
+	defer systemDB.Close()
+
 	appLogger.Info("Database connection established")
 
 	// Initialize repositories
 	productRepo := postgres.NewProductRepository(db)
+	categoryRepo := postgres.NewCategoryRepository(db)
+	transactionRepo := postgres.NewTransactionRepository(db)
+	supplierRepo := postgres.NewSupplierRepository(db)
+	purchaseOrderRepo := postgres.NewPurchaseOrderRepository(db)
+	reservationRepo := postgres.NewReservationRepository(db)
+	snapshotRepo := postgres.NewStockSnapshotRepository(db)
+	classificationRepo := postgres.NewClassificationRepository(db)
+	reconciliationRepo := postgres.NewReconciliationRepository(db)
+	idempotencyRepo := postgres.NewIdempotencyRepository(db)
+	roleRepo := postgres.NewRoleRepository(db)
+	apiKeyRepo := postgres.NewAPIKeyRepository(db)
+	tenantRepo := postgres.NewTenantRepository(db)
+	auditRepo := postgres.NewAuditRepository(db)
+	outboxRepo := postgres.NewOutboxRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	webhookDeliveryRepo := postgres.NewWebhookDeliveryRepository(db)
+	alertRepo := postgres.NewAlertRepository(db)
+	alertSubscriptionRepo := postgres.NewAlertSubscriptionRepository(db)
+	alertFeedRepo := postgres.NewAlertFeedRepository(db)
+
+	// Repositories of the dispatcher and its consumers, cross-tenant jobs and the API key lookup
+	systemOutboxRepo := postgres.NewOutboxRepository(systemDB)
+	systemIdempotencyRepo := postgres.NewIdempotencyRepository(systemDB)
+	systemAPIKeyRepo := postgres.NewAPIKeyRepository(systemDB)
+	systemWebhookRepo := postgres.NewWebhookRepository(systemDB)
+	systemWebhookDeliveryRepo := postgres.NewWebhookDeliveryRepository(systemDB)
+	systemCategoryRepo := postgres.NewCategoryRepository(systemDB)
+	systemAlertSubscriptionRepo := postgres.NewAlertSubscriptionRepository(systemDB)
+	systemAlertFeedRepo := postgres.NewAlertFeedRepository(systemDB)
+
+	// Initialize access control
+	bootstrapAdmin := uuid.Nil
+	if cfg.RBAC.BootstrapAdmin != "" {
+		if bootstrapAdmin, err = uuid.Parse(cfg.RBAC.BootstrapAdmin); err != nil {
+			appLogger.Fatal("Invalid RBAC bootstrap admin", appLogger.WithField("error", err))
+		}
+	}
+	if cfg.RBAC.DefaultRole != "" && !entities.IsValidRole(cfg.RBAC.DefaultRole) {
+		appLogger.Fatal("Invalid RBAC default role", appLogger.WithField("role", cfg.RBAC.DefaultRole))
+	}
+	authorizationService := services.NewAuthorizationService(roleRepo, cfg.RBAC.DefaultRole, bootstrapAdmin, appLogger)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, appLogger)
+	// Keys are looked up before their tenant is known
+	apiKeyAuthService := services.NewAPIKeyService(systemAPIKeyRepo, appLogger)
 
 	// Initialize services
-	inventoryService := services.NewInventoryService(productRepo, nil) // Transaction repo will be added later
+	eventService := services.NewEventService(outboxRepo)
+	alertService := services.NewAlertService(alertRepo, eventService)
+	inventoryService := services.NewInventoryService(productRepo, transactionRepo, eventService, alertService, db)
+	replenishmentService := services.NewReplenishmentService(
+		productRepo, transactionRepo, supplierRepo, purchaseOrderRepo, reservationRepo,
+		cfg.ReplenishmentParams(), cfg.Replenishment.DemandWindowDays,
+	)
+	forecastService := services.NewForecastService(productRepo, transactionRepo, supplierRepo)
+	classificationService := services.NewClassificationService(productRepo, transactionRepo, classificationRepo)
+	snapshotService := services.NewStockSnapshotService(snapshotRepo)
+	reconciliationService := services.NewReconciliationService(reconciliationRepo)
+	auditService := services.NewAuditService(auditRepo)
 
 	// Initialize use cases
-	productUseCase := usecases.NewProductUseCase(productRepo, nil, inventoryService) // Category repo will be added later
+	priceBuckets, err := cfg.SearchPriceBuckets()
+	if err != nil {
+		appLogger.Fatal("Invalid search price buckets", appLogger.WithField("error", err))
+	}
+	productUseCase := usecases.NewProductUseCase(productRepo, categoryRepo, inventoryService,
+		classificationRepo, priceBuckets, authorizationService, auditService, eventService, db)
+	productImportUseCase := usecases.NewProductImportUseCase(productRepo, categoryRepo, db, auditService, eventService)
+	categoryUseCase := usecases.NewCategoryUseCase(categoryRepo, productRepo, auditService, authorizationService, db)
+	inventoryUseCase := usecases.NewInventoryUseCase(inventoryService, transactionRepo, productRepo, reservationRepo, authorizationService)
+	accessUseCase := usecases.NewAccessUseCase(roleRepo)
+	apiKeyUseCase := usecases.NewAPIKeyUseCase(apiKeyService, apiKeyRepo)
+	supplierUseCase := usecases.NewSupplierUseCase(supplierRepo, productRepo)
+	replenishmentUseCase := usecases.NewReplenishmentUseCase(replenishmentService, supplierRepo, purchaseOrderRepo, db)
+	forecastUseCase := usecases.NewForecastUseCase(forecastService, productRepo, cfg.ForecastParams(), auditService, db)
+	analyticsUseCase := usecases.NewAnalyticsUseCase(classificationService, snapshotRepo, transactionRepo, cfg.ClassificationParams())
+	reconciliationUseCase := usecases.NewReconciliationUseCase(reconciliationService, reconciliationRepo)
+	auditUseCase := usecases.NewAuditUseCase(auditRepo, productRepo)
+	webhookUseCase := usecases.NewWebhookUseCase(webhookRepo, webhookDeliveryRepo)
+
+	// Initialize alert notification channels
+	alertChannels := map[string]notify.Channel{
+		entities.AlertChannelInApp: notify.NewFeedChannel(systemAlertFeedRepo),
+	}
+	if cfg.Alert.SMTPHost != "" {
+		alertChannels[entities.AlertChannelEmail] = notify.NewEmailChannel(notify.EmailSettings{
+			Host:     cfg.Alert.SMTPHost,
+			Port:     cfg.Alert.SMTPPort,
+			Username: cfg.Alert.SMTPUsername,
+			Password: cfg.Alert.SMTPPassword,
+			From:     cfg.Alert.SMTPFrom,
+			Timeout:  cfg.Alert.SMTPTimeout,
+		})
+	}
+	if cfg.Alert.SlackEnabled {
+		alertChannels[entities.AlertChannelSlack] = notify.NewSlackChannel(cfg.Alert.SlackTimeout)
+	}
+	alertNotifier := notify.NewNotifier(systemAlertSubscriptionRepo, systemCategoryRepo, alertChannels, appLogger)
+	stockStreamService := services.NewStockStreamService(outboxRepo, cfg.Stream.BufferSize, cfg.Stream.ReplayLimit)
+	streamUseCase := usecases.NewStreamUseCase(stockStreamService, categoryRepo)
+	alertUseCase := usecases.NewAlertUseCase(alertRepo, alertSubscriptionRepo, alertFeedRepo, categoryRepo, alertNotifier.Channels())
 
 	// Initialize handlers
-	productHandler := handlers.NewProductHandler(productUseCase)
+	productHandler := handlers.NewProductHandler(productUseCase, productImportUseCase, cfg.Server.ImportBodyLimit)
+	categoryHandler := handlers.NewCategoryHandler(categoryUseCase)
+	transactionHandler := handlers.NewTransactionHandler()
+	inventoryHandler := handlers.NewInventoryHandler(inventoryUseCase)
+	supplierHandler := handlers.NewSupplierHandler(supplierUseCase)
+	replenishmentHandler := handlers.NewReplenishmentHandler(replenishmentUseCase)
+	forecastHandler := handlers.NewForecastHandler(forecastUseCase)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsUseCase)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationUseCase)
+	adminHandler := handlers.NewAdminHandler(accessUseCase)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyUseCase)
+	auditHandler := handlers.NewAuditHandler(auditUseCase)
+	webhookHandler := handlers.NewWebhookHandler(webhookUseCase)
+	alertHandler := handlers.NewAlertHandler(alertUseCase)
+	streamHandler := handlers.NewStreamHandler(streamUseCase, cfg.Stream.Heartbeat)
+
+	// Initialize authentication
+	verifier, err := cfg.JWTVerifier()
+	if err != nil {
+		appLogger.Fatal("Failed to initialize authentication", appLogger.WithField("error", err))
+	}
+	defaultTenant := uuid.Nil
+	if cfg.Tenancy.DefaultTenant != "" {
+		if defaultTenant, err = uuid.Parse(cfg.Tenancy.DefaultTenant); err != nil {
+			appLogger.Fatal("Invalid default tenant", appLogger.WithField("error", err))
+		}
+	}
 
 	// Initialize HTTP router
-	router := httpInfra.NewRouter(productHandler)
+	router := httpInfra.NewRouter(productHandler, categoryHandler, transactionHandler,
+		inventoryHandler, supplierHandler, replenishmentHandler, forecastHandler, analyticsHandler, reconciliationHandler,
+		adminHandler, apiKeyHandler, auditHandler, webhookHandler, alertHandler, streamHandler, middleware.Idempotency(idempotencyRepo, cfg.Idempotency.TTL),
+		middleware.APIKey(apiKeyAuthService), middleware.JWT(verifier, defaultTenant), middleware.RequirePermission(authorizationService), cfg.CORS.AllowOrigins, cfg.Server.BodyLimit)
 	router.SetupRoutes()
 
+	// Initialize event publishing; in-process consumers subscribe to the bus
+	eventBus := events.NewBus()
+	eventBus.Subscribe(events.AllEvents, func(ctx context.Context, event *entities.Event) error {
+		appLogger.Debug("Domain event", appLogger.WithFields(map[string]interface{}{
+			"tenant_id":  event.TenantID,
+			"event_type": event.Type,
+			"product_id": event.ProductID,
+			"sequence":   event.Sequence,
+		})...)
+		return nil
+	})
+	webhookDeliverer := events.NewWebhookDeliverer(systemWebhookRepo, systemWebhookDeliveryRepo, events.WebhookSettings{
+		BatchSize:   cfg.Webhook.BatchSize,
+		Timeout:     cfg.Webhook.Timeout,
+		MaxAttempts: cfg.Webhook.MaxAttempts,
+		MinBackoff:  cfg.Webhook.MinBackoff,
+		MaxBackoff:  cfg.Webhook.MaxBackoff,
+	}, appLogger)
+	eventBus.Subscribe(events.AllEvents, webhookDeliverer.Enqueue)
+	eventBus.Subscribe(entities.EventAlertOpened, alertNotifier.Notify)
+	eventBus.Subscribe(entities.EventAlertResolved, alertNotifier.Notify)
+	publishers := []events.Publisher{eventBus, events.NewNotifyPublisher(systemDB)}
+	if cfg.Outbox.WebhookURL != "" {
+		publishers = append(publishers, events.NewWebhookPublisher(cfg.Outbox.WebhookURL, cfg.Outbox.WebhookTimeout))
+	}
+	dispatcher := events.NewDispatcher(systemOutboxRepo, systemDB, publishers, cfg.Outbox.BatchSize, cfg.Outbox.MaxBackoff, appLogger)
+
+	// Start background jobs
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	scheduler := jobs.NewScheduler(appLogger)
+	if cfg.Snapshot.Enabled {
+		scheduler.Register(jobs.Job{
+			Name:     "stock-snapshots",
+			Interval: cfg.Snapshot.Interval,
+			Run: jobs.PerTenant(tenantRepo, func(ctx context.Context) error {
+				_, err := snapshotService.TakeDailySnapshots(ctx)
+				return err
+			}),
+		})
+	}
+	scheduler.Register(jobs.Job{
+		Name:     "product-classification",
+		Interval: cfg.Classification.Interval,
+		Run: jobs.PerTenant(tenantRepo, func(ctx context.Context) error {
+			_, err := classificationService.Refresh(ctx, cfg.ClassificationParams())
+			return err
+		}),
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "ledger-reconciliation",
+		Interval: cfg.Reconciliation.Interval,
+		Run: jobs.PerTenant(tenantRepo, func(ctx context.Context) error {
+			run, err := reconciliationService.Reconcile(ctx, cfg.Reconciliation.Repair, uuid.Nil)
+			if err != nil {
+				return err
+			}
+			if run.Discrepancies > 0 {
+				appLogger.Warn("Stock does not match the transaction ledger", appLogger.WithFields(map[string]interface{}{
+					"tenant_id":     entities.TenantIDFromContext(ctx),
+					"run_id":        run.ID,
+					"discrepancies": run.Discrepancies,
+					"repaired":      run.Repaired,
+				})...)
+			}
+			return nil
+		}),
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "purge-deleted-products",
+		Interval: cfg.Purge.Interval,
+		Run: jobs.PerTenant(tenantRepo, func(ctx context.Context) error {
+			purged, err := productRepo.PurgeDeleted(ctx, time.Now().Add(-cfg.Purge.Retention))
+			if err != nil {
+				return err
+			}
+			if purged > 0 {
+				appLogger.Info("Purged soft-deleted products", appLogger.WithFields(map[string]interface{}{
+					"tenant_id": entities.TenantIDFromContext(ctx),
+					"purged":    purged,
+				})...)
+			}
+			return nil
+		}),
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "outbox-dispatcher",
+		Interval: cfg.Outbox.PollInterval,
+		Run:      dispatcher.Dispatch,
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "webhook-delivery",
+		Interval: cfg.Webhook.DeliveryInterval,
+		Run:      webhookDeliverer.Deliver,
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "outbox-cleanup",
+		Interval: cfg.Outbox.CleanupInterval,
+		Run: func(ctx context.Context) error {
+			_, err := systemOutboxRepo.DeletePublished(ctx, time.Now().Add(-cfg.Outbox.Retention))
+			return err
+		},
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "idempotency-cleanup",
+		Interval: cfg.Idempotency.CleanupInterval,
+		Run: func(ctx context.Context) error {
+			_, err := systemIdempotencyRepo.DeleteExpired(ctx)
+			return err
+		},
+	})
+	scheduler.Start(jobCtx)
+
+	// Feed the stock stream with the stock events relayed by whichever instance dispatches. Clients resume by
+	// replay after a lost listener connection, as notifications sent meanwhile are gone.
+	stockListener := events.NewNotifyListener(cfg.DatabaseURL(), stockStreamService.DisconnectAll, appLogger)
+	go func() {
+		if err := stockListener.Listen(jobCtx, stockStreamService.Publish); err != nil && jobCtx.Err() == nil {
+			appLogger.Error("Stock event listener stopped", appLogger.WithField("error", err))
+		}
+	}()
+
 	// Get Fiber app
 	app := router.GetApp()
 
@@ -77,6 +334,11 @@ func main() {
 
 	appLogger.Info("Shutting down server...")
 
+	// Stop background jobs and end open streams
+	stopJobs()
+	scheduler.Wait()
+	stockStreamService.DisconnectAll()
+
 	// Create a context with timeout for graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
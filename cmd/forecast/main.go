@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/services"
+	"inventory-app/internal/infrastructure/config"
+	"inventory-app/internal/infrastructure/database"
+	"inventory-app/internal/infrastructure/database/postgres"
+	"inventory-app/internal/infrastructure/jobs"
+	"inventory-app/pkg/logger"
+)
+
+// Forecast batch job: forecasts the demand of every stocked product of each tenant and optionally
+// updates each product's MinStock to the recommended safety stock.
+func main() {
+	model := flag.String("model", "", "forecast model: moving_average or exponential_smoothing (default from config)")
+	historyDays := flag.Int("history-days", 0, "days of history to use (default from config)")
+	serviceLevel := flag.Float64("service-level", 0, "target service level, e.g. 0.95 (default from config)")
+	updateMinStock := flag.Bool("update-min-stock", false, "set each product's min stock to the recommended safety stock")
+	tenant := flag.String("tenant", "", "tenant ID to forecast (default: every tenant)")
+	timeout := flag.Duration("timeout", 10*time.Minute, "maximum run time")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize logger
+	appLogger, err := logger.NewLogger(cfg.Logger.Level, cfg.Logger.Format)
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Sync()
+
+	// Initialize database connection
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		appLogger.Fatal("Failed to connect to database", appLogger.WithField("error", err))
+	}
+	defer db.Close()
+
+	productRepo := postgres.NewProductRepository(db)
+	transactionRepo := postgres.NewTransactionRepository(db)
+	supplierRepo := postgres.NewSupplierRepository(db)
+
+	forecastService := services.NewForecastService(productRepo, transactionRepo, supplierRepo)
+	auditService := services.NewAuditService(postgres.NewAuditRepository(db))
+	forecastUseCase := usecases.NewForecastUseCase(forecastService, productRepo, cfg.ForecastParams(), auditService, db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	forecast := func(ctx context.Context) error {
+		tenantID := entities.TenantIDFromContext(ctx)
+		result, err := forecastUseCase.RunBatch(ctx, &dto.ForecastBatchRequest{
+			ForecastRequest: dto.ForecastRequest{
+				Model:        *model,
+				HistoryDays:  *historyDays,
+				ServiceLevel: *serviceLevel,
+			},
+			UpdateMinStock: *updateMinStock,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, r := range result.Results {
+			appLogger.Info("Forecast", appLogger.WithFields(map[string]interface{}{
+				"tenant_id":            tenantID,
+				"product_id":           r.ProductID,
+				"average_daily_demand": r.AverageDailyDemand,
+				"safety_stock":         r.SafetyStock,
+				"reorder_point":        r.ReorderPoint,
+				"previous_min_stock":   r.PreviousMinStock,
+				"min_stock_updated":    r.MinStockUpdated,
+			})...)
+		}
+
+		appLogger.Info("Forecast batch finished", appLogger.WithFields(map[string]interface{}{
+			"tenant_id": tenantID,
+			"products":  len(result.Results),
+			"updated":   result.Updated,
+		})...)
+		return nil
+	}
+
+	if *tenant != "" {
+		tenantID, parseErr := uuid.Parse(*tenant)
+		if parseErr != nil {
+			appLogger.Fatal("Invalid tenant", appLogger.WithField("error", parseErr))
+		}
+		err = forecast(entities.WithTenantID(ctx, tenantID))
+	} else {
+		err = jobs.PerTenant(postgres.NewTenantRepository(db), forecast)(ctx)
+	}
+	if err != nil {
+		appLogger.Fatal("Forecast batch failed", appLogger.WithField("error", err))
+	}
+}
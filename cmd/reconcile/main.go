@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/services"
+	"inventory-app/internal/infrastructure/config"
+	"inventory-app/internal/infrastructure/database"
+	"inventory-app/internal/infrastructure/database/postgres"
+	"inventory-app/internal/infrastructure/jobs"
+	"inventory-app/pkg/logger"
+)
+
+// Reconciliation job: compares products.stock with the transaction ledger of each tenant, logs
+// every discrepancy and, with -repair, posts correcting adjustments. Exits with status 1 when
+// discrepancies were found and left unrepaired, so it can alert from cron.
+func main() {
+	repair := flag.Bool("repair", false, "post an adjustment transaction for every discrepancy")
+	tenant := flag.String("tenant", "", "tenant ID to reconcile (default: every tenant)")
+	timeout := flag.Duration("timeout", 10*time.Minute, "maximum run time")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize logger
+	appLogger, err := logger.NewLogger(cfg.Logger.Level, cfg.Logger.Format)
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer appLogger.Sync()
+
+	// Initialize database connection
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		appLogger.Fatal("Failed to connect to database", appLogger.WithField("error", err))
+	}
+	defer db.Close()
+
+	reconciliationService := services.NewReconciliationService(postgres.NewReconciliationRepository(db))
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	unrepaired := false
+	reconcile := func(ctx context.Context) error {
+		tenantID := entities.TenantIDFromContext(ctx)
+		run, err := reconciliationService.Reconcile(ctx, *repair, uuid.Nil)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range run.Items {
+			appLogger.Warn("Stock does not match the transaction ledger", appLogger.WithFields(map[string]interface{}{
+				"tenant_id":           tenantID,
+				"product_id":          item.ProductID,
+				"sku":                 item.SKU,
+				"stock":               item.Stock,
+				"ledger_stock":        item.LedgerStock,
+				"difference":          item.Difference,
+				"transactions":        item.Transactions,
+				"last_transaction_at": item.LastTransactionAt,
+				"adjustment_id":       item.AdjustmentID,
+			})...)
+		}
+
+		appLogger.Info("Reconciliation finished", appLogger.WithFields(map[string]interface{}{
+			"tenant_id":        tenantID,
+			"run_id":           run.ID,
+			"products_checked": run.ProductsChecked,
+			"discrepancies":    run.Discrepancies,
+			"repaired":         run.Repaired,
+		})...)
+
+		if run.Discrepancies > run.Repaired {
+			unrepaired = true
+		}
+		return nil
+	}
+
+	if *tenant != "" {
+		tenantID, parseErr := uuid.Parse(*tenant)
+		if parseErr != nil {
+			appLogger.Fatal("Invalid tenant", appLogger.WithField("error", parseErr))
+		}
+		err = reconcile(entities.WithTenantID(ctx, tenantID))
+	} else {
+		err = jobs.PerTenant(postgres.NewTenantRepository(db), reconcile)(ctx)
+	}
+	if err != nil {
+		appLogger.Fatal("Reconciliation failed", appLogger.WithField("error", err))
+	}
+
+	if unrepaired {
+		appLogger.Sync()
+		os.Exit(1)
+	}
+}
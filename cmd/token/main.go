@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/infrastructure/config"
+	"inventory-app/pkg/jwt"
+)
+
+// Token minting for development and tests: prints a JWT for the given user and tenant, signed with the
+// configured HS256 secret or, with -key, an RS256 private key. Issuer and audience default to
+// the values the API checks.
+func main() {
+	subject := flag.String("sub", "", "user ID to put in the sub claim (default: a new random ID)")
+	tenant := flag.String("tenant", "", "tenant ID to put in the tenant_id claim (default: none, i.e. the configured default tenant)")
+	algorithm := flag.String("alg", jwt.HS256, "signing algorithm, HS256 or RS256")
+	keyFile := flag.String("key", "", "PEM file holding the RS256 private key")
+	ttl := flag.Duration("ttl", time.Hour, "token lifetime")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if *subject == "" {
+		*subject = uuid.New().String()
+	} else if _, err := uuid.Parse(*subject); err != nil {
+		log.Fatalf("The subject must be a user ID: %v", err)
+	}
+	if *tenant != "" {
+		if _, err := uuid.Parse(*tenant); err != nil {
+			log.Fatalf("The tenant must be a tenant ID: %v", err)
+		}
+	}
+
+	now := time.Now()
+	claims := &jwt.Claims{
+		Subject:   *subject,
+		Issuer:    cfg.Auth.JWTIssuer,
+		TenantID:  *tenant,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(*ttl).Unix(),
+	}
+	if cfg.Auth.JWTAudience != "" {
+		claims.Audience = jwt.Audience{cfg.Auth.JWTAudience}
+	}
+
+	var token string
+	switch *algorithm {
+	case jwt.HS256:
+		if cfg.Auth.JWTSecret == "" {
+			log.Fatal("AUTH_JWT_SECRET is not set")
+		}
+		token, err = jwt.SignHS256(claims, []byte(cfg.Auth.JWTSecret))
+	case jwt.RS256:
+		if *keyFile == "" {
+			log.Fatal("-key is required for RS256")
+		}
+		data, readErr := os.ReadFile(*keyFile)
+		if readErr != nil {
+			log.Fatalf("Failed to read private key: %v", readErr)
+		}
+		key, parseErr := jwt.ParseRSAPrivateKey(data)
+		if parseErr != nil {
+			log.Fatalf("Failed to load private key: %v", parseErr)
+		}
+		token, err = jwt.SignRS256(claims, key)
+	default:
+		log.Fatalf("Unsupported algorithm %q", *algorithm)
+	}
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+
+	fmt.Println(token)
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoleResponse represents a role and every permission it grants
+type RoleResponse struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// RoleAssignmentRequest represents a request to give a user a role
+type RoleAssignmentRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// RoleAssignmentResponse represents the role given to a user
+type RoleAssignmentResponse struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Role       string    `json:"role"`
+	AssignedBy uuid.UUID `json:"assigned_by"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// APIKeyRequest represents a request to issue an API key
+type APIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyResponse represents an API key; the secret is never included
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// APIKeyCreatedResponse represents a newly issued API key with the full key, which is shown only once
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
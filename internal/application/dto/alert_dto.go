@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertResponse represents a stock alert
+type AlertResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	ProductID  uuid.UUID  `json:"product_id"`
+	Type       string     `json:"type"`
+	Status     string     `json:"status"`
+	Stock      int        `json:"stock"`
+	Threshold  int        `json:"threshold"`
+	OpenedAt   time.Time  `json:"opened_at"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+}
+
+// AlertListResponse represents a paginated list of stock alerts, newest first
+type AlertListResponse struct {
+	Alerts []AlertResponse `json:"alerts"`
+	Total  int             `json:"total"`
+	Page   int             `json:"page"`
+	Limit  int             `json:"limit"`
+}
+
+// AlertSubscriptionRequest represents a request to subscribe the caller to the alerts of a category,
+// or of every category when category_id is omitted
+type AlertSubscriptionRequest struct {
+	CategoryID *uuid.UUID `json:"category_id"`
+	Channel    string     `json:"channel" binding:"required"`
+	Target     string     `json:"target"` // email address for email, incoming webhook URL for slack
+}
+
+// AlertSubscriptionResponse represents an alert subscription
+type AlertSubscriptionResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	CategoryID *uuid.UUID `json:"category_id"`
+	Channel    string     `json:"channel"`
+	Target     string     `json:"target,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// AlertFeedItemResponse represents an alert notification in the in-app feed
+type AlertFeedItemResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	AlertID   uuid.UUID  `json:"alert_id"`
+	EventType string     `json:"event_type"`
+	Subject   string     `json:"subject"`
+	Message   string     `json:"message"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at"`
+}
+
+// AlertFeedResponse represents a paginated page of the in-app feed, newest first
+type AlertFeedResponse struct {
+	Items []AlertFeedItemResponse `json:"items"`
+	Total int                     `json:"total"`
+	Page  int                     `json:"page"`
+	Limit int                     `json:"limit"`
+}
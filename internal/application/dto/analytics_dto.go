@@ -0,0 +1,145 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ClassificationRequest represents ABC/XYZ settings and filters; zero values fall back to the configured defaults
+type ClassificationRequest struct {
+	WindowDays int     `json:"window_days" query:"window_days"`
+	PeriodDays int     `json:"period_days" query:"period_days"`
+	AThreshold float64 `json:"a_threshold" query:"a_threshold"`
+	BThreshold float64 `json:"b_threshold" query:"b_threshold"`
+	XThreshold float64 `json:"x_threshold" query:"x_threshold"`
+	YThreshold float64 `json:"y_threshold" query:"y_threshold"`
+	ABCClass   string  `json:"abc" query:"abc"`
+	XYZClass   string  `json:"xyz" query:"xyz"`
+}
+
+// ProductClassificationResponse represents the ABC/XYZ class of a product
+type ProductClassificationResponse struct {
+	ProductID           uuid.UUID `json:"product_id"`
+	SKU                 string    `json:"sku"`
+	Name                string    `json:"name"`
+	ConsumptionQuantity int       `json:"consumption_quantity"`
+	ConsumptionValue    float64   `json:"consumption_value"`
+	ValueShare          float64   `json:"value_share"`
+	CumulativeShare     float64   `json:"cumulative_share"`
+	DemandCV            *float64  `json:"demand_cv"`
+	ABCClass            string    `json:"abc_class"`
+	XYZClass            string    `json:"xyz_class"`
+	Class               string    `json:"class"`
+}
+
+// ClassSummaryResponse represents the products and value of one combined class, e.g. AX
+type ClassSummaryResponse struct {
+	Class            string  `json:"class"`
+	Products         int     `json:"products"`
+	ConsumptionValue float64 `json:"consumption_value"`
+	ValueShare       float64 `json:"value_share"`
+}
+
+// ClassificationResponse represents an ABC/XYZ classification report
+type ClassificationResponse struct {
+	WindowStart time.Time                       `json:"window_start"`
+	WindowEnd   time.Time                       `json:"window_end"`
+	PeriodDays  int                             `json:"period_days"`
+	AThreshold  float64                         `json:"a_threshold"`
+	BThreshold  float64                         `json:"b_threshold"`
+	XThreshold  float64                         `json:"x_threshold"`
+	YThreshold  float64                         `json:"y_threshold"`
+	TotalValue  float64                         `json:"total_value"`
+	Summary     []ClassSummaryResponse          `json:"summary"`
+	Products    []ProductClassificationResponse `json:"products"`
+}
+
+// ProductStockResponse represents a product's stock at a point in time
+type ProductStockResponse struct {
+	ProductID uuid.UUID `json:"product_id"`
+	SKU       string    `json:"sku"`
+	Name      string    `json:"name"`
+	Quantity  int       `json:"quantity"`
+	UnitCost  float64   `json:"unit_cost"`
+	Value     float64   `json:"value"`
+}
+
+// CategoryStockResponse represents a category's stock at a point in time
+type CategoryStockResponse struct {
+	CategoryID   uuid.UUID              `json:"category_id"`
+	CategoryName string                 `json:"category_name"`
+	Quantity     int                    `json:"quantity"`
+	Value        float64                `json:"value"`
+	Products     []ProductStockResponse `json:"products"`
+}
+
+// StockAsOfResponse represents the stock on hand at a point in time, per category and product
+type StockAsOfResponse struct {
+	AsOf       time.Time               `json:"as_of"`
+	Quantity   int                     `json:"quantity"`
+	Value      float64                 `json:"value"`
+	Categories []CategoryStockResponse `json:"categories"`
+}
+
+// MovementReportRequest represents the range, grouping and filter of a movement report
+type MovementReportRequest struct {
+	From       time.Time
+	To         time.Time
+	GroupBy    string // "product" or "category"
+	CategoryID *uuid.UUID
+}
+
+// MovementRowResponse represents the movements of a product or category over the report range
+type MovementRowResponse struct {
+	ProductID      *uuid.UUID `json:"product_id,omitempty"`
+	SKU            string     `json:"sku,omitempty"`
+	Name           string     `json:"name,omitempty"`
+	CategoryID     *uuid.UUID `json:"category_id,omitempty"`
+	CategoryName   string     `json:"category_name,omitempty"`
+	OpeningStock   int        `json:"opening_stock"`
+	In             int        `json:"in"`
+	Out            int        `json:"out"`
+	Adjustments    int        `json:"adjustments"`
+	ClosingStock   int        `json:"closing_stock"`
+	ClosingValue   float64    `json:"closing_value"`
+	Turnover       *float64   `json:"turnover"`
+	DaysOnHand     *float64   `json:"days_on_hand"`
+	LastMovementAt *time.Time `json:"last_movement_at,omitempty"`
+}
+
+// MovementReportResponse represents stock movements, turnover and days on hand over a date range
+type MovementReportResponse struct {
+	From    time.Time             `json:"from"`
+	To      time.Time             `json:"to"`
+	Days    int                   `json:"days"`
+	GroupBy string                `json:"group_by"`
+	Rows    []MovementRowResponse `json:"rows"`
+	Totals  MovementRowResponse   `json:"totals"`
+}
+
+// DeadStockRequest represents the idle period and filter of a dead stock report
+type DeadStockRequest struct {
+	Days       int
+	CategoryID *uuid.UUID
+}
+
+// DeadStockItemResponse represents a product whose stock has not moved
+type DeadStockItemResponse struct {
+	ProductID      uuid.UUID  `json:"product_id"`
+	SKU            string     `json:"sku"`
+	Name           string     `json:"name"`
+	CategoryID     uuid.UUID  `json:"category_id"`
+	CategoryName   string     `json:"category_name"`
+	Stock          int        `json:"stock"`
+	UnitCost       float64    `json:"unit_cost"`
+	Value          float64    `json:"value"`
+	LastMovementAt *time.Time `json:"last_movement_at"`
+}
+
+// DeadStockResponse represents the products without movement since a cut-off
+type DeadStockResponse struct {
+	Days       int                     `json:"days"`
+	Since      time.Time               `json:"since"`
+	TotalValue float64                 `json:"total_value"`
+	Items      []DeadStockItemResponse `json:"items"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntryResponse represents one change in the audit log
+type AuditEntryResponse struct {
+	ID         uuid.UUID             `json:"id"`
+	EntityType string                `json:"entity_type"`
+	EntityID   uuid.UUID             `json:"entity_id"`
+	Action     string                `json:"action"`
+	Changes    []FieldChangeResponse `json:"changes"`
+	ActorID    *uuid.UUID            `json:"actor_id"`
+	RequestID  string                `json:"request_id,omitempty"`
+	IP         string                `json:"ip,omitempty"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
+// AuditListResponse represents a paginated list of audit entries, newest first
+type AuditListResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+	Total   int                  `json:"total"`
+	Page    int                  `json:"page"`
+	Limit   int                  `json:"limit"`
+}
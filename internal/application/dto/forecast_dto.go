@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ForecastRequest represents forecast settings; zero values fall back to the configured defaults
+type ForecastRequest struct {
+	Model        string  `json:"model" query:"model"`
+	HistoryDays  int     `json:"history_days" query:"history_days"`
+	Window       int     `json:"window" query:"window"`
+	Horizon      int     `json:"horizon" query:"horizon"`
+	ServiceLevel float64 `json:"service_level" query:"service_level"`
+}
+
+// ForecastResponse represents a demand forecast and the resulting stock recommendation
+type ForecastResponse struct {
+	ProductID          uuid.UUID `json:"product_id"`
+	Model              string    `json:"model"`
+	HistoryStart       time.Time `json:"history_start"`
+	HistoryEnd         time.Time `json:"history_end"`
+	AverageDailyDemand float64   `json:"average_daily_demand"`
+	DemandStdDev       float64   `json:"demand_std_dev"`
+	LeadTimeDays       int       `json:"lead_time_days"`
+	ServiceLevel       float64   `json:"service_level"`
+	SafetyStock        int       `json:"safety_stock"`
+	ReorderPoint       int       `json:"reorder_point"`
+	DailyForecast      []float64 `json:"daily_forecast,omitempty"`
+}
+
+// ForecastBatchRequest represents a forecast run over all stocked products
+type ForecastBatchRequest struct {
+	ForecastRequest
+	UpdateMinStock bool `json:"update_min_stock"`
+}
+
+// ForecastBatchResult represents the outcome of the batch for one product
+type ForecastBatchResult struct {
+	ForecastResponse
+	PreviousMinStock int  `json:"previous_min_stock"`
+	MinStockUpdated  bool `json:"min_stock_updated"`
+}
+
+// ForecastBatchResponse represents the outcome of a forecast batch
+type ForecastBatchResponse struct {
+	Results []ForecastBatchResult `json:"results"`
+	Updated int                   `json:"updated"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// ImportRequest represents the options of a product import
+type ImportRequest struct {
+	Mode   string `query:"mode"`
+	DryRun bool   `query:"dry_run"`
+}
+
+// ImportRowError represents a problem with one row of an import file.
+// Row is the line number in the file, the header being row 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	SKU     string `json:"sku,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportResponse represents the outcome of a product import
+type ImportResponse struct {
+	Mode            string           `json:"mode"`
+	DryRun          bool             `json:"dry_run"`
+	Committed       bool             `json:"committed"`
+	Rows            int              `json:"rows"`
+	Created         int              `json:"created"`
+	Updated         int              `json:"updated"`
+	Failed          int              `json:"failed"`
+	Errors          []ImportRowError `json:"errors"`
+	ErrorsTruncated bool             `json:"errors_truncated,omitempty"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+// Patch formats, identified by the request content type
+const (
+	PatchFormatMerge = "application/merge-patch+json" // JSON Merge Patch, RFC 7386
+	PatchFormatJSON  = "application/json-patch+json"  // JSON Patch, RFC 6902
+)
+
+// PatchRequest represents a partial update in one of the patch formats
+type PatchRequest struct {
+	Format   string
+	Document []byte
+}
+
+// FieldChangeResponse represents the old and new value of a changed field
+type FieldChangeResponse struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// ProductPatchResponse represents a patched product with the fields that changed
+type ProductPatchResponse struct {
+	Product *ProductResponse      `json:"product"`
+	Changes []FieldChangeResponse `json:"changes"`
+}
+
+// CategoryPatchResponse represents a patched category with the fields that changed
+type CategoryPatchResponse struct {
+	Category *CategoryResponse     `json:"category"`
+	Changes  []FieldChangeResponse `json:"changes"`
+}
@@ -19,23 +19,40 @@ type ProductRequest struct {
 
 // ProductResponse represents a product response
 type ProductResponse struct {
-	ID          uuid.UUID `json:"id"`
-	SKU         string    `json:"sku"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CategoryID  uuid.UUID `json:"category_id"`
-	Price       float64   `json:"price"`
-	Cost        float64   `json:"cost"`
-	Stock       int       `json:"stock"`
-	MinStock    int       `json:"min_stock"`
-	MaxStock    int       `json:"max_stock"`
-	Status      string    `json:"status"`
-	IsLowStock  bool      `json:"is_low_stock"`
-	IsOverStock bool      `json:"is_over_stock"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID  `json:"id"`
+	SKU         string     `json:"sku"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	CategoryID  uuid.UUID  `json:"category_id"`
+	Price       float64    `json:"price"`
+	Cost        float64    `json:"cost"`
+	Stock       int        `json:"stock"`
+	MinStock    int        `json:"min_stock"`
+	MaxStock    int        `json:"max_stock"`
+	Status      string     `json:"status"`
+	IsLowStock  bool       `json:"is_low_stock"`
+	IsOverStock bool       `json:"is_over_stock"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Version     int        `json:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+
+	ParentID    *uuid.UUID        `json:"parent_id,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	VariantAxes []string          `json:"variant_axes,omitempty"`
+	Variants    []VariantResponse `json:"variants,omitempty"`
+}
+
+// ProductFilter represents optional product list filters
+type ProductFilter struct {
+	ABCClass       string `query:"abc"`
+	XYZClass       string `query:"xyz"`
+	IncludeDeleted bool   `query:"include_deleted"` // list only; exports never include deleted products
 }
 
+// ProductExport streams the exported products to fn; it stops at the first error returned by fn
+type ProductExport func(fn func(*ProductResponse) error) error
+
 // ProductListResponse represents a paginated list of products
 type ProductListResponse struct {
 	Products []ProductResponse `json:"products"`
@@ -43,3 +60,102 @@ type ProductListResponse struct {
 	Page     int               `json:"page"`
 	Limit    int               `json:"limit"`
 }
+
+// ProductSearchRequest represents product search criteria
+type ProductSearchRequest struct {
+	Query          string      `query:"q"`
+	CategoryIDs    []uuid.UUID // subcategories are included
+	Statuses       []string    // active only when empty
+	InStock        bool        `query:"in_stock"`
+	IncludeDeleted bool        `query:"include_deleted"`
+	Page           int         `query:"page"`
+	Limit          int         `query:"limit"`
+	Facets         bool        `query:"facets"`
+	PriceBuckets   string      `query:"price_buckets"` // comma-separated bucket bounds; the configured ones when empty
+}
+
+// SearchHighlights holds HTML-escaped text with the matched words wrapped in <mark> tags
+type SearchHighlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description"` // the best matching fragments, empty without a query
+}
+
+// ProductSearchHit represents a product found by a search
+type ProductSearchHit struct {
+	ProductResponse
+	Rank       float64          `json:"rank"`
+	Highlights SearchHighlights `json:"highlights"`
+}
+
+// ProductSearchResponse represents a page of search hits, most relevant first
+type ProductSearchResponse struct {
+	Products []ProductSearchHit `json:"products"`
+	Total    int                `json:"total"`
+	Page     int                `json:"page"`
+	Limit    int                `json:"limit"`
+	Facets   *SearchFacets      `json:"facets,omitempty"`
+}
+
+// SearchFacets represents the counts of all search hits by category, status, stock state and price
+type SearchFacets struct {
+	Categories  []CategoryFacet `json:"categories"`
+	Statuses    []FacetCount    `json:"statuses"`
+	StockStates []FacetCount    `json:"stock_states"`
+	Prices      []PriceFacet    `json:"prices"`
+}
+
+// CategoryFacet represents the hits in a category and its subcategories
+type CategoryFacet struct {
+	CategoryID uuid.UUID  `json:"category_id"`
+	ParentID   *uuid.UUID `json:"parent_id"`
+	Name       string     `json:"name"`
+	Count      int        `json:"count"`
+}
+
+// FacetCount represents the hits with a value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// PriceFacet represents the hits priced from Min up to, excluding, Max
+type PriceFacet struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max"` // nil for the last bucket
+	Count int      `json:"count"`
+}
+
+// VariantAttributeRequest represents one axis of a variant matrix
+type VariantAttributeRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Values []string `json:"values" binding:"required"`
+}
+
+// VariantPolicyRequest represents values applied across all variants; omitted fields are left untouched
+type VariantPolicyRequest struct {
+	Price    *float64 `json:"price" binding:"omitempty,min=0"`
+	Cost     *float64 `json:"cost" binding:"omitempty,min=0"`
+	MinStock *int     `json:"min_stock" binding:"omitempty,min=0"`
+	MaxStock *int     `json:"max_stock" binding:"omitempty,min=0"`
+}
+
+// GenerateVariantsRequest represents a request to generate variants from an attribute matrix
+type GenerateVariantsRequest struct {
+	Attributes []VariantAttributeRequest `json:"attributes" binding:"required"`
+	Policy     VariantPolicyRequest      `json:"policy"`
+}
+
+// VariantResponse represents one cell of a product's variant grid
+type VariantResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	SKU         string            `json:"sku"`
+	Attributes  map[string]string `json:"attributes"`
+	Price       float64           `json:"price"`
+	Cost        float64           `json:"cost"`
+	Stock       int               `json:"stock"`
+	MinStock    int               `json:"min_stock"`
+	MaxStock    int               `json:"max_stock"`
+	Status      string            `json:"status"`
+	IsLowStock  bool              `json:"is_low_stock"`
+	IsOverStock bool              `json:"is_over_stock"`
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// PurchaseOrderLineResponse represents a purchase order line response
+type PurchaseOrderLineResponse struct {
+	ID               uuid.UUID `json:"id"`
+	ProductID        uuid.UUID `json:"product_id"`
+	Quantity         int       `json:"quantity"`
+	ReceivedQuantity int       `json:"received_quantity"`
+	UnitCost         float64   `json:"unit_cost"`
+}
+
+// PurchaseOrderResponse represents a purchase order response
+type PurchaseOrderResponse struct {
+	ID         uuid.UUID                   `json:"id"`
+	Number     string                      `json:"number"`
+	SupplierID uuid.UUID                   `json:"supplier_id"`
+	Status     string                      `json:"status"`
+	ExpectedAt *time.Time                  `json:"expected_at"`
+	Notes      string                      `json:"notes"`
+	Lines      []PurchaseOrderLineResponse `json:"lines,omitempty"`
+	Total      float64                     `json:"total,omitempty"`
+	CreatedBy  uuid.UUID                   `json:"created_by"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+}
+
+// PurchaseOrderListResponse represents a paginated list of purchase orders
+type PurchaseOrderListResponse struct {
+	PurchaseOrders []PurchaseOrderResponse `json:"purchase_orders"`
+	Total          int                     `json:"total"`
+	Page           int                     `json:"page"`
+	Limit          int                     `json:"limit"`
+}
+
+// ReplenishmentProposalResponse represents a suggested order for a product
+type ReplenishmentProposalResponse struct {
+	ProductID          uuid.UUID `json:"product_id"`
+	SKU                string    `json:"sku"`
+	Name               string    `json:"name"`
+	Stock              int       `json:"stock"`
+	Reserved           int       `json:"reserved"`
+	OnOrder            int       `json:"on_order"`
+	InventoryPosition  int       `json:"inventory_position"`
+	ReorderPoint       int       `json:"reorder_point"`
+	MinStock           int       `json:"min_stock"`
+	MaxStock           int       `json:"max_stock"`
+	AverageDailyDemand float64   `json:"average_daily_demand"`
+	LeadTimeDays       int       `json:"lead_time_days"`
+	MinOrderQty        int       `json:"min_order_qty"`
+	OrderMultiple      int       `json:"order_multiple"`
+	SuggestedQuantity  int       `json:"suggested_quantity"`
+	UnitCost           float64   `json:"unit_cost"`
+	LineCost           float64   `json:"line_cost"`
+}
+
+// SupplierProposalsResponse groups replenishment proposals by supplier.
+// Proposals for products without a supplier have a nil SupplierID.
+type SupplierProposalsResponse struct {
+	SupplierID   *uuid.UUID                      `json:"supplier_id"`
+	SupplierName string                          `json:"supplier_name"`
+	Proposals    []ReplenishmentProposalResponse `json:"proposals"`
+	TotalCost    float64                         `json:"total_cost"`
+}
+
+// ReplenishmentResponse represents replenishment proposals grouped by supplier
+type ReplenishmentResponse struct {
+	Policy    string                      `json:"policy"`
+	Suppliers []SupplierProposalsResponse `json:"suppliers"`
+}
+
+// CreatePurchaseOrdersRequest represents a request to convert proposals into draft purchase orders.
+// Empty supplier or product lists mean "all".
+type CreatePurchaseOrdersRequest struct {
+	Policy      string      `json:"policy" binding:"required,oneof=max eoq"`
+	SupplierIDs []uuid.UUID `json:"supplier_ids"`
+	ProductIDs  []uuid.UUID `json:"product_ids"`
+	Notes       string      `json:"notes"`
+}
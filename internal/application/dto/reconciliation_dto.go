@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ReconciliationRequest represents a request to run a ledger reconciliation
+type ReconciliationRequest struct {
+	Repair bool `json:"repair"`
+}
+
+// ReconciliationItemResponse represents a product whose stock did not match its ledger
+type ReconciliationItemResponse struct {
+	ProductID         uuid.UUID  `json:"product_id"`
+	SKU               string     `json:"sku"`
+	Name              string     `json:"name"`
+	Stock             int        `json:"stock"`
+	LedgerStock       int        `json:"ledger_stock"`
+	Difference        int        `json:"difference"`
+	Transactions      int        `json:"transactions"`
+	LastTransactionAt *time.Time `json:"last_transaction_at"`
+	AdjustmentID      *uuid.UUID `json:"adjustment_id,omitempty"`
+}
+
+// ReconciliationRunResponse represents a reconciliation run response
+type ReconciliationRunResponse struct {
+	ID              uuid.UUID                    `json:"id"`
+	Repair          bool                         `json:"repair"`
+	Status          string                       `json:"status"`
+	ProductsChecked int                          `json:"products_checked"`
+	Discrepancies   int                          `json:"discrepancies"`
+	Repaired        int                          `json:"repaired"`
+	Error           string                       `json:"error,omitempty"`
+	CreatedBy       uuid.UUID                    `json:"created_by"`
+	StartedAt       time.Time                    `json:"started_at"`
+	FinishedAt      time.Time                    `json:"finished_at"`
+	Items           []ReconciliationItemResponse `json:"items,omitempty"`
+}
+
+// ReconciliationRunListResponse represents a paginated list of reconciliation runs
+type ReconciliationRunListResponse struct {
+	Runs  []ReconciliationRunResponse `json:"runs"`
+	Total int                         `json:"total"`
+	Page  int                         `json:"page"`
+	Limit int                         `json:"limit"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockStreamRequest represents the filters of a stock stream and the event to resume after
+type StockStreamRequest struct {
+	ProductIDs  []uuid.UUID
+	CategoryIDs []uuid.UUID // subcategories are included
+	LastEventID int64       // 0 starts with live events only
+}
+
+// StockChangeResponse represents a committed stock movement in the stock stream
+type StockChangeResponse struct {
+	ProductID       uuid.UUID `json:"product_id"`
+	SKU             string    `json:"sku"`
+	CategoryID      uuid.UUID `json:"category_id"`
+	TransactionID   uuid.UUID `json:"transaction_id"`
+	TransactionType string    `json:"transaction_type"`
+	Quantity        int       `json:"quantity"`
+	StockBefore     int       `json:"stock_before"`
+	StockAfter      int       `json:"stock_after"`
+	Reference       string    `json:"reference,omitempty"`
+	OccurredAt      time.Time `json:"occurred_at"`
+}
+
+// StockStreamEvent represents one server-sent event of the stock stream
+type StockStreamEvent struct {
+	ID   int64 // the event sequence, sent as the SSE id
+	Type string
+	Data StockChangeResponse
+}
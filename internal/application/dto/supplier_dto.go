@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// SupplierRequest represents a supplier creation/update request
+type SupplierRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	LeadTimeDays int    `json:"lead_time_days" binding:"min=0"`
+}
+
+// SupplierResponse represents a supplier response
+type SupplierResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	Phone        string    `json:"phone"`
+	LeadTimeDays int       `json:"lead_time_days"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SupplierListResponse represents a paginated list of suppliers
+type SupplierListResponse struct {
+	Suppliers []SupplierResponse `json:"suppliers"`
+	Total     int                `json:"total"`
+	Page      int                `json:"page"`
+	Limit     int                `json:"limit"`
+}
+
+// ProductSupplierRequest represents the purchasing terms of a product at a supplier
+type ProductSupplierRequest struct {
+	SupplierSKU   string  `json:"supplier_sku"`
+	UnitCost      float64 `json:"unit_cost" binding:"min=0"`
+	LeadTimeDays  int     `json:"lead_time_days" binding:"min=0"`
+	MinOrderQty   int     `json:"min_order_qty" binding:"min=0"`
+	OrderMultiple int     `json:"order_multiple" binding:"min=0"`
+	IsPreferred   bool    `json:"is_preferred"`
+}
+
+// ProductSupplierResponse represents the purchasing terms of a product at a supplier
+type ProductSupplierResponse struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	SupplierID    uuid.UUID `json:"supplier_id"`
+	SupplierSKU   string    `json:"supplier_sku"`
+	UnitCost      float64   `json:"unit_cost"`
+	LeadTimeDays  int       `json:"lead_time_days"`
+	MinOrderQty   int       `json:"min_order_qty"`
+	OrderMultiple int       `json:"order_multiple"`
+	IsPreferred   bool      `json:"is_preferred"`
+}
@@ -26,6 +26,17 @@ type TransactionResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// TransactionExportRequest represents the range and type filter of a ledger export.
+// Nil dates leave the range open; To is exclusive.
+type TransactionExportRequest struct {
+	From *time.Time
+	To   *time.Time
+	Type string
+}
+
+// TransactionExport streams the exported transactions to fn; it stops at the first error returned by fn
+type TransactionExport func(fn func(*TransactionResponse) error) error
+
 // StockMovementRequest represents a stock movement request
 type StockMovementRequest struct {
 	ProductID uuid.UUID `json:"product_id" binding:"required"`
@@ -41,6 +52,41 @@ type StockAdjustmentRequest struct {
 	Notes       string    `json:"notes"`
 }
 
+// MovementBatchRequest represents a batch of stock movements sharing one reference
+type MovementBatchRequest struct {
+	Reference string                `json:"reference"`
+	Lines     []MovementLineRequest `json:"lines" binding:"required,min=1,max=1000"`
+}
+
+// MovementLineRequest represents one line of a movement batch.
+// In and out lines move Quantity units; adjustment lines set the stock to NewQuantity.
+type MovementLineRequest struct {
+	ProductID   uuid.UUID `json:"product_id" binding:"required"`
+	Type        string    `json:"type" binding:"required,oneof=in out adjustment"`
+	Quantity    int       `json:"quantity"`
+	NewQuantity *int      `json:"new_quantity"`
+	Notes       string    `json:"notes"`
+}
+
+// MovementLineResponse represents the outcome of one line of a movement batch
+type MovementLineResponse struct {
+	Line          int        `json:"line"`
+	ProductID     uuid.UUID  `json:"product_id"`
+	Type          string     `json:"type"`
+	Quantity      int        `json:"quantity"`
+	StockBefore   int        `json:"stock_before"`
+	StockAfter    int        `json:"stock_after"`
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// MovementBatchResponse represents the outcome of a movement batch
+type MovementBatchResponse struct {
+	Reference string                 `json:"reference"`
+	Applied   bool                   `json:"applied"`
+	Lines     []MovementLineResponse `json:"lines"`
+}
+
 // TransactionListResponse represents a paginated list of transactions
 type TransactionListResponse struct {
 	Transactions []TransactionResponse `json:"transactions"`
@@ -48,3 +94,24 @@ type TransactionListResponse struct {
 	Page         int                   `json:"page"`
 	Limit        int                   `json:"limit"`
 }
+
+// ReservationRequest represents a stock reservation request
+type ReservationRequest struct {
+	ProductID uuid.UUID  `json:"product_id" binding:"required"`
+	Quantity  int        `json:"quantity" binding:"required,min=1"`
+	Reference string     `json:"reference"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ReservationResponse represents a stock reservation response
+type ReservationResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	ProductID uuid.UUID  `json:"product_id"`
+	Quantity  int        `json:"quantity"`
+	Reference string     `json:"reference"`
+	Status    string     `json:"status"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedBy uuid.UUID  `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRequest represents a request to create or update a webhook subscription.
+// An empty secret is generated on create and left unchanged on update.
+type WebhookRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+	Secret     string   `json:"secret"`
+	Active     *bool    `json:"active"`
+}
+
+// WebhookResponse represents a webhook subscription; the secret is never included
+type WebhookResponse struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookCreatedResponse represents a new webhook subscription with its signing secret, which is shown only once
+type WebhookCreatedResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookAttemptResponse represents one HTTP request made for a delivery
+type WebhookAttemptResponse struct {
+	Attempt     int       `json:"attempt"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	DurationMS  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+// WebhookDeliveryResponse represents a delivery of an event to a webhook subscription
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID                `json:"id"`
+	SubscriptionID uuid.UUID                `json:"subscription_id"`
+	EventID        uuid.UUID                `json:"event_id"`
+	EventType      string                   `json:"event_type"`
+	Status         string                   `json:"status"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  *time.Time               `json:"next_attempt_at,omitempty"`
+	LastStatusCode int                      `json:"last_status_code,omitempty"`
+	LastError      string                   `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time               `json:"delivered_at"`
+	CreatedAt      time.Time                `json:"created_at"`
+	AttemptLog     []WebhookAttemptResponse `json:"attempt_log,omitempty"`
+}
+
+// WebhookDeliveryListResponse represents a paginated list of deliveries, newest first
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	Total      int                       `json:"total"`
+	Page       int                       `json:"page"`
+	Limit      int                       `json:"limit"`
+}
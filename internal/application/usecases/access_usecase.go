@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// AccessUseCase handles role administration
+type AccessUseCase interface {
+	ListRoles(ctx context.Context) []dto.RoleResponse
+	ListAssignments(ctx context.Context) ([]dto.RoleAssignmentResponse, error)
+	AssignRole(ctx context.Context, userID uuid.UUID, req *dto.RoleAssignmentRequest, assignedBy uuid.UUID) (*dto.RoleAssignmentResponse, error)
+	RevokeRole(ctx context.Context, userID uuid.UUID) error
+}
+
+type accessUseCase struct {
+	roleRepo repositories.RoleRepository
+}
+
+// NewAccessUseCase creates a new access use case
+func NewAccessUseCase(roleRepo repositories.RoleRepository) AccessUseCase {
+	return &accessUseCase{roleRepo: roleRepo}
+}
+
+// ListRoles lists the roles with the permissions each grants
+func (uc *accessUseCase) ListRoles(ctx context.Context) []dto.RoleResponse {
+	roles := make([]dto.RoleResponse, len(entities.Roles))
+	for i, role := range entities.Roles {
+		permissions := entities.RolePermissions(role)
+		roles[i] = dto.RoleResponse{Role: role, Permissions: make([]string, len(permissions))}
+		for j, permission := range permissions {
+			roles[i].Permissions[j] = string(permission)
+		}
+	}
+	return roles
+}
+
+// ListAssignments lists the users with an assigned role
+func (uc *accessUseCase) ListAssignments(ctx context.Context) ([]dto.RoleAssignmentResponse, error) {
+	assignments, err := uc.roleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.RoleAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		response[i] = *uc.assignmentToResponse(assignment)
+	}
+
+	return response, nil
+}
+
+// AssignRole gives a user a role, replacing the user's previous role
+func (uc *accessUseCase) AssignRole(ctx context.Context, userID uuid.UUID, req *dto.RoleAssignmentRequest, assignedBy uuid.UUID) (*dto.RoleAssignmentResponse, error) {
+	if !entities.IsValidRole(req.Role) {
+		return nil, entities.ErrInvalidRole
+	}
+
+	assignment := entities.NewRoleAssignment(userID, req.Role, assignedBy)
+	if err := uc.roleRepo.Assign(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	return uc.assignmentToResponse(assignment), nil
+}
+
+// RevokeRole removes the role of a user, who falls back to the default role
+func (uc *accessUseCase) RevokeRole(ctx context.Context, userID uuid.UUID) error {
+	return uc.roleRepo.Revoke(ctx, userID)
+}
+
+// assignmentToResponse converts a role assignment to a response DTO
+func (uc *accessUseCase) assignmentToResponse(assignment *entities.RoleAssignment) *dto.RoleAssignmentResponse {
+	return &dto.RoleAssignmentResponse{
+		UserID:     assignment.UserID,
+		Role:       assignment.Role,
+		AssignedBy: assignment.AssignedBy,
+		AssignedAt: assignment.AssignedAt,
+	}
+}
@@ -0,0 +1,210 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// AlertUseCase handles stock alerts, the alert subscriptions of the caller and their in-app feed
+type AlertUseCase interface {
+	ListAlerts(ctx context.Context, status, alertType string, page, limit int) (*dto.AlertListResponse, error)
+	Subscribe(ctx context.Context, req *dto.AlertSubscriptionRequest) (*dto.AlertSubscriptionResponse, error)
+	ListSubscriptions(ctx context.Context) ([]dto.AlertSubscriptionResponse, error)
+	Unsubscribe(ctx context.Context, id uuid.UUID) error
+	GetFeed(ctx context.Context, unreadOnly bool, page, limit int) (*dto.AlertFeedResponse, error)
+	MarkFeedItemRead(ctx context.Context, id uuid.UUID) error
+}
+
+type alertUseCase struct {
+	alertRepo        repositories.AlertRepository
+	subscriptionRepo repositories.AlertSubscriptionRepository
+	feedRepo         repositories.AlertFeedRepository
+	categoryRepo     repositories.CategoryRepository
+	channels         map[string]bool
+}
+
+// NewAlertUseCase creates a new alert use case; subscriptions can only be made to the given channels
+func NewAlertUseCase(alertRepo repositories.AlertRepository, subscriptionRepo repositories.AlertSubscriptionRepository, feedRepo repositories.AlertFeedRepository, categoryRepo repositories.CategoryRepository, channels []string) AlertUseCase {
+	enabled := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		enabled[channel] = true
+	}
+
+	return &alertUseCase{
+		alertRepo:        alertRepo,
+		subscriptionRepo: subscriptionRepo,
+		feedRepo:         feedRepo,
+		categoryRepo:     categoryRepo,
+		channels:         enabled,
+	}
+}
+
+// ListAlerts retrieves stock alerts, optionally restricted to a status and type
+func (uc *alertUseCase) ListAlerts(ctx context.Context, status, alertType string, page, limit int) (*dto.AlertListResponse, error) {
+	if status != "" && status != entities.AlertStatusOpen && status != entities.AlertStatusResolved {
+		return nil, entities.ErrInvalidAlertStatus
+	}
+	if alertType != "" && alertType != entities.AlertTypeLowStock && alertType != entities.AlertTypeOverStock {
+		return nil, fmt.Errorf("%w: type must be low_stock or over_stock", entities.ErrInvalidField)
+	}
+
+	offset := (page - 1) * limit
+	alerts, err := uc.alertRepo.GetAll(ctx, status, alertType, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AlertListResponse{
+		Alerts: make([]dto.AlertResponse, len(alerts)),
+		Total:  len(alerts), // In a real implementation, you'd get the total count separately
+		Page:   page,
+		Limit:  limit,
+	}
+
+	for i, alert := range alerts {
+		status := entities.AlertStatusOpen
+		if !alert.IsOpen() {
+			status = entities.AlertStatusResolved
+		}
+		response.Alerts[i] = dto.AlertResponse{
+			ID:         alert.ID,
+			ProductID:  alert.ProductID,
+			Type:       alert.Type,
+			Status:     status,
+			Stock:      alert.Stock,
+			Threshold:  alert.Threshold,
+			OpenedAt:   alert.OpenedAt,
+			ResolvedAt: alert.ResolvedAt,
+		}
+	}
+
+	return response, nil
+}
+
+// Subscribe subscribes the caller to the alerts of a category and its subcategories through a channel
+func (uc *alertUseCase) Subscribe(ctx context.Context, req *dto.AlertSubscriptionRequest) (*dto.AlertSubscriptionResponse, error) {
+	if !entities.IsValidAlertChannel(req.Channel) {
+		return nil, entities.ErrInvalidAlertChannel
+	}
+	if !uc.channels[req.Channel] {
+		return nil, entities.ErrAlertChannelDisabled
+	}
+
+	target, err := validateAlertTarget(req.Channel, req.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CategoryID != nil {
+		category, err := uc.categoryRepo.GetByID(ctx, *req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			return nil, entities.ErrCategoryNotFound
+		}
+	}
+
+	subscription := entities.NewAlertSubscription(entities.UserIDFromContext(ctx), req.CategoryID, req.Channel, target)
+	if err := uc.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return uc.subscriptionToResponse(subscription), nil
+}
+
+// ListSubscriptions lists the alert subscriptions of the caller
+func (uc *alertUseCase) ListSubscriptions(ctx context.Context) ([]dto.AlertSubscriptionResponse, error) {
+	subscriptions, err := uc.subscriptionRepo.GetByUser(ctx, entities.UserIDFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.AlertSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		response[i] = *uc.subscriptionToResponse(subscription)
+	}
+
+	return response, nil
+}
+
+// Unsubscribe deletes an alert subscription of the caller
+func (uc *alertUseCase) Unsubscribe(ctx context.Context, id uuid.UUID) error {
+	return uc.subscriptionRepo.Delete(ctx, id, entities.UserIDFromContext(ctx))
+}
+
+// GetFeed retrieves the in-app alert feed of the caller
+func (uc *alertUseCase) GetFeed(ctx context.Context, unreadOnly bool, page, limit int) (*dto.AlertFeedResponse, error) {
+	offset := (page - 1) * limit
+	items, err := uc.feedRepo.GetByUser(ctx, entities.UserIDFromContext(ctx), unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AlertFeedResponse{
+		Items: make([]dto.AlertFeedItemResponse, len(items)),
+		Total: len(items), // In a real implementation, you'd get the total count separately
+		Page:  page,
+		Limit: limit,
+	}
+
+	for i, item := range items {
+		response.Items[i] = dto.AlertFeedItemResponse{
+			ID:        item.ID,
+			AlertID:   item.AlertID,
+			EventType: item.EventType,
+			Subject:   item.Subject,
+			Message:   item.Message,
+			CreatedAt: item.CreatedAt,
+			ReadAt:    item.ReadAt,
+		}
+	}
+
+	return response, nil
+}
+
+// MarkFeedItemRead marks an item of the caller's feed read
+func (uc *alertUseCase) MarkFeedItemRead(ctx context.Context, id uuid.UUID) error {
+	return uc.feedRepo.MarkRead(ctx, id, entities.UserIDFromContext(ctx), time.Now())
+}
+
+// validateAlertTarget checks the target of a subscription against its channel and returns it normalized
+func validateAlertTarget(channel, target string) (string, error) {
+	target = strings.TrimSpace(target)
+	switch channel {
+	case entities.AlertChannelEmail:
+		address, err := mail.ParseAddress(target)
+		if err != nil {
+			return "", entities.ErrInvalidAlertTarget
+		}
+		return address.Address, nil
+	case entities.AlertChannelSlack:
+		parsed, err := url.Parse(target)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "", entities.ErrInvalidAlertTarget
+		}
+		return target, nil
+	default:
+		return "", nil
+	}
+}
+
+// subscriptionToResponse converts an alert subscription to a response DTO
+func (uc *alertUseCase) subscriptionToResponse(subscription *entities.AlertSubscription) *dto.AlertSubscriptionResponse {
+	return &dto.AlertSubscriptionResponse{
+		ID:         subscription.ID,
+		CategoryID: subscription.CategoryID,
+		Channel:    subscription.Channel,
+		Target:     subscription.Target,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
@@ -0,0 +1,334 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/domain/services"
+)
+
+// AnalyticsUseCase handles inventory analytics reports
+type AnalyticsUseCase interface {
+	ClassifyProducts(ctx context.Context, req *dto.ClassificationRequest) (*dto.ClassificationResponse, error)
+	GetStockAsOf(ctx context.Context, at time.Time) (*dto.StockAsOfResponse, error)
+	GetMovementReport(ctx context.Context, req *dto.MovementReportRequest) (*dto.MovementReportResponse, error)
+	GetDeadStock(ctx context.Context, req *dto.DeadStockRequest) (*dto.DeadStockResponse, error)
+}
+
+// Movement report groupings
+const (
+	GroupByProduct  = "product"
+	GroupByCategory = "category"
+)
+
+type analyticsUseCase struct {
+	classificationService services.ClassificationService
+	snapshotRepo          repositories.StockSnapshotRepository
+	transactionRepo       repositories.TransactionRepository
+	classificationParams  entities.ClassificationParams
+}
+
+// NewAnalyticsUseCase creates a new analytics use case
+func NewAnalyticsUseCase(
+	classificationService services.ClassificationService,
+	snapshotRepo repositories.StockSnapshotRepository,
+	transactionRepo repositories.TransactionRepository,
+	classificationParams entities.ClassificationParams) AnalyticsUseCase {
+	return &analyticsUseCase{
+		classificationService: classificationService,
+		snapshotRepo:          snapshotRepo,
+		transactionRepo:       transactionRepo,
+		classificationParams:  classificationParams,
+	}
+}
+
+// ClassifyProducts returns the ABC/XYZ classification of the stocked products.
+// The summary covers every product; the product list honours the class filters.
+func (uc *analyticsUseCase) ClassifyProducts(ctx context.Context, req *dto.ClassificationRequest) (*dto.ClassificationResponse, error) {
+	if err := validateClassFilter(req.ABCClass, req.XYZClass); err != nil {
+		return nil, err
+	}
+
+	params := uc.params(req)
+	classifications, err := uc.classificationService.Classify(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := services.ClassificationWindow(params)
+	response := &dto.ClassificationResponse{
+		WindowStart: start,
+		WindowEnd:   end,
+		PeriodDays:  params.PeriodDays,
+		AThreshold:  params.AThreshold,
+		BThreshold:  params.BThreshold,
+		XThreshold:  params.XThreshold,
+		YThreshold:  params.YThreshold,
+		Products:    make([]dto.ProductClassificationResponse, 0, len(classifications)),
+	}
+
+	summary := make(map[string]*dto.ClassSummaryResponse)
+	for _, classification := range classifications {
+		response.TotalValue += classification.ConsumptionValue
+
+		class := classification.Class()
+		if summary[class] == nil {
+			summary[class] = &dto.ClassSummaryResponse{Class: class}
+		}
+		summary[class].Products++
+		summary[class].ConsumptionValue += classification.ConsumptionValue
+		summary[class].ValueShare += classification.ValueShare
+
+		if matchesClass(classification, req.ABCClass, req.XYZClass) {
+			response.Products = append(response.Products, uc.classificationToResponse(classification))
+		}
+	}
+
+	// Keep the summary in matrix order: AX, AY, AZ, BX, ...
+	for _, abc := range []string{entities.ClassA, entities.ClassB, entities.ClassC} {
+		for _, xyz := range []string{entities.ClassX, entities.ClassY, entities.ClassZ} {
+			if s, ok := summary[abc+xyz]; ok {
+				response.Summary = append(response.Summary, *s)
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// GetStockAsOf returns the stock quantity and value per category and product at the given time.
+// Times in the future are answered with the current stock.
+func (uc *analyticsUseCase) GetStockAsOf(ctx context.Context, at time.Time) (*dto.StockAsOfResponse, error) {
+	if now := time.Now(); at.After(now) {
+		at = now
+	}
+
+	positions, err := uc.snapshotRepo.GetStockAsOf(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.StockAsOfResponse{
+		AsOf:       at,
+		Categories: []dto.CategoryStockResponse{},
+	}
+
+	// Positions arrive ordered by category, so each category is a contiguous run
+	var category *dto.CategoryStockResponse
+	for _, position := range positions {
+		if category == nil || category.CategoryID != position.CategoryID {
+			response.Categories = append(response.Categories, dto.CategoryStockResponse{
+				CategoryID:   position.CategoryID,
+				CategoryName: position.CategoryName,
+			})
+			category = &response.Categories[len(response.Categories)-1]
+		}
+
+		value := position.Value()
+		category.Products = append(category.Products, dto.ProductStockResponse{
+			ProductID: position.ProductID,
+			SKU:       position.SKU,
+			Name:      position.Name,
+			Quantity:  position.Quantity,
+			UnitCost:  position.UnitCost,
+			Value:     value,
+		})
+		category.Quantity += position.Quantity
+		category.Value += value
+		response.Quantity += position.Quantity
+		response.Value += value
+	}
+
+	return response, nil
+}
+
+// GetMovementReport returns opening and closing stock, movements, turnover and days on hand
+// per product or per category over [From, To)
+func (uc *analyticsUseCase) GetMovementReport(ctx context.Context, req *dto.MovementReportRequest) (*dto.MovementReportResponse, error) {
+	if !req.From.Before(req.To) {
+		return nil, entities.ErrInvalidDateRange
+	}
+
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = GroupByProduct
+	}
+	if groupBy != GroupByProduct && groupBy != GroupByCategory {
+		return nil, entities.ErrInvalidReportGrouping
+	}
+
+	movements, err := uc.transactionRepo.GetMovementSummary(ctx, req.From, req.To, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	days := int(req.To.Sub(req.From).Hours() / 24)
+	if days < 1 {
+		days = 1
+	}
+
+	response := &dto.MovementReportResponse{
+		From:    req.From,
+		To:      req.To,
+		Days:    days,
+		GroupBy: groupBy,
+		Rows:    []dto.MovementRowResponse{},
+	}
+
+	// Movements arrive ordered by category, so each category is a contiguous run
+	var category *dto.MovementRowResponse
+	for _, m := range movements {
+		row := dto.MovementRowResponse{
+			OpeningStock: m.OpeningStock,
+			In:           m.In,
+			Out:          m.Out,
+			Adjustments:  m.Adjustments,
+			ClosingStock: m.ClosingStock,
+			ClosingValue: float64(m.ClosingStock) * m.UnitCost,
+		}
+
+		if groupBy == GroupByCategory {
+			if category == nil || *category.CategoryID != m.CategoryID {
+				categoryID := m.CategoryID
+				response.Rows = append(response.Rows, dto.MovementRowResponse{CategoryID: &categoryID, CategoryName: m.CategoryName})
+				category = &response.Rows[len(response.Rows)-1]
+			}
+			addMovements(category, &row)
+		} else {
+			productID, categoryID := m.ProductID, m.CategoryID
+			row.ProductID = &productID
+			row.SKU = m.SKU
+			row.Name = m.Name
+			row.CategoryID = &categoryID
+			row.CategoryName = m.CategoryName
+			row.LastMovementAt = m.LastMovementAt
+			setTurnover(&row, days)
+			response.Rows = append(response.Rows, row)
+		}
+
+		addMovements(&response.Totals, &row)
+	}
+
+	if groupBy == GroupByCategory {
+		for i := range response.Rows {
+			setTurnover(&response.Rows[i], days)
+		}
+	}
+	setTurnover(&response.Totals, days)
+
+	return response, nil
+}
+
+// GetDeadStock returns the products holding stock without any movement in the last Days days
+func (uc *analyticsUseCase) GetDeadStock(ctx context.Context, req *dto.DeadStockRequest) (*dto.DeadStockResponse, error) {
+	if req.Days <= 0 {
+		return nil, entities.ErrInvalidDateRange
+	}
+
+	since := time.Now().AddDate(0, 0, -req.Days)
+	items, err := uc.transactionRepo.GetDeadStock(ctx, since, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.DeadStockResponse{
+		Days:  req.Days,
+		Since: since,
+		Items: make([]dto.DeadStockItemResponse, 0, len(items)),
+	}
+
+	for _, item := range items {
+		value := item.Value()
+		response.TotalValue += value
+		response.Items = append(response.Items, dto.DeadStockItemResponse{
+			ProductID:      item.ProductID,
+			SKU:            item.SKU,
+			Name:           item.Name,
+			CategoryID:     item.CategoryID,
+			CategoryName:   item.CategoryName,
+			Stock:          item.Stock,
+			UnitCost:       item.UnitCost,
+			Value:          value,
+			LastMovementAt: item.LastMovementAt,
+		})
+	}
+
+	return response, nil
+}
+
+// addMovements adds the stock figures of a row to an aggregate row
+func addMovements(total, row *dto.MovementRowResponse) {
+	total.OpeningStock += row.OpeningStock
+	total.In += row.In
+	total.Out += row.Out
+	total.Adjustments += row.Adjustments
+	total.ClosingStock += row.ClosingStock
+	total.ClosingValue += row.ClosingValue
+}
+
+// setTurnover derives the turnover ratio and days on hand of a row from its stock figures
+func setTurnover(row *dto.MovementRowResponse, days int) {
+	average := float64(row.OpeningStock+row.ClosingStock) / 2
+	row.Turnover = entities.InventoryTurnover(row.Out, average)
+	row.DaysOnHand = entities.DaysOnHand(row.Out, average, days)
+}
+
+// params merges the request settings over the configured defaults
+func (uc *analyticsUseCase) params(req *dto.ClassificationRequest) entities.ClassificationParams {
+	params := uc.classificationParams
+	if req.WindowDays > 0 {
+		params.WindowDays = req.WindowDays
+	}
+	if req.PeriodDays > 0 {
+		params.PeriodDays = req.PeriodDays
+	}
+	if req.AThreshold > 0 {
+		params.AThreshold = req.AThreshold
+	}
+	if req.BThreshold > 0 {
+		params.BThreshold = req.BThreshold
+	}
+	if req.XThreshold > 0 {
+		params.XThreshold = req.XThreshold
+	}
+	if req.YThreshold > 0 {
+		params.YThreshold = req.YThreshold
+	}
+	return params
+}
+
+// classificationToResponse converts a product classification to response DTO
+func (uc *analyticsUseCase) classificationToResponse(classification *entities.ProductClassification) dto.ProductClassificationResponse {
+	return dto.ProductClassificationResponse{
+		ProductID:           classification.ProductID,
+		SKU:                 classification.SKU,
+		Name:                classification.Name,
+		ConsumptionQuantity: classification.ConsumptionQuantity,
+		ConsumptionValue:    classification.ConsumptionValue,
+		ValueShare:          classification.ValueShare,
+		CumulativeShare:     classification.CumulativeShare,
+		DemandCV:            classification.DemandCV,
+		ABCClass:            classification.ABCClass,
+		XYZClass:            classification.XYZClass,
+		Class:               classification.Class(),
+	}
+}
+
+// validateClassFilter checks the optional ABC and XYZ class filters
+func validateClassFilter(abc, xyz string) error {
+	if abc != "" && !entities.IsValidABCClass(abc) {
+		return entities.ErrInvalidClass
+	}
+	if xyz != "" && !entities.IsValidXYZClass(xyz) {
+		return entities.ErrInvalidClass
+	}
+	return nil
+}
+
+// matchesClass checks a classification against the optional ABC and XYZ class filters
+func matchesClass(classification *entities.ProductClassification, abc, xyz string) bool {
+	return (abc == "" || classification.ABCClass == abc) && (xyz == "" || classification.XYZClass == xyz)
+}
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/domain/services"
+)
+
+// APIKeyUseCase handles API key administration
+type APIKeyUseCase interface {
+	CreateAPIKey(ctx context.Context, req *dto.APIKeyRequest, createdBy uuid.UUID) (*dto.APIKeyCreatedResponse, error)
+	ListAPIKeys(ctx context.Context) ([]dto.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+}
+
+type apiKeyUseCase struct {
+	apiKeyService services.APIKeyService
+	apiKeyRepo    repositories.APIKeyRepository
+}
+
+// NewAPIKeyUseCase creates a new API key use case
+func NewAPIKeyUseCase(apiKeyService services.APIKeyService, apiKeyRepo repositories.APIKeyRepository) APIKeyUseCase {
+	return &apiKeyUseCase{
+		apiKeyService: apiKeyService,
+		apiKeyRepo:    apiKeyRepo,
+	}
+}
+
+// CreateAPIKey issues a new API key; each scope is a permission the key grants
+func (uc *apiKeyUseCase) CreateAPIKey(ctx context.Context, req *dto.APIKeyRequest, createdBy uuid.UUID) (*dto.APIKeyCreatedResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", entities.ErrInvalidField)
+	}
+	if len(req.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", entities.ErrInvalidScope)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", entities.ErrInvalidField)
+	}
+
+	scopes := make([]entities.Permission, 0, len(req.Scopes))
+	seen := make(map[entities.Permission]bool)
+	for _, scope := range req.Scopes {
+		permission := entities.Permission(scope)
+		if !entities.IsValidPermission(permission) {
+			return nil, fmt.Errorf("%w: %s", entities.ErrInvalidScope, scope)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			scopes = append(scopes, permission)
+		}
+	}
+
+	key, token, err := uc.apiKeyService.Issue(ctx, name, scopes, req.ExpiresAt, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.APIKeyCreatedResponse{APIKeyResponse: *uc.apiKeyToResponse(key), Key: token}, nil
+}
+
+// ListAPIKeys lists all API keys, including revoked and expired ones
+func (uc *apiKeyUseCase) ListAPIKeys(ctx context.Context) ([]dto.APIKeyResponse, error) {
+	keys, err := uc.apiKeyRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		response[i] = *uc.apiKeyToResponse(key)
+	}
+
+	return response, nil
+}
+
+// RevokeAPIKey revokes an API key; revoked keys are kept for the record
+func (uc *apiKeyUseCase) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	return uc.apiKeyRepo.Revoke(ctx, id, time.Now())
+}
+
+// apiKeyToResponse converts an API key to a response DTO
+func (uc *apiKeyUseCase) apiKeyToResponse(key *entities.APIKey) *dto.APIKeyResponse {
+	scopes := make([]string, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return &dto.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
@@ -0,0 +1,349 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/domain/services"
+)
+
+// CategoryUseCase handles category-related operations
+type CategoryUseCase interface {
+	CreateCategory(ctx context.Context, req *dto.CategoryRequest) (*dto.CategoryResponse, error)
+	GetCategory(ctx context.Context, id uuid.UUID) (*dto.CategoryResponse, error)
+	ListCategories(ctx context.Context, includeDeleted bool) ([]dto.CategoryResponse, error)
+	PatchCategory(ctx context.Context, id uuid.UUID, req *dto.PatchRequest) (*dto.CategoryPatchResponse, error)
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	RestoreCategory(ctx context.Context, id uuid.UUID) (*dto.CategoryResponse, error)
+	ActivateCategory(ctx context.Context, id uuid.UUID) (*dto.CategoryResponse, error)
+	DeactivateCategory(ctx context.Context, id uuid.UUID) (*dto.CategoryResponse, error)
+}
+
+type categoryUseCase struct {
+	categoryRepo  repositories.CategoryRepository
+	productRepo   repositories.ProductRepository
+	audit         services.AuditService
+	authorization services.AuthorizationService
+	txManager     repositories.TxManager
+}
+
+// NewCategoryUseCase creates a new category use case
+func NewCategoryUseCase(categoryRepo repositories.CategoryRepository, productRepo repositories.ProductRepository, audit services.AuditService, authorization services.AuthorizationService, txManager repositories.TxManager) CategoryUseCase {
+	return &categoryUseCase{
+		categoryRepo:  categoryRepo,
+		productRepo:   productRepo,
+		audit:         audit,
+		authorization: authorization,
+		txManager:     txManager,
+	}
+}
+
+// CreateCategory creates a new category
+func (uc *categoryUseCase) CreateCategory(ctx context.Context, req *dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("%w: name is required", entities.ErrInvalidField)
+	}
+
+	category := entities.NewCategory(req.Name, req.Description, req.ParentID)
+	if err := uc.validateParent(ctx, category.ID, req.ParentID); err != nil {
+		return nil, err
+	}
+
+	err := uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.categoryRepo.Create(ctx, category); err != nil {
+			return err
+		}
+		return uc.audit.Record(ctx, entities.AuditEntityCategory, category.ID, entities.AuditActionCreate, nil, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.entityToResponse(category), nil
+}
+
+// GetCategory retrieves a category by ID
+func (uc *categoryUseCase) GetCategory(ctx context.Context, id uuid.UUID) (*dto.CategoryResponse, error) {
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, entities.ErrCategoryNotFound
+	}
+
+	return uc.entityToResponse(category), nil
+}
+
+// ListCategories retrieves all categories; listing soft-deleted categories too requires the permission to delete them
+func (uc *categoryUseCase) ListCategories(ctx context.Context, includeDeleted bool) ([]dto.CategoryResponse, error) {
+	if includeDeleted {
+		if err := uc.authorization.Authorize(ctx, entities.PermissionCategoryDelete); err != nil {
+			return nil, err
+		}
+	}
+
+	categories, err := uc.categoryRepo.GetAll(ctx, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.CategoryResponse, len(categories))
+	for i, category := range categories {
+		response[i] = *uc.entityToResponse(category)
+	}
+
+	return response, nil
+}
+
+// PatchCategory applies a merge patch or JSON patch to the editable fields of a category.
+// Only the fields the patch changes are validated.
+func (uc *categoryUseCase) PatchCategory(ctx context.Context, id uuid.UUID, req *dto.PatchRequest) (*dto.CategoryPatchResponse, error) {
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, entities.ErrCategoryNotFound
+	}
+
+	current := &dto.CategoryRequest{
+		Name:        category.Name,
+		Description: category.Description,
+		ParentID:    category.ParentID,
+	}
+
+	patched := &dto.CategoryRequest{}
+	changes, err := applyPatch(req, current, patched)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		switch change.Field {
+		case "name":
+			if strings.TrimSpace(patched.Name) == "" {
+				return nil, fmt.Errorf("%w: name is required", entities.ErrInvalidField)
+			}
+		case "parent_id":
+			if err := uc.validateParent(ctx, category.ID, patched.ParentID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(changes) > 0 {
+		before := *category
+		category.Name = patched.Name
+		category.Description = patched.Description
+		category.ParentID = patched.ParentID
+		category.UpdatedAt = time.Now()
+
+		if err := uc.updateCategory(ctx, &before, category, entities.AuditActionUpdate); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.CategoryPatchResponse{
+		Category: uc.entityToResponse(category),
+		Changes:  fieldChangesToResponse(changes),
+	}, nil
+}
+
+// DeleteCategory soft-deletes a category that has no products or subcategories left; deleted ones do not count
+func (uc *categoryUseCase) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if category == nil {
+		return entities.ErrCategoryNotFound
+	}
+
+	products, err := uc.productRepo.GetByCategory(ctx, id, 1, 0)
+	if err != nil {
+		return err
+	}
+
+	children, err := uc.categoryRepo.GetByParentID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(products) > 0 || len(children) > 0 {
+		return entities.ErrCategoryInUse
+	}
+
+	deletedAt := time.Now()
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.categoryRepo.Delete(ctx, id, deletedAt); err != nil {
+			return err
+		}
+		before := *category
+		category.DeletedAt = &deletedAt
+		return uc.audit.Record(ctx, entities.AuditEntityCategory, category.ID, entities.AuditActionDelete, &before, category)
+	})
+}
+
+// RestoreCategory undeletes a soft-deleted category; its parent must not be deleted
+func (uc *categoryUseCase) RestoreCategory(ctx context.Context, id uuid.UUID) (*dto.CategoryResponse, error) {
+	category, err := uc.categoryRepo.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, entities.ErrCategoryNotFound
+	}
+
+	if !category.IsDeleted() {
+		return uc.entityToResponse(category), nil
+	}
+
+	if category.ParentID != nil {
+		parent, err := uc.categoryRepo.GetByID(ctx, *category.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, entities.ErrParentDeleted
+		}
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.categoryRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		before := *category
+		category.DeletedAt = nil
+		return uc.audit.Record(ctx, entities.AuditEntityCategory, category.ID, entities.AuditActionRestore, &before, category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.entityToResponse(category), nil
+}
+
+// ActivateCategory marks a category as active
+func (uc *categoryUseCase) ActivateCategory(ctx context.Context, id uuid.UUID) (*dto.CategoryResponse, error) {
+	return uc.setCategoryStatus(ctx, id, entities.AuditActionActivate)
+}
+
+// DeactivateCategory marks a category as inactive
+func (uc *categoryUseCase) DeactivateCategory(ctx context.Context, id uuid.UUID) (*dto.CategoryResponse, error) {
+	return uc.setCategoryStatus(ctx, id, entities.AuditActionDeactivate)
+}
+
+// setCategoryStatus activates or deactivates a category; a category already in that status is left unchanged
+func (uc *categoryUseCase) setCategoryStatus(ctx context.Context, id uuid.UUID, action string) (*dto.CategoryResponse, error) {
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, entities.ErrCategoryNotFound
+	}
+
+	before := *category
+	if action == entities.AuditActionActivate {
+		category.Activate()
+	} else {
+		category.Deactivate()
+	}
+
+	if category.Status != before.Status {
+		if err := uc.updateCategory(ctx, &before, category, action); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.entityToResponse(category), nil
+}
+
+// updateCategory writes an edited category and its audit entry in one transaction
+func (uc *categoryUseCase) updateCategory(ctx context.Context, before, category *entities.Category, action string) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.categoryRepo.Update(ctx, category); err != nil {
+			return err
+		}
+		return uc.audit.Record(ctx, entities.AuditEntityCategory, category.ID, action, before, category)
+	})
+}
+
+// validateParent checks that a new parent exists and is not the category itself or one of its descendants
+func (uc *categoryUseCase) validateParent(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) error {
+	for ancestorID := parentID; ancestorID != nil; {
+		if *ancestorID == id {
+			return fmt.Errorf("%w: parent_id would create a cycle", entities.ErrInvalidField)
+		}
+
+		ancestor, err := uc.categoryRepo.GetByID(ctx, *ancestorID)
+		if err != nil {
+			return err
+		}
+
+		if ancestor == nil {
+			return fmt.Errorf("%w: parent_id: %v", entities.ErrInvalidField, entities.ErrCategoryNotFound)
+		}
+
+		ancestorID = ancestor.ParentID
+	}
+
+	return nil
+}
+
+// entityToResponse converts a category entity to a response DTO
+func (uc *categoryUseCase) entityToResponse(category *entities.Category) *dto.CategoryResponse {
+	return &dto.CategoryResponse{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		ParentID:    category.ParentID,
+		Status:      category.Status,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+		DeletedAt:   category.DeletedAt,
+	}
+}
+
+// withSubcategories returns the categories together with every category below them
+func withSubcategories(ctx context.Context, categoryRepo repositories.CategoryRepository, categoryIDs []uuid.UUID) ([]uuid.UUID, error) {
+	categories, err := categoryRepo.GetAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[uuid.UUID][]uuid.UUID)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var result []uuid.UUID
+	queue := append([]uuid.UUID(nil), categoryIDs...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+		queue = append(queue, children[id]...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/domain/services"
+)
+
+// ForecastUseCase handles demand forecasting operations
+type ForecastUseCase interface {
+	GetProductForecast(ctx context.Context, productID uuid.UUID, req *dto.ForecastRequest) (*dto.ForecastResponse, error)
+	RunBatch(ctx context.Context, req *dto.ForecastBatchRequest) (*dto.ForecastBatchResponse, error)
+}
+
+type forecastUseCase struct {
+	forecastService services.ForecastService
+	productRepo     repositories.ProductRepository
+	defaults        entities.ForecastParams
+	audit           services.AuditService
+	txManager       repositories.TxManager
+}
+
+// NewForecastUseCase creates a new forecast use case
+func NewForecastUseCase(forecastService services.ForecastService, productRepo repositories.ProductRepository, defaults entities.ForecastParams, audit services.AuditService, txManager repositories.TxManager) ForecastUseCase {
+	return &forecastUseCase{
+		forecastService: forecastService,
+		productRepo:     productRepo,
+		defaults:        defaults,
+		audit:           audit,
+		txManager:       txManager,
+	}
+}
+
+// GetProductForecast forecasts the demand of a product
+func (uc *forecastUseCase) GetProductForecast(ctx context.Context, productID uuid.UUID, req *dto.ForecastRequest) (*dto.ForecastResponse, error) {
+	params, err := uc.params(req)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := uc.forecastService.ForecastProduct(ctx, productID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.forecastToResponse(forecast), nil
+}
+
+// RunBatch forecasts every stocked product and, when requested, sets each product's
+// MinStock to its recommended safety stock. Replenishment adds lead time demand on top
+// of MinStock, so its reorder point then matches the forecast's.
+func (uc *forecastUseCase) RunBatch(ctx context.Context, req *dto.ForecastBatchRequest) (*dto.ForecastBatchResponse, error) {
+	params, err := uc.params(&req.ForecastRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts, err := uc.forecastService.ForecastAll(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := uc.productRepo.GetStockedProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	minStock := make(map[uuid.UUID]int, len(products))
+	for _, product := range products {
+		minStock[product.ID] = product.MinStock
+	}
+
+	response := &dto.ForecastBatchResponse{
+		Results: make([]dto.ForecastBatchResult, 0, len(forecasts)),
+	}
+
+	for _, forecast := range forecasts {
+		result := dto.ForecastBatchResult{
+			ForecastResponse: *uc.forecastToResponse(forecast),
+			PreviousMinStock: minStock[forecast.ProductID],
+		}
+		result.DailyForecast = nil
+
+		if req.UpdateMinStock && forecast.SafetyStock != result.PreviousMinStock {
+			if err := uc.updateMinStock(ctx, forecast.ProductID, result.PreviousMinStock, forecast.SafetyStock); err != nil {
+				return nil, err
+			}
+			result.MinStockUpdated = true
+			response.Updated++
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// updateMinStock writes a product's new minimum stock and its audit entry in one transaction
+func (uc *forecastUseCase) updateMinStock(ctx context.Context, productID uuid.UUID, previous, minStock int) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.UpdateMinStock(ctx, productID, minStock); err != nil {
+			return err
+		}
+		return uc.audit.Record(ctx, entities.AuditEntityProduct, productID, entities.AuditActionUpdate,
+			map[string]int{"min_stock": previous}, map[string]int{"min_stock": minStock})
+	})
+}
+
+// params merges the request settings over the configured defaults, rejecting ranges too large to hold in memory
+func (uc *forecastUseCase) params(req *dto.ForecastRequest) (entities.ForecastParams, error) {
+	if req.HistoryDays > entities.MaxForecastHistoryDays || req.Window > entities.MaxForecastWindow ||
+		req.Horizon > entities.MaxForecastHorizon {
+		return entities.ForecastParams{}, entities.ErrForecastRangeTooLarge
+	}
+
+	params := uc.defaults
+	if req.Model != "" {
+		params.Model = req.Model
+	}
+	if req.HistoryDays > 0 {
+		params.HistoryDays = req.HistoryDays
+	}
+	if req.Window > 0 {
+		params.Window = req.Window
+	}
+	if req.Horizon > 0 {
+		params.Horizon = req.Horizon
+	}
+	if req.ServiceLevel > 0 {
+		params.ServiceLevel = req.ServiceLevel
+	}
+	return params, nil
+}
+
+// forecastToResponse converts a demand forecast to response DTO
+func (uc *forecastUseCase) forecastToResponse(forecast *entities.DemandForecast) *dto.ForecastResponse {
+	return &dto.ForecastResponse{
+		ProductID:          forecast.ProductID,
+		Model:              forecast.Model,
+		HistoryStart:       forecast.HistoryStart,
+		HistoryEnd:         forecast.HistoryEnd,
+		AverageDailyDemand: forecast.AverageDailyDemand,
+		DemandStdDev:       forecast.DemandStdDev,
+		LeadTimeDays:       forecast.LeadTimeDays,
+		ServiceLevel:       forecast.ServiceLevel,
+		SafetyStock:        forecast.SafetyStock,
+		ReorderPoint:       forecast.ReorderPoint,
+		DailyForecast:      forecast.DailyForecast,
+	}
+}
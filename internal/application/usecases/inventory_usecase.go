@@ -2,6 +2,8 @@ package usecases
 
 import (
 	"context"
+	"errors"
+
 	"github.com/google/uuid"
 	"inventory-app/internal/application/dto"
 	"inventory-app/internal/domain/entities"
@@ -14,20 +16,31 @@ type InventoryUseCase interface {
 	StockIn(ctx context.Context, req *dto.StockMovementRequest, userID uuid.UUID) error
 	StockOut(ctx context.Context, req *dto.StockMovementRequest, userID uuid.UUID) error
 	AdjustStock(ctx context.Context, req *dto.StockAdjustmentRequest, userID uuid.UUID) error
+	ApplyMovements(ctx context.Context, req *dto.MovementBatchRequest, userID uuid.UUID) (*dto.MovementBatchResponse, error)
 	GetTransactionHistory(ctx context.Context, productID uuid.UUID, page, limit int) (*dto.TransactionListResponse, error)
 	GetAllTransactions(ctx context.Context, page, limit int) (*dto.TransactionListResponse, error)
+	ExportTransactions(ctx context.Context, req *dto.TransactionExportRequest) (dto.TransactionExport, error)
+	ReserveStock(ctx context.Context, req *dto.ReservationRequest, userID uuid.UUID) (*dto.ReservationResponse, error)
+	ReleaseReservation(ctx context.Context, id uuid.UUID) (*dto.ReservationResponse, error)
+	GetReservations(ctx context.Context, productID uuid.UUID) ([]dto.ReservationResponse, error)
 }
 
 type inventoryUseCase struct {
 	inventoryService services.InventoryService
 	transactionRepo  repositories.TransactionRepository
+	productRepo      repositories.ProductRepository
+	reservationRepo  repositories.ReservationRepository
+	authorization    services.AuthorizationService
 }
 
 // NewInventoryUseCase creates a new inventory use case
-func NewInventoryUseCase(inventoryService services.InventoryService, transactionRepo repositories.TransactionRepository) InventoryUseCase {
+func NewInventoryUseCase(inventoryService services.InventoryService, transactionRepo repositories.TransactionRepository, productRepo repositories.ProductRepository, reservationRepo repositories.ReservationRepository, authorization services.AuthorizationService) InventoryUseCase {
 	return &inventoryUseCase{
 		inventoryService: inventoryService,
 		transactionRepo:  transactionRepo,
+		productRepo:      productRepo,
+		reservationRepo:  reservationRepo,
+		authorization:    authorization,
 	}
 }
 
@@ -43,9 +56,76 @@ func (uc *inventoryUseCase) StockOut(ctx context.Context, req *dto.StockMovement
 
 // AdjustStock adjusts stock to a specific quantity
 func (uc *inventoryUseCase) AdjustStock(ctx context.Context, req *dto.StockAdjustmentRequest, userID uuid.UUID) error {
+	if err := uc.authorization.Authorize(ctx, entities.PermissionStockAdjust); err != nil {
+		return err
+	}
+
 	return uc.inventoryService.AdjustStock(ctx, req.ProductID, req.NewQuantity, req.Notes, userID)
 }
 
+// ApplyMovements applies a batch of stock movements all or nothing and reports the outcome of every line.
+// Every line type in the batch needs its own permission: stock in, stock out or stock adjustment.
+func (uc *inventoryUseCase) ApplyMovements(ctx context.Context, req *dto.MovementBatchRequest, userID uuid.UUID) (*dto.MovementBatchResponse, error) {
+	movements := make([]*entities.StockMovement, len(req.Lines))
+	required := make(map[entities.Permission]bool)
+	for i, line := range req.Lines {
+		movement := &entities.StockMovement{ProductID: line.ProductID, Type: line.Type, Quantity: line.Quantity, Notes: line.Notes}
+		switch line.Type {
+		case entities.TransactionTypeIn:
+			required[entities.PermissionStockIn] = true
+		case entities.TransactionTypeOut:
+			required[entities.PermissionStockOut] = true
+		case entities.TransactionTypeAdjustment:
+			required[entities.PermissionStockAdjust] = true
+			// A missing target level is rejected rather than read as zero
+			movement.Quantity = -1
+			if line.NewQuantity != nil {
+				movement.Quantity = *line.NewQuantity
+			}
+		}
+		movements[i] = movement
+	}
+
+	for _, permission := range []entities.Permission{entities.PermissionStockIn, entities.PermissionStockOut, entities.PermissionStockAdjust} {
+		if !required[permission] {
+			continue
+		}
+		if err := uc.authorization.Authorize(ctx, permission); err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := uc.inventoryService.ApplyMovements(ctx, req.Reference, movements, userID)
+	if err != nil && !errors.Is(err, entities.ErrMovementBatchRejected) {
+		return nil, err
+	}
+
+	response := &dto.MovementBatchResponse{
+		Reference: req.Reference,
+		Applied:   err == nil,
+		Lines:     make([]dto.MovementLineResponse, len(results)),
+	}
+
+	for i, result := range results {
+		line := dto.MovementLineResponse{
+			Line:        result.Line,
+			ProductID:   result.Movement.ProductID,
+			Type:        result.Movement.Type,
+			Quantity:    result.Movement.Quantity,
+			StockBefore: result.StockBefore,
+			StockAfter:  result.StockAfter,
+		}
+		if result.Err != nil {
+			line.Error = result.Err.Error()
+		} else if response.Applied {
+			line.TransactionID = &result.Transaction.ID
+		}
+		response.Lines[i] = line
+	}
+
+	return response, nil
+}
+
 // GetTransactionHistory retrieves transaction history for a product
 func (uc *inventoryUseCase) GetTransactionHistory(ctx context.Context, productID uuid.UUID, page, limit int) (*dto.TransactionListResponse, error) {
 	offset := (page - 1) * limit
@@ -90,6 +170,102 @@ func (uc *inventoryUseCase) GetAllTransactions(ctx context.Context, page, limit
 	return response, nil
 }
 
+// ExportTransactions prepares a streaming export of the ledger over a date range, optionally restricted to one type
+func (uc *inventoryUseCase) ExportTransactions(ctx context.Context, req *dto.TransactionExportRequest) (dto.TransactionExport, error) {
+	if req.Type != "" && !entities.IsValidTransactionType(req.Type) {
+		return nil, entities.ErrInvalidTransactionType
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return nil, entities.ErrInvalidDateRange
+	}
+
+	return func(fn func(*dto.TransactionResponse) error) error {
+		return uc.transactionRepo.ForEach(ctx, req.From, req.To, req.Type, func(transaction *entities.Transaction) error {
+			response := uc.entityToResponse(transaction)
+			return fn(&response)
+		})
+	}, nil
+}
+
+// ReserveStock sets stock aside for an order that has not shipped yet
+func (uc *inventoryUseCase) ReserveStock(ctx context.Context, req *dto.ReservationRequest, userID uuid.UUID) (*dto.ReservationResponse, error) {
+	if req.Quantity <= 0 {
+		return nil, entities.ErrInvalidQuantity
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	if product.IsVariantParent() {
+		return nil, entities.ErrVariantParentStock
+	}
+
+	reservation := entities.NewReservation(req.ProductID, req.Quantity, req.Reference, req.ExpiresAt, userID)
+	if err := uc.reservationRepo.Create(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	return uc.reservationToResponse(reservation), nil
+}
+
+// ReleaseReservation releases reserved stock
+func (uc *inventoryUseCase) ReleaseReservation(ctx context.Context, id uuid.UUID) (*dto.ReservationResponse, error) {
+	reservation, err := uc.reservationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation == nil {
+		return nil, entities.ErrReservationNotFound
+	}
+
+	if err := reservation.Release(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reservationRepo.UpdateStatus(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	return uc.reservationToResponse(reservation), nil
+}
+
+// GetReservations retrieves the reservations of a product
+func (uc *inventoryUseCase) GetReservations(ctx context.Context, productID uuid.UUID) ([]dto.ReservationResponse, error) {
+	reservations, err := uc.reservationRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ReservationResponse, len(reservations))
+	for i, reservation := range reservations {
+		response[i] = *uc.reservationToResponse(reservation)
+	}
+
+	return response, nil
+}
+
+// reservationToResponse converts reservation entity to response DTO
+func (uc *inventoryUseCase) reservationToResponse(reservation *entities.Reservation) *dto.ReservationResponse {
+	return &dto.ReservationResponse{
+		ID:        reservation.ID,
+		ProductID: reservation.ProductID,
+		Quantity:  reservation.Quantity,
+		Reference: reservation.Reference,
+		Status:    reservation.Status,
+		ExpiresAt: reservation.ExpiresAt,
+		CreatedBy: reservation.CreatedBy,
+		CreatedAt: reservation.CreatedAt,
+		UpdatedAt: reservation.UpdatedAt,
+	}
+}
+
 // entityToResponse converts transaction entity to response DTO
 func (uc *inventoryUseCase) entityToResponse(transaction *entities.Transaction) dto.TransactionResponse {
 	return dto.TransactionResponse{
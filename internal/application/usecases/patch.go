@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/pkg/jsonpatch"
+)
+
+// applyPatch applies a patch to the JSON form of current and decodes the result into patched,
+// which must point to a zero value of the same type. It returns the top-level fields whose value
+// changed, so callers only validate what the client touched.
+func applyPatch(req *dto.PatchRequest, current, patched interface{}) ([]entities.FieldChange, error) {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	var before map[string]interface{}
+	if err := json.Unmarshal(data, &before); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	var after interface{}
+	switch req.Format {
+	case dto.PatchFormatMerge:
+		var patch interface{}
+		if err := json.Unmarshal(req.Document, &patch); err != nil {
+			return nil, fmt.Errorf("%w: %v", entities.ErrInvalidPatch, err)
+		}
+		after = jsonpatch.MergePatch(before, patch)
+	case dto.PatchFormatJSON:
+		ops, err := jsonpatch.Decode(req.Document)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", entities.ErrInvalidPatch, err)
+		}
+		after, err = jsonpatch.Apply(before, ops)
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, fmt.Errorf("%w: %v", entities.ErrPatchTestFailed, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", entities.ErrInvalidPatch, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", entities.ErrInvalidPatch, req.Format)
+	}
+
+	object, ok := after.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: the patched document must be an object", entities.ErrInvalidPatch)
+	}
+
+	data, err = json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode patched document: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patched); err != nil {
+		return nil, fmt.Errorf("%w: %v", entities.ErrInvalidField, err)
+	}
+
+	return entities.DiffDocuments(before, object), nil
+}
+
+// fieldChangesToResponse converts field changes to their response form
+func fieldChangesToResponse(changes []entities.FieldChange) []dto.FieldChangeResponse {
+	response := make([]dto.FieldChangeResponse, len(changes))
+	for i, change := range changes {
+		response[i] = dto.FieldChangeResponse{Field: change.Field, From: change.From, To: change.To}
+	}
+	return response
+}
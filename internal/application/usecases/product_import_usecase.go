@@ -0,0 +1,522 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/domain/services"
+	"inventory-app/internal/domain/valueobjects"
+)
+
+const (
+	// importBatchSize is the number of rows looked up and written per statement
+	importBatchSize = 500
+	// importMaxErrors caps the row errors listed in the response; Failed still counts all of them
+	importMaxErrors = 1000
+)
+
+// importColumns are the CSV columns understood by the importer, named after the product request fields.
+// A category can be given by ID, by name or by its "Parent/Child" path.
+var importColumns = []string{"sku", "name", "description", "category_id", "category", "price", "cost", "min_stock", "max_stock"}
+
+// errImportRejected rolls back an all-or-nothing import that has invalid rows
+var errImportRejected = errors.New("import rejected")
+
+// ProductImportUseCase handles bulk product imports
+type ProductImportUseCase interface {
+	ImportProducts(ctx context.Context, r io.Reader, req *dto.ImportRequest) (*dto.ImportResponse, error)
+}
+
+type productImportUseCase struct {
+	productRepo  repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+	txManager    repositories.TxManager
+	audit        services.AuditService
+	events       services.EventService
+}
+
+// NewProductImportUseCase creates a new product import use case
+func NewProductImportUseCase(productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository, txManager repositories.TxManager, audit services.AuditService, events services.EventService) ProductImportUseCase {
+	return &productImportUseCase{
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
+		audit:        audit,
+		events:       events,
+	}
+}
+
+// importRow is a validated row waiting to be written
+type importRow struct {
+	row int
+	req dto.ProductRequest
+}
+
+// productImport holds the state of one import while the file is streamed
+type productImport struct {
+	mode       string
+	dryRun     bool
+	columns    map[string]int
+	categories *categoryIndex
+	seen       map[string]int
+	batch      []importRow
+	response   *dto.ImportResponse
+}
+
+// ImportProducts reads products from CSV and upserts them by SKU.
+// Rows are validated and written in batches as the file is read, so large files are never held in memory.
+// In all-or-nothing mode the import runs in one transaction that is rolled back when any row fails;
+// in best-effort mode valid rows are committed batch by batch. A dry run validates without writing.
+func (uc *productImportUseCase) ImportProducts(ctx context.Context, r io.Reader, req *dto.ImportRequest) (*dto.ImportResponse, error) {
+	mode := req.Mode
+	if mode == "" {
+		mode = entities.ImportModeAllOrNothing
+	}
+	if !entities.IsValidImportMode(mode) {
+		return nil, entities.ErrInvalidImportMode
+	}
+
+	categories, err := uc.categoryIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &productImport{
+		mode:       mode,
+		dryRun:     req.DryRun,
+		categories: categories,
+		seen:       make(map[string]int),
+		response:   &dto.ImportResponse{Mode: mode, DryRun: req.DryRun, Errors: []dto.ImportRowError{}},
+	}
+
+	if req.DryRun || mode == entities.ImportModeBestEffort {
+		if err := uc.importRows(ctx, r, imp); err != nil {
+			return nil, err
+		}
+		imp.response.Committed = !req.DryRun
+		return imp.response, nil
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.importRows(ctx, r, imp); err != nil {
+			return err
+		}
+		if imp.response.Failed > 0 {
+			return errImportRejected
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRejected) {
+		return nil, err
+	}
+
+	imp.response.Committed = err == nil
+	return imp.response, nil
+}
+
+// importRows streams the CSV records, validating each row and flushing full batches
+func (uc *productImportUseCase) importRows(ctx context.Context, r io.Reader, imp *productImport) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		switch {
+		case err == io.EOF:
+			return fmt.Errorf("%w: file is empty", entities.ErrInvalidImportFile)
+		case errors.As(err, &parseErr):
+			return fmt.Errorf("%w: %v", entities.ErrInvalidImportFile, err)
+		default:
+			return fmt.Errorf("failed to read import file: %w", err)
+		}
+	}
+
+	imp.columns, err = parseImportHeader(header)
+	if err != nil {
+		return err
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return fmt.Errorf("failed to read import file: %w", err)
+			}
+			imp.response.Rows++
+			imp.fail(parseErr.StartLine, "", "", parseErr.Err.Error())
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		imp.response.Rows++
+		if len(record) != len(header) {
+			imp.fail(line, "", "", fmt.Sprintf("expected %d fields, got %d", len(header), len(record)))
+			continue
+		}
+
+		if row, ok := imp.parseRow(line, record); ok {
+			imp.batch = append(imp.batch, row)
+		}
+
+		if len(imp.batch) >= importBatchSize {
+			if err := uc.flush(ctx, imp); err != nil {
+				return err
+			}
+		}
+	}
+
+	return uc.flush(ctx, imp)
+}
+
+// flush writes the pending batch, or only counts it when nothing may be written
+func (uc *productImportUseCase) flush(ctx context.Context, imp *productImport) error {
+	if len(imp.batch) == 0 {
+		return nil
+	}
+	defer func() { imp.batch = imp.batch[:0] }()
+
+	skus := make([]string, len(imp.batch))
+	for i, row := range imp.batch {
+		skus[i] = row.req.SKU
+	}
+
+	existing, err := uc.productRepo.GetBySKUs(ctx, skus)
+	if err != nil {
+		return err
+	}
+
+	bySKU := make(map[string]*entities.Product, len(existing))
+	for _, product := range existing {
+		bySKU[product.SKU] = product
+	}
+
+	products := make([]*entities.Product, len(imp.batch))
+	before := make([]*entities.Product, len(imp.batch))
+	for i, row := range imp.batch {
+		if existing := bySKU[row.req.SKU]; existing != nil {
+			previous := *existing
+			before[i] = &previous
+		}
+		products[i] = importProduct(bySKU[row.req.SKU], &row.req)
+	}
+
+	// An all-or-nothing import that already failed keeps validating the rest of the file without writing
+	if imp.dryRun || (imp.mode == entities.ImportModeAllOrNothing && imp.response.Failed > 0) {
+		for _, row := range imp.batch {
+			imp.count(bySKU[row.req.SKU] != nil)
+		}
+		return nil
+	}
+
+	if err := uc.upsert(ctx, products, before); err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		// Retry row by row so the error is reported against the row that caused it; an all-or-nothing
+		// import then rolls back with the failure in its report
+		for i, row := range imp.batch {
+			if err := uc.upsert(ctx, products[i:i+1], before[i:i+1]); err != nil {
+				imp.fail(row.row, row.req.SKU, "", err.Error())
+				continue
+			}
+			imp.count(bySKU[row.req.SKU] != nil)
+		}
+		return nil
+	}
+
+	for _, row := range imp.batch {
+		imp.count(bySKU[row.req.SKU] != nil)
+	}
+
+	return nil
+}
+
+// upsert writes imported products with their audit entries and events in one transaction, or in a savepoint of
+// the import's transaction; before holds each product as it was, or nil for a new one
+func (uc *productImportUseCase) upsert(ctx context.Context, products, before []*entities.Product) error {
+	return uc.txManager.WithinSavepoint(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.UpsertBySKU(ctx, products); err != nil {
+			return err
+		}
+
+		for i, product := range products {
+			action := entities.AuditActionUpdate
+			if before[i] == nil {
+				action = entities.AuditActionCreate
+			}
+			if err := uc.audit.Record(ctx, entities.AuditEntityProduct, product.ID, action, before[i], product); err != nil {
+				return err
+			}
+			if err := uc.events.ProductSaved(ctx, before[i], product); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// importProduct applies an imported row to the existing product, or creates a new one
+func importProduct(product *entities.Product, req *dto.ProductRequest) *entities.Product {
+	if product == nil {
+		return entities.NewProduct(req.SKU, req.Name, req.Description, req.CategoryID, req.Price, req.Cost, req.MinStock, req.MaxStock)
+	}
+
+	product.Name = req.Name
+	product.Description = req.Description
+	product.CategoryID = req.CategoryID
+	product.Price = req.Price
+	product.Cost = req.Cost
+	product.MinStock = req.MinStock
+	product.MaxStock = req.MaxStock
+	product.UpdatedAt = time.Now()
+	return product
+}
+
+// parseRow validates a record and converts it to a product request, reporting every invalid field
+func (imp *productImport) parseRow(line int, record []string) (importRow, bool) {
+	value := func(column string) string {
+		if i, ok := imp.columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := importRow{row: line}
+	req := &row.req
+	valid := true
+	fail := func(field, message string) {
+		imp.addError(line, req.SKU, field, message)
+		valid = false
+	}
+
+	req.SKU = value("sku")
+	if _, err := valueobjects.NewSKU(req.SKU); err != nil {
+		fail("sku", err.Error())
+	} else if first, ok := imp.seen[req.SKU]; ok {
+		fail("sku", fmt.Sprintf("duplicate SKU, first seen in row %d", first))
+	} else {
+		imp.seen[req.SKU] = line
+	}
+
+	req.Name = value("name")
+	if req.Name == "" {
+		fail("name", "name is required")
+	}
+	req.Description = value("description")
+
+	if id := value("category_id"); id != "" {
+		categoryID, err := uuid.Parse(id)
+		if err != nil || !imp.categories.exists(categoryID) {
+			fail("category_id", entities.ErrCategoryNotFound.Error())
+		}
+		req.CategoryID = categoryID
+	} else if name := value("category"); name != "" {
+		categoryID, ok := imp.categories.resolve(name)
+		if !ok {
+			fail("category", entities.ErrCategoryNotFound.Error())
+		}
+		req.CategoryID = categoryID
+	} else {
+		fail("category", "category or category_id is required")
+	}
+
+	var err error
+	if req.Price, err = parseImportFloat(value("price")); err != nil {
+		fail("price", err.Error())
+	}
+	if req.Cost, err = parseImportFloat(value("cost")); err != nil {
+		fail("cost", err.Error())
+	}
+	if req.MinStock, err = parseImportInt(value("min_stock")); err != nil {
+		fail("min_stock", err.Error())
+	}
+	if req.MaxStock, err = parseImportInt(value("max_stock")); err != nil {
+		fail("max_stock", err.Error())
+	}
+
+	if !valid {
+		imp.response.Failed++
+	}
+	return row, valid
+}
+
+// fail records an error for a row that could not be imported
+func (imp *productImport) fail(row int, sku, field, message string) {
+	imp.response.Failed++
+	imp.addError(row, sku, field, message)
+}
+
+// addError adds a row error to the response unless the error list is full
+func (imp *productImport) addError(row int, sku, field, message string) {
+	if len(imp.response.Errors) >= importMaxErrors {
+		imp.response.ErrorsTruncated = true
+		return
+	}
+	imp.response.Errors = append(imp.response.Errors, dto.ImportRowError{Row: row, SKU: sku, Field: field, Message: message})
+}
+
+// count records a row that was, or in a dry run would have been, written
+func (imp *productImport) count(updated bool) {
+	if updated {
+		imp.response.Updated++
+	} else {
+		imp.response.Created++
+	}
+}
+
+// parseImportHeader maps the header to column positions and checks the required columns
+func parseImportHeader(header []string) (map[string]int, error) {
+	known := make(map[string]bool, len(importColumns))
+	for _, column := range importColumns {
+		known[column] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Spreadsheet exports often start with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !known[name] {
+			continue
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: column %q appears more than once", entities.ErrInvalidImportFile, name)
+		}
+		columns[name] = i
+	}
+
+	var missing []string
+	for _, column := range []string{"sku", "name", "price", "cost"} {
+		if _, ok := columns[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+	_, hasID := columns["category_id"]
+	_, hasName := columns["category"]
+	if !hasID && !hasName {
+		missing = append(missing, "category or category_id")
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing columns %s", entities.ErrInvalidImportFile, strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// parseImportFloat parses a required non-negative amount
+func parseImportFloat(value string) (float64, error) {
+	if value == "" {
+		return 0, errors.New("value is required")
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.New("must be a number")
+	}
+	if f < 0 {
+		return 0, errors.New("must not be negative")
+	}
+	return f, nil
+}
+
+// parseImportInt parses an optional non-negative quantity; empty means zero
+func parseImportInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("must be a whole number")
+	}
+	if n < 0 {
+		return 0, errors.New("must not be negative")
+	}
+	return n, nil
+}
+
+// categoryIndex resolves category IDs, names and "Parent/Child" paths, case-insensitively
+type categoryIndex struct {
+	ids   map[uuid.UUID]bool
+	names map[string]uuid.UUID
+	paths map[string]uuid.UUID
+}
+
+// categoryIndex loads every category once for the duration of an import
+func (uc *productImportUseCase) categoryIndex(ctx context.Context) (*categoryIndex, error) {
+	categories, err := uc.categoryRepo.GetAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*entities.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	index := &categoryIndex{
+		ids:   make(map[uuid.UUID]bool, len(categories)),
+		names: make(map[string]uuid.UUID, len(categories)),
+		paths: make(map[string]uuid.UUID, len(categories)),
+	}
+
+	for _, category := range categories {
+		index.ids[category.ID] = true
+		index.names[strings.ToLower(category.Name)] = category.ID
+
+		// Walk up to the root; the depth guard stops on a parent cycle
+		path := []string{strings.ToLower(category.Name)}
+		for parent := category.ParentID; parent != nil && len(path) <= len(categories); {
+			p, ok := byID[*parent]
+			if !ok {
+				break
+			}
+			path = append([]string{strings.ToLower(p.Name)}, path...)
+			parent = p.ParentID
+		}
+		index.paths[strings.Join(path, "/")] = category.ID
+	}
+
+	return index, nil
+}
+
+// exists checks if a category ID is known
+func (i *categoryIndex) exists(id uuid.UUID) bool {
+	return i.ids[id]
+}
+
+// resolve looks a category up by name, or by full path when the value contains a slash
+func (i *categoryIndex) resolve(value string) (uuid.UUID, bool) {
+	if !strings.Contains(value, "/") {
+		id, ok := i.names[strings.ToLower(value)]
+		return id, ok
+	}
+
+	parts := strings.Split(value, "/")
+	for j, part := range parts {
+		parts[j] = strings.ToLower(strings.TrimSpace(part))
+	}
+	id, ok := i.paths[strings.Join(parts, "/")]
+	return id, ok
+}
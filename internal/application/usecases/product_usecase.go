@@ -2,11 +2,18 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"inventory-app/internal/application/dto"
 	"inventory-app/internal/domain/entities"
 	"inventory-app/internal/domain/repositories"
 	"inventory-app/internal/domain/services"
+	"inventory-app/internal/domain/valueobjects"
 )
 
 // ProductUseCase handles product-related operations
@@ -14,36 +21,70 @@ type ProductUseCase interface {
 	CreateProduct(ctx context.Context, req *dto.ProductRequest) (*dto.ProductResponse, error)
 	GetProduct(ctx context.Context, id uuid.UUID) (*dto.ProductResponse, error)
 	GetProductBySKU(ctx context.Context, sku string) (*dto.ProductResponse, error)
-	UpdateProduct(ctx context.Context, id uuid.UUID, req *dto.ProductRequest) (*dto.ProductResponse, error)
-	DeleteProduct(ctx context.Context, id uuid.UUID) error
-	ListProducts(ctx context.Context, page, limit int) (*dto.ProductListResponse, error)
-	SearchProducts(ctx context.Context, query string, page, limit int) (*dto.ProductListResponse, error)
+	UpdateProduct(ctx context.Context, id uuid.UUID, req *dto.ProductRequest, version int) (*dto.ProductResponse, error)
+	PatchProduct(ctx context.Context, id uuid.UUID, req *dto.PatchRequest, version int) (*dto.ProductPatchResponse, error)
+	DeleteProduct(ctx context.Context, id uuid.UUID, version int) error
+	RestoreProduct(ctx context.Context, id uuid.UUID, version int) (*dto.ProductResponse, error)
+	ActivateProduct(ctx context.Context, id uuid.UUID, version int) (*dto.ProductResponse, error)
+	DeactivateProduct(ctx context.Context, id uuid.UUID, version int) (*dto.ProductResponse, error)
+	ListProducts(ctx context.Context, filter *dto.ProductFilter, page, limit int) (*dto.ProductListResponse, error)
+	ExportProducts(ctx context.Context, filter *dto.ProductFilter) (dto.ProductExport, error)
+	SearchProducts(ctx context.Context, req *dto.ProductSearchRequest) (*dto.ProductSearchResponse, error)
 	GetLowStockProducts(ctx context.Context) ([]dto.ProductResponse, error)
+	GenerateVariants(ctx context.Context, parentID uuid.UUID, req *dto.GenerateVariantsRequest) (*dto.ProductResponse, error)
+	ApplyVariantPolicy(ctx context.Context, parentID uuid.UUID, req *dto.VariantPolicyRequest) (*dto.ProductResponse, error)
 }
 
 type productUseCase struct {
-	productRepo      repositories.ProductRepository
-	categoryRepo     repositories.CategoryRepository
-	inventoryService services.InventoryService
+	productRepo        repositories.ProductRepository
+	categoryRepo       repositories.CategoryRepository
+	inventoryService   services.InventoryService
+	classificationRepo repositories.ClassificationRepository
+	priceBuckets       []float64
+	authorization      services.AuthorizationService
+	audit              services.AuditService
+	events             services.EventService
+	txManager          repositories.TxManager
 }
 
-// NewProductUseCase creates a new product use case
-func NewProductUseCase(productRepo repositories.ProductRepository, categoryRepo repositories.CategoryRepository, inventoryService services.InventoryService) ProductUseCase {
+// NewProductUseCase creates a new product use case.
+// Class filters on the product list read the classification stored by the scheduled run, and search facets bucket prices
+// by priceBuckets unless a search asks for other buckets.
+func NewProductUseCase(
+	productRepo repositories.ProductRepository,
+	categoryRepo repositories.CategoryRepository,
+	inventoryService services.InventoryService,
+	classificationRepo repositories.ClassificationRepository,
+	priceBuckets []float64,
+	authorization services.AuthorizationService,
+	audit services.AuditService,
+	events services.EventService,
+	txManager repositories.TxManager) ProductUseCase {
 	return &productUseCase{
-		productRepo:      productRepo,
-		categoryRepo:     categoryRepo,
-		inventoryService: inventoryService,
+		productRepo:        productRepo,
+		categoryRepo:       categoryRepo,
+		inventoryService:   inventoryService,
+		classificationRepo: classificationRepo,
+		priceBuckets:       priceBuckets,
+		authorization:      authorization,
+		audit:              audit,
+		events:             events,
+		txManager:          txManager,
 	}
 }
 
 // CreateProduct creates a new product
 func (uc *productUseCase) CreateProduct(ctx context.Context, req *dto.ProductRequest) (*dto.ProductResponse, error) {
 	// Validate category exists
-	_, err := uc.categoryRepo.GetByID(ctx, req.CategoryID)
+	category, err := uc.categoryRepo.GetByID(ctx, req.CategoryID)
 	if err != nil {
 		return nil, err
 	}
 
+	if category == nil {
+		return nil, entities.ErrCategoryNotFound
+	}
+
 	// Check if SKU already exists
 	existingProduct, _ := uc.productRepo.GetBySKU(ctx, req.SKU)
 	if existingProduct != nil {
@@ -63,7 +104,15 @@ func (uc *productUseCase) CreateProduct(ctx context.Context, req *dto.ProductReq
 	)
 
 	// Save product
-	err = uc.productRepo.Create(ctx, product)
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.Create(ctx, product); err != nil {
+			return err
+		}
+		if err := uc.audit.Record(ctx, entities.AuditEntityProduct, product.ID, entities.AuditActionCreate, nil, product); err != nil {
+			return err
+		}
+		return uc.events.ProductSaved(ctx, nil, product)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +131,16 @@ func (uc *productUseCase) GetProduct(ctx context.Context, id uuid.UUID) (*dto.Pr
 		return nil, entities.ErrProductNotFound
 	}
 
-	return uc.entityToResponse(product), nil
+	response := uc.entityToResponse(product)
+	if product.IsVariantParent() {
+		variants, err := uc.productRepo.GetVariants(ctx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+		response.Variants = uc.variantsToResponse(variants)
+	}
+
+	return response, nil
 }
 
 // GetProductBySKU retrieves a product by SKU
@@ -99,8 +157,9 @@ func (uc *productUseCase) GetProductBySKU(ctx context.Context, sku string) (*dto
 	return uc.entityToResponse(product), nil
 }
 
-// UpdateProduct updates an existing product
-func (uc *productUseCase) UpdateProduct(ctx context.Context, id uuid.UUID, req *dto.ProductRequest) (*dto.ProductResponse, error) {
+// UpdateProduct updates an existing product.
+// The product must be at the given version; a version of 0 skips the check.
+func (uc *productUseCase) UpdateProduct(ctx context.Context, id uuid.UUID, req *dto.ProductRequest, version int) (*dto.ProductResponse, error) {
 	// Get existing product
 	product, err := uc.productRepo.GetByID(ctx, id)
 	if err != nil {
@@ -111,12 +170,20 @@ func (uc *productUseCase) UpdateProduct(ctx context.Context, id uuid.UUID, req *
 		return nil, entities.ErrProductNotFound
 	}
 
+	if version != 0 && product.Version != version {
+		return nil, entities.ErrVersionMismatch
+	}
+
 	// Validate category exists
-	_, err = uc.categoryRepo.GetByID(ctx, req.CategoryID)
+	category, err := uc.categoryRepo.GetByID(ctx, req.CategoryID)
 	if err != nil {
 		return nil, err
 	}
 
+	if category == nil {
+		return nil, entities.ErrCategoryNotFound
+	}
+
 	// Check if SKU already exists (excluding current product)
 	if req.SKU != product.SKU {
 		existingProduct, _ := uc.productRepo.GetBySKU(ctx, req.SKU)
@@ -126,6 +193,7 @@ func (uc *productUseCase) UpdateProduct(ctx context.Context, id uuid.UUID, req *
 	}
 
 	// Update product fields
+	before := *product
 	product.SKU = req.SKU
 	product.Name = req.Name
 	product.Description = req.Description
@@ -134,32 +202,327 @@ func (uc *productUseCase) UpdateProduct(ctx context.Context, id uuid.UUID, req *
 	product.Cost = req.Cost
 	product.MinStock = req.MinStock
 	product.MaxStock = req.MaxStock
+	product.UpdatedAt = time.Now()
 
 	// Save updated product
-	err = uc.productRepo.Update(ctx, product)
+	err = uc.updateProduct(ctx, &before, product, entities.AuditActionUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.entityToResponse(product), nil
+}
+
+// PatchProduct applies a merge patch or JSON patch to the editable fields of a product.
+// Only the fields the patch changes are validated. The product must be at the given version;
+// a version of 0 skips the check.
+func (uc *productUseCase) PatchProduct(ctx context.Context, id uuid.UUID, req *dto.PatchRequest, version int) (*dto.ProductPatchResponse, error) {
+	product, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	if version != 0 && product.Version != version {
+		return nil, entities.ErrVersionMismatch
+	}
+
+	current := &dto.ProductRequest{
+		SKU:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		CategoryID:  product.CategoryID,
+		Price:       product.Price,
+		Cost:        product.Cost,
+		MinStock:    product.MinStock,
+		MaxStock:    product.MaxStock,
+	}
+
+	patched := &dto.ProductRequest{}
+	changes, err := applyPatch(req, current, patched)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, change := range changes {
+		if err := uc.validatePatchedField(ctx, product, patched, change.Field); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(changes) > 0 {
+		before := *product
+		product.SKU = patched.SKU
+		product.Name = patched.Name
+		product.Description = patched.Description
+		product.CategoryID = patched.CategoryID
+		product.Price = patched.Price
+		product.Cost = patched.Cost
+		product.MinStock = patched.MinStock
+		product.MaxStock = patched.MaxStock
+		product.UpdatedAt = time.Now()
+
+		if err := uc.updateProduct(ctx, &before, product, entities.AuditActionUpdate); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.ProductPatchResponse{
+		Product: uc.entityToResponse(product),
+		Changes: fieldChangesToResponse(changes),
+	}, nil
+}
+
+// validatePatchedField validates one field changed by a patch
+func (uc *productUseCase) validatePatchedField(ctx context.Context, product *entities.Product, patched *dto.ProductRequest, field string) error {
+	switch field {
+	case "sku":
+		if _, err := valueobjects.NewSKU(patched.SKU); err != nil {
+			return fmt.Errorf("%w: sku: %v", entities.ErrInvalidField, err)
+		}
+		existing, err := uc.productRepo.GetBySKU(ctx, patched.SKU)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.ID != product.ID {
+			return entities.ErrDuplicateSKU
+		}
+	case "name":
+		if strings.TrimSpace(patched.Name) == "" {
+			return fmt.Errorf("%w: name is required", entities.ErrInvalidField)
+		}
+	case "category_id":
+		category, err := uc.categoryRepo.GetByID(ctx, patched.CategoryID)
+		if err != nil {
+			return err
+		}
+		if category == nil {
+			return entities.ErrCategoryNotFound
+		}
+	case "price":
+		if patched.Price < 0 {
+			return fmt.Errorf("%w: price must not be negative", entities.ErrInvalidField)
+		}
+	case "cost":
+		if patched.Cost < 0 {
+			return fmt.Errorf("%w: cost must not be negative", entities.ErrInvalidField)
+		}
+	case "min_stock":
+		if patched.MinStock < 0 {
+			return fmt.Errorf("%w: min_stock must not be negative", entities.ErrInvalidField)
+		}
+	case "max_stock":
+		if patched.MaxStock < 0 {
+			return fmt.Errorf("%w: max_stock must not be negative", entities.ErrInvalidField)
+		}
+	}
+	return nil
+}
+
+// DeleteProduct soft-deletes a product together with its variants. The product keeps its ledger
+// history and can be restored until it is purged. The product must be at the given version;
+// a version of 0 skips the check.
+func (uc *productUseCase) DeleteProduct(ctx context.Context, id uuid.UUID, version int) error {
+	if err := uc.authorization.Authorize(ctx, entities.PermissionProductDelete); err != nil {
+		return err
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if product == nil {
+		return entities.ErrProductNotFound
+	}
+
+	if version != 0 && product.Version != version {
+		return entities.ErrVersionMismatch
+	}
+
+	deletedAt := time.Now()
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		variants, err := uc.productRepo.GetVariants(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.productRepo.Delete(ctx, id, product.Version, deletedAt); err != nil {
+			return err
+		}
+
+		for _, p := range append([]*entities.Product{product}, variants...) {
+			before := *p
+			p.DeletedAt = &deletedAt
+			if err := uc.audit.Record(ctx, entities.AuditEntityProduct, p.ID, entities.AuditActionDelete, &before, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RestoreProduct undeletes a soft-deleted product and the variants deleted with it.
+// Its category, and its parent for a variant, must not be deleted. The product must be at the
+// given version; a version of 0 skips the check.
+func (uc *productUseCase) RestoreProduct(ctx context.Context, id uuid.UUID, version int) (*dto.ProductResponse, error) {
+	if err := uc.authorization.Authorize(ctx, entities.PermissionProductDelete); err != nil {
+		return nil, err
+	}
+
+	product, err := uc.productRepo.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	if version != 0 && product.Version != version {
+		return nil, entities.ErrVersionMismatch
+	}
+
+	if !product.IsDeleted() {
+		return uc.entityToResponse(product), nil
+	}
+
+	category, err := uc.categoryRepo.GetByID(ctx, product.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, entities.ErrParentDeleted
+	}
+
+	if product.IsVariant() {
+		parent, err := uc.productRepo.GetByID(ctx, *product.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, entities.ErrParentDeleted
+		}
+	}
+
+	deletedAt := *product.DeletedAt
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		variantIDs, err := uc.productRepo.Restore(ctx, id, product.Version)
+		if err != nil {
+			return err
+		}
+
+		for _, productID := range append([]uuid.UUID{id}, variantIDs...) {
+			err := uc.audit.Record(ctx, entities.AuditEntityProduct, productID, entities.AuditActionRestore,
+				map[string]interface{}{"deleted_at": deletedAt}, map[string]interface{}{"deleted_at": nil})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetProduct(ctx, id)
+}
+
+// ActivateProduct marks a product as active.
+// The product must be at the given version; a version of 0 skips the check.
+func (uc *productUseCase) ActivateProduct(ctx context.Context, id uuid.UUID, version int) (*dto.ProductResponse, error) {
+	return uc.setProductStatus(ctx, id, version, entities.AuditActionActivate)
+}
+
+// DeactivateProduct marks a product as inactive.
+// The product must be at the given version; a version of 0 skips the check.
+func (uc *productUseCase) DeactivateProduct(ctx context.Context, id uuid.UUID, version int) (*dto.ProductResponse, error) {
+	return uc.setProductStatus(ctx, id, version, entities.AuditActionDeactivate)
+}
+
+// setProductStatus activates or deactivates a product; a product already in that status is left unchanged
+func (uc *productUseCase) setProductStatus(ctx context.Context, id uuid.UUID, version int, action string) (*dto.ProductResponse, error) {
+	product, err := uc.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	if version != 0 && product.Version != version {
+		return nil, entities.ErrVersionMismatch
+	}
+
+	before := *product
+	if action == entities.AuditActionActivate {
+		product.Activate()
+	} else {
+		product.Deactivate()
+	}
+
+	if product.Status != before.Status {
+		if err := uc.updateProduct(ctx, &before, product, action); err != nil {
+			return nil, err
+		}
+	}
+
 	return uc.entityToResponse(product), nil
 }
 
-// DeleteProduct deletes a product
-func (uc *productUseCase) DeleteProduct(ctx context.Context, id uuid.UUID) error {
-	return uc.productRepo.Delete(ctx, id)
+// updateProduct writes an edited product, its audit entry and its events in one transaction
+func (uc *productUseCase) updateProduct(ctx context.Context, before, product *entities.Product, action string) error {
+	return uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.Update(ctx, product); err != nil {
+			return err
+		}
+		if err := uc.audit.Record(ctx, entities.AuditEntityProduct, product.ID, action, before, product); err != nil {
+			return err
+		}
+		return uc.events.ProductSaved(ctx, before, product)
+	})
 }
 
-// ListProducts retrieves a paginated list of products
-func (uc *productUseCase) ListProducts(ctx context.Context, page, limit int) (*dto.ProductListResponse, error) {
+// ListProducts retrieves a paginated list of products, optionally restricted to an ABC and/or XYZ class.
+// Listing soft-deleted products too requires the permission to delete them.
+func (uc *productUseCase) ListProducts(ctx context.Context, filter *dto.ProductFilter, page, limit int) (*dto.ProductListResponse, error) {
+	if filter.IncludeDeleted {
+		if err := uc.authorization.Authorize(ctx, entities.PermissionProductDelete); err != nil {
+			return nil, err
+		}
+	}
+
 	offset := (page - 1) * limit
-	products, err := uc.productRepo.GetAll(ctx, limit, offset)
+
+	var products []*entities.Product
+	var total int
+	var err error
+	if filter.ABCClass != "" || filter.XYZClass != "" {
+		if err := validateClassFilter(filter.ABCClass, filter.XYZClass); err != nil {
+			return nil, err
+		}
+		if products, err = uc.productRepo.GetByClass(ctx, filter.ABCClass, filter.XYZClass, limit, offset); err != nil {
+			return nil, err
+		}
+		total, err = uc.productRepo.CountByClass(ctx, filter.ABCClass, filter.XYZClass)
+	} else {
+		if products, err = uc.productRepo.GetAll(ctx, limit, offset, filter.IncludeDeleted); err != nil {
+			return nil, err
+		}
+		total, err = uc.productRepo.Count(ctx, filter.IncludeDeleted)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	response := &dto.ProductListResponse{
 		Products: make([]dto.ProductResponse, len(products)),
-		Total:    len(products), // In a real implementation, you'd get the total count separately
+		Total:    total,
 		Page:     page,
 		Limit:    limit,
 	}
@@ -171,28 +534,184 @@ func (uc *productUseCase) ListProducts(ctx context.Context, page, limit int) (*d
 	return response, nil
 }
 
-// SearchProducts searches for products
-func (uc *productUseCase) SearchProducts(ctx context.Context, query string, page, limit int) (*dto.ProductListResponse, error) {
-	offset := (page - 1) * limit
-	products, err := uc.productRepo.Search(ctx, query, limit, offset)
+// ExportProducts prepares a streaming export of the products matching the list filters.
+// Filters are validated and classes resolved up front; products are read when the export runs.
+func (uc *productUseCase) ExportProducts(ctx context.Context, filter *dto.ProductFilter) (dto.ProductExport, error) {
+	var ids []uuid.UUID
+	if filter.ABCClass != "" || filter.XYZClass != "" {
+		var err error
+		if ids, err = uc.classifiedProductIDs(ctx, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(fn func(*dto.ProductResponse) error) error {
+		return uc.productRepo.ForEach(ctx, ids, func(product *entities.Product) error {
+			return fn(uc.entityToResponse(product))
+		})
+	}, nil
+}
+
+// classifiedProductIDs returns the IDs of the products in the requested class of the stored classification
+func (uc *productUseCase) classifiedProductIDs(ctx context.Context, filter *dto.ProductFilter) ([]uuid.UUID, error) {
+	if err := validateClassFilter(filter.ABCClass, filter.XYZClass); err != nil {
+		return nil, err
+	}
+
+	return uc.classificationRepo.GetProductIDs(ctx, filter.ABCClass, filter.XYZClass)
+}
+
+// SearchProducts searches for products; searching soft-deleted products too requires the permission to delete them
+func (uc *productUseCase) SearchProducts(ctx context.Context, req *dto.ProductSearchRequest) (*dto.ProductSearchResponse, error) {
+	if req.IncludeDeleted {
+		if err := uc.authorization.Authorize(ctx, entities.PermissionProductDelete); err != nil {
+			return nil, err
+		}
+	}
+
+	search := &entities.ProductSearch{
+		Query:          strings.TrimSpace(req.Query),
+		Statuses:       req.Statuses,
+		InStockOnly:    req.InStock,
+		IncludeDeleted: req.IncludeDeleted,
+		Limit:          req.Limit,
+		Offset:         (req.Page - 1) * req.Limit,
+		Facets:         req.Facets,
+		PriceBuckets:   uc.priceBuckets,
+	}
+	if len(search.Statuses) == 0 {
+		search.Statuses = []string{"active"}
+	}
+	for _, status := range search.Statuses {
+		if !entities.IsValidProductStatus(status) {
+			return nil, entities.ErrInvalidProductStatus
+		}
+	}
+	if req.PriceBuckets != "" {
+		priceBuckets, err := entities.ParsePriceBuckets(req.PriceBuckets)
+		if err != nil {
+			return nil, err
+		}
+		search.PriceBuckets = priceBuckets
+	}
+	if len(req.CategoryIDs) > 0 {
+		categoryIDs, err := withSubcategories(ctx, uc.categoryRepo, req.CategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		search.CategoryIDs = categoryIDs
+	}
+
+	result, err := uc.productRepo.Search(ctx, search)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &dto.ProductListResponse{
-		Products: make([]dto.ProductResponse, len(products)),
-		Total:    len(products), // In a real implementation, you'd get the total count separately
-		Page:     page,
-		Limit:    limit,
+	response := &dto.ProductSearchResponse{
+		Products: make([]dto.ProductSearchHit, len(result.Hits)),
+		Total:    result.Total,
+		Page:     req.Page,
+		Limit:    req.Limit,
 	}
 
-	for i, product := range products {
-		response.Products[i] = *uc.entityToResponse(product)
+	for i, hit := range result.Hits {
+		response.Products[i] = dto.ProductSearchHit{
+			ProductResponse: *uc.entityToResponse(hit.Product),
+			Rank:            hit.Rank,
+			Highlights: dto.SearchHighlights{
+				Name:        highlightHTML(hit.Name),
+				Description: highlightHTML(hit.DescriptionSnippet),
+			},
+		}
+	}
+
+	if result.Facets != nil {
+		if response.Facets, err = uc.searchFacets(ctx, result.Facets, search.PriceBuckets); err != nil {
+			return nil, err
+		}
 	}
 
 	return response, nil
 }
 
+// searchFacets converts facet counts to the response, rolling category counts up to the parent categories
+func (uc *productUseCase) searchFacets(ctx context.Context, facets *entities.ProductFacets, priceBuckets []float64) (*dto.SearchFacets, error) {
+	// Deleted categories too, as they may hold deleted products
+	categories, err := uc.categoryRepo.GetAll(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*entities.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	counts := make(map[uuid.UUID]int)
+	for categoryID, count := range facets.Categories {
+		// A category counts once per product even if the tree were to loop
+		seen := make(map[uuid.UUID]bool)
+		for id := &categoryID; id != nil && !seen[*id]; {
+			seen[*id] = true
+			counts[*id] += count
+			category, ok := byID[*id]
+			if !ok {
+				break
+			}
+			id = category.ParentID
+		}
+	}
+
+	response := &dto.SearchFacets{
+		Categories:  []dto.CategoryFacet{},
+		Statuses:    []dto.FacetCount{},
+		StockStates: make([]dto.FacetCount, len(entities.StockStates)),
+		Prices:      make([]dto.PriceFacet, len(facets.PriceBuckets)),
+	}
+
+	for id, count := range counts {
+		facet := dto.CategoryFacet{CategoryID: id, Count: count}
+		if category, ok := byID[id]; ok {
+			facet.ParentID = category.ParentID
+			facet.Name = category.Name
+		}
+		response.Categories = append(response.Categories, facet)
+	}
+	sort.Slice(response.Categories, func(i, j int) bool {
+		if response.Categories[i].Name != response.Categories[j].Name {
+			return response.Categories[i].Name < response.Categories[j].Name
+		}
+		return response.Categories[i].CategoryID.String() < response.Categories[j].CategoryID.String()
+	})
+
+	for status, count := range facets.Statuses {
+		response.Statuses = append(response.Statuses, dto.FacetCount{Value: status, Count: count})
+	}
+	sort.Slice(response.Statuses, func(i, j int) bool { return response.Statuses[i].Value < response.Statuses[j].Value })
+
+	for i, state := range entities.StockStates {
+		response.StockStates[i] = dto.FacetCount{Value: state, Count: facets.StockStates[state]}
+	}
+
+	for i, count := range facets.PriceBuckets {
+		response.Prices[i].Count = count
+		if i > 0 {
+			response.Prices[i].Min = priceBuckets[i-1]
+		}
+		if i < len(priceBuckets) {
+			response.Prices[i].Max = &priceBuckets[i]
+		}
+	}
+
+	return response, nil
+}
+
+// highlightHTML escapes a search highlight and marks the matched words with <mark> tags
+func highlightHTML(text string) string {
+	return strings.NewReplacer(entities.SearchHighlightStart, "<mark>", entities.SearchHighlightStop, "</mark>").
+		Replace(html.EscapeString(text))
+}
+
 // GetLowStockProducts retrieves products with low stock
 func (uc *productUseCase) GetLowStockProducts(ctx context.Context) ([]dto.ProductResponse, error) {
 	products, err := uc.inventoryService.GetLowStockAlerts(ctx)
@@ -208,6 +727,194 @@ func (uc *productUseCase) GetLowStockProducts(ctx context.Context) ([]dto.Produc
 	return response, nil
 }
 
+// GenerateVariants generates child variants of a product from an attribute matrix.
+// Combinations that already exist as variants are skipped, so the matrix can be extended later.
+func (uc *productUseCase) GenerateVariants(ctx context.Context, parentID uuid.UUID, req *dto.GenerateVariantsRequest) (*dto.ProductResponse, error) {
+	parent, err := uc.productRepo.GetByID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	if parent.IsVariant() {
+		return nil, entities.ErrNestedVariant
+	}
+
+	matrix := make(entities.VariantMatrix, len(req.Attributes))
+	for i, attr := range req.Attributes {
+		matrix[i] = entities.VariantAttribute{Name: attr.Name, Values: attr.Values}
+	}
+
+	if err := matrix.Validate(); err != nil {
+		return nil, err
+	}
+
+	axes := matrix.Axes()
+	if parent.IsVariantParent() {
+		if !parent.SameAxes(axes) {
+			return nil, entities.ErrVariantAxesMismatch
+		}
+	} else if parent.Stock != 0 {
+		return nil, entities.ErrVariantParentHasStock
+	}
+
+	existing, err := uc.productRepo.GetVariants(ctx, parent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingSKUs := make(map[string]bool, len(existing))
+	for _, variant := range existing {
+		existingSKUs[variant.SKU] = true
+	}
+
+	before := *parent
+	parent.VariantAxes = axes
+	parent.UpdatedAt = time.Now()
+	policy := uc.policyFromRequest(&req.Policy)
+
+	var variants []*entities.Product
+	generatedSKUs := make(map[string]bool)
+	for _, attributes := range matrix.Combinations() {
+		sku := entities.VariantSKU(parent.SKU, axes, attributes)
+		if existingSKUs[sku] {
+			continue
+		}
+
+		// Values joined by hyphens, such as "A-B" and "C" against "A" and "B-C", can still spell the same SKU
+		if generatedSKUs[sku] {
+			return nil, fmt.Errorf("%w: combinations share the SKU %s", entities.ErrInvalidVariantMatrix, sku)
+		}
+		generatedSKUs[sku] = true
+
+		if _, err := valueobjects.NewSKU(sku); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", entities.ErrInvalidSKU, sku, err)
+		}
+
+		other, err := uc.productRepo.GetBySKU(ctx, sku)
+		if err != nil {
+			return nil, err
+		}
+		if other != nil {
+			return nil, fmt.Errorf("%w: %s", entities.ErrDuplicateSKU, sku)
+		}
+
+		variant := parent.NewVariant(sku, attributes)
+		policy.Apply(variant)
+		variants = append(variants, variant)
+	}
+
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.CreateVariants(ctx, parent, variants); err != nil {
+			return err
+		}
+		if err := uc.audit.Record(ctx, entities.AuditEntityProduct, parent.ID, entities.AuditActionUpdate, &before, parent); err != nil {
+			return err
+		}
+		for _, variant := range variants {
+			if err := uc.audit.Record(ctx, entities.AuditEntityProduct, variant.ID, entities.AuditActionCreate, nil, variant); err != nil {
+				return err
+			}
+			if err := uc.events.ProductSaved(ctx, nil, variant); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetProduct(ctx, parent.ID)
+}
+
+// ApplyVariantPolicy applies a price and stock policy to a parent product and all of its variants
+func (uc *productUseCase) ApplyVariantPolicy(ctx context.Context, parentID uuid.UUID, req *dto.VariantPolicyRequest) (*dto.ProductResponse, error) {
+	parent, err := uc.productRepo.GetByID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	if !parent.IsVariantParent() {
+		return nil, entities.ErrNotVariantParent
+	}
+
+	policy := uc.policyFromRequest(req)
+	if !policy.IsEmpty() {
+		err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			variants, err := uc.productRepo.GetVariants(ctx, parent.ID)
+			if err != nil {
+				return err
+			}
+
+			before := *parent
+			policy.Apply(parent)
+			if err := uc.updateProduct(ctx, &before, parent, entities.AuditActionUpdate); err != nil {
+				return err
+			}
+
+			if err := uc.productRepo.UpdateVariantPolicy(ctx, parent.ID, policy); err != nil {
+				return err
+			}
+
+			for _, variant := range variants {
+				before := *variant
+				policy.Apply(variant)
+				if err := uc.audit.Record(ctx, entities.AuditEntityProduct, variant.ID, entities.AuditActionUpdate, &before, variant); err != nil {
+					return err
+				}
+				if err := uc.events.ProductSaved(ctx, &before, variant); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.GetProduct(ctx, parent.ID)
+}
+
+// policyFromRequest converts a variant policy request to the domain policy
+func (uc *productUseCase) policyFromRequest(req *dto.VariantPolicyRequest) entities.VariantPolicy {
+	return entities.VariantPolicy{
+		Price:    req.Price,
+		Cost:     req.Cost,
+		MinStock: req.MinStock,
+		MaxStock: req.MaxStock,
+	}
+}
+
+// variantsToResponse converts variant entities to the variant grid
+func (uc *productUseCase) variantsToResponse(variants []*entities.Product) []dto.VariantResponse {
+	grid := make([]dto.VariantResponse, len(variants))
+	for i, variant := range variants {
+		grid[i] = dto.VariantResponse{
+			ID:          variant.ID,
+			SKU:         variant.SKU,
+			Attributes:  variant.Attributes,
+			Price:       variant.Price,
+			Cost:        variant.Cost,
+			Stock:       variant.Stock,
+			MinStock:    variant.MinStock,
+			MaxStock:    variant.MaxStock,
+			Status:      variant.Status,
+			IsLowStock:  variant.IsLowStock(),
+			IsOverStock: variant.IsOverStock(),
+		}
+	}
+	return grid
+}
+
 // entityToResponse converts product entity to response DTO
 func (uc *productUseCase) entityToResponse(product *entities.Product) *dto.ProductResponse {
 	return &dto.ProductResponse{
@@ -226,5 +933,10 @@ func (uc *productUseCase) entityToResponse(product *entities.Product) *dto.Produ
 		IsOverStock: product.IsOverStock(),
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
+		Version:     product.Version,
+		ParentID:    product.ParentID,
+		Attributes:  product.Attributes,
+		VariantAxes: product.VariantAxes,
+		DeletedAt:   product.DeletedAt,
 	}
 }
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/domain/services"
+)
+
+// ReconciliationUseCase handles ledger reconciliation runs
+type ReconciliationUseCase interface {
+	Reconcile(ctx context.Context, req *dto.ReconciliationRequest, userID uuid.UUID) (*dto.ReconciliationRunResponse, error)
+	GetRun(ctx context.Context, id uuid.UUID) (*dto.ReconciliationRunResponse, error)
+	ListRuns(ctx context.Context, page, limit int) (*dto.ReconciliationRunListResponse, error)
+}
+
+type reconciliationUseCase struct {
+	reconciliationService services.ReconciliationService
+	reconciliationRepo    repositories.ReconciliationRepository
+}
+
+// NewReconciliationUseCase creates a new reconciliation use case
+func NewReconciliationUseCase(reconciliationService services.ReconciliationService, reconciliationRepo repositories.ReconciliationRepository) ReconciliationUseCase {
+	return &reconciliationUseCase{
+		reconciliationService: reconciliationService,
+		reconciliationRepo:    reconciliationRepo,
+	}
+}
+
+// Reconcile compares stored stock with the ledger and, when requested, repairs the ledger
+func (uc *reconciliationUseCase) Reconcile(ctx context.Context, req *dto.ReconciliationRequest, userID uuid.UUID) (*dto.ReconciliationRunResponse, error) {
+	run, err := uc.reconciliationService.Reconcile(ctx, req.Repair, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.runToResponse(run), nil
+}
+
+// GetRun retrieves a reconciliation run with its discrepancies
+func (uc *reconciliationUseCase) GetRun(ctx context.Context, id uuid.UUID) (*dto.ReconciliationRunResponse, error) {
+	run, err := uc.reconciliationRepo.GetRunByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if run == nil {
+		return nil, entities.ErrReconciliationRunNotFound
+	}
+
+	return uc.runToResponse(run), nil
+}
+
+// ListRuns retrieves a paginated list of reconciliation runs
+func (uc *reconciliationUseCase) ListRuns(ctx context.Context, page, limit int) (*dto.ReconciliationRunListResponse, error) {
+	offset := (page - 1) * limit
+	runs, err := uc.reconciliationRepo.GetRuns(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ReconciliationRunListResponse{
+		Runs:  make([]dto.ReconciliationRunResponse, len(runs)),
+		Total: len(runs), // In a real implementation, you'd get the total count separately
+		Page:  page,
+		Limit: limit,
+	}
+
+	for i, run := range runs {
+		response.Runs[i] = *uc.runToResponse(run)
+	}
+
+	return response, nil
+}
+
+// runToResponse converts reconciliation run entity to response DTO
+func (uc *reconciliationUseCase) runToResponse(run *entities.ReconciliationRun) *dto.ReconciliationRunResponse {
+	response := &dto.ReconciliationRunResponse{
+		ID:              run.ID,
+		Repair:          run.Repair,
+		Status:          run.Status,
+		ProductsChecked: run.ProductsChecked,
+		Discrepancies:   run.Discrepancies,
+		Repaired:        run.Repaired,
+		Error:           run.Error,
+		CreatedBy:       run.CreatedBy,
+		StartedAt:       run.StartedAt,
+		FinishedAt:      run.FinishedAt,
+	}
+
+	for _, item := range run.Items {
+		response.Items = append(response.Items, dto.ReconciliationItemResponse{
+			ProductID:         item.ProductID,
+			SKU:               item.SKU,
+			Name:              item.Name,
+			Stock:             item.Stock,
+			LedgerStock:       item.LedgerStock,
+			Difference:        item.Difference,
+			Transactions:      item.Transactions,
+			LastTransactionAt: item.LastTransactionAt,
+			AdjustmentID:      item.AdjustmentID,
+		})
+	}
+
+	return response
+}
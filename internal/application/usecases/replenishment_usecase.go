@@ -0,0 +1,299 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/domain/services"
+	"inventory-app/pkg/utils"
+)
+
+// ReplenishmentUseCase handles reorder suggestions and the purchase orders created from them
+type ReplenishmentUseCase interface {
+	GetProposals(ctx context.Context, policy string) (*dto.ReplenishmentResponse, error)
+	CreatePurchaseOrders(ctx context.Context, req *dto.CreatePurchaseOrdersRequest, userID uuid.UUID) ([]dto.PurchaseOrderResponse, error)
+	GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error)
+	ListPurchaseOrders(ctx context.Context, status string, page, limit int) (*dto.PurchaseOrderListResponse, error)
+	SubmitPurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error)
+	CancelPurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error)
+}
+
+type replenishmentUseCase struct {
+	replenishmentService services.ReplenishmentService
+	supplierRepo         repositories.SupplierRepository
+	purchaseOrderRepo    repositories.PurchaseOrderRepository
+	txManager            repositories.TxManager
+}
+
+// NewReplenishmentUseCase creates a new replenishment use case
+func NewReplenishmentUseCase(replenishmentService services.ReplenishmentService, supplierRepo repositories.SupplierRepository, purchaseOrderRepo repositories.PurchaseOrderRepository, txManager repositories.TxManager) ReplenishmentUseCase {
+	return &replenishmentUseCase{
+		replenishmentService: replenishmentService,
+		supplierRepo:         supplierRepo,
+		purchaseOrderRepo:    purchaseOrderRepo,
+		txManager:            txManager,
+	}
+}
+
+// GetProposals returns replenishment proposals grouped by supplier
+func (uc *replenishmentUseCase) GetProposals(ctx context.Context, policy string) (*dto.ReplenishmentResponse, error) {
+	proposals, err := uc.replenishmentService.ProposeReplenishment(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := uc.groupBySupplier(ctx, proposals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ReplenishmentResponse{Policy: policy, Suppliers: groups}, nil
+}
+
+// CreatePurchaseOrders converts the current proposals into one draft purchase order per supplier.
+// Proposals for products without a supplier are skipped.
+func (uc *replenishmentUseCase) CreatePurchaseOrders(ctx context.Context, req *dto.CreatePurchaseOrdersRequest, userID uuid.UUID) ([]dto.PurchaseOrderResponse, error) {
+	proposals, err := uc.replenishmentService.ProposeReplenishment(ctx, req.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	suppliers := uuidSet(req.SupplierIDs)
+	products := uuidSet(req.ProductIDs)
+
+	bySupplier := make(map[uuid.UUID][]*entities.ReplenishmentProposal)
+	var supplierIDs []uuid.UUID
+	for _, proposal := range proposals {
+		if proposal.SupplierID == nil {
+			continue
+		}
+		if len(suppliers) > 0 && !suppliers[*proposal.SupplierID] {
+			continue
+		}
+		if len(products) > 0 && !products[proposal.ProductID] {
+			continue
+		}
+		if _, ok := bySupplier[*proposal.SupplierID]; !ok {
+			supplierIDs = append(supplierIDs, *proposal.SupplierID)
+		}
+		bySupplier[*proposal.SupplierID] = append(bySupplier[*proposal.SupplierID], proposal)
+	}
+
+	if len(supplierIDs) == 0 {
+		return nil, entities.ErrNoReplenishmentProposals
+	}
+
+	// The orders are created together so that a failure does not leave some suppliers ordered and a
+	// retry ordering them twice
+	orders := make([]*entities.PurchaseOrder, 0, len(supplierIDs))
+	err = uc.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, supplierID := range supplierIDs {
+			var leadTime int
+			for _, proposal := range bySupplier[supplierID] {
+				if proposal.LeadTimeDays > leadTime {
+					leadTime = proposal.LeadTimeDays
+				}
+			}
+			expectedAt := time.Now().AddDate(0, 0, leadTime)
+
+			po := entities.NewPurchaseOrder(utils.GenerateID("PO"), supplierID, &expectedAt, req.Notes, userID)
+			for _, proposal := range bySupplier[supplierID] {
+				po.AddLine(proposal.ProductID, proposal.SuggestedQuantity, proposal.UnitCost)
+			}
+
+			if err := uc.purchaseOrderRepo.Create(ctx, po); err != nil {
+				return err
+			}
+			orders = append(orders, po)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.PurchaseOrderResponse, len(orders))
+	for i, po := range orders {
+		response[i] = *uc.purchaseOrderToResponse(po)
+	}
+
+	return response, nil
+}
+
+// GetPurchaseOrder retrieves a purchase order with its lines
+func (uc *replenishmentUseCase) GetPurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error) {
+	po, err := uc.purchaseOrderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if po == nil {
+		return nil, entities.ErrPurchaseOrderNotFound
+	}
+
+	return uc.purchaseOrderToResponse(po), nil
+}
+
+// ListPurchaseOrders retrieves a paginated list of purchase orders, optionally filtered by status
+func (uc *replenishmentUseCase) ListPurchaseOrders(ctx context.Context, status string, page, limit int) (*dto.PurchaseOrderListResponse, error) {
+	offset := (page - 1) * limit
+	purchaseOrders, err := uc.purchaseOrderRepo.GetAll(ctx, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.PurchaseOrderListResponse{
+		PurchaseOrders: make([]dto.PurchaseOrderResponse, len(purchaseOrders)),
+		Total:          len(purchaseOrders), // In a real implementation, you'd get the total count separately
+		Page:           page,
+		Limit:          limit,
+	}
+
+	for i, po := range purchaseOrders {
+		response.PurchaseOrders[i] = *uc.purchaseOrderToResponse(po)
+	}
+
+	return response, nil
+}
+
+// SubmitPurchaseOrder sends a draft purchase order to the supplier
+func (uc *replenishmentUseCase) SubmitPurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error) {
+	return uc.changePurchaseOrderStatus(ctx, id, (*entities.PurchaseOrder).Submit)
+}
+
+// CancelPurchaseOrder cancels a draft or open purchase order
+func (uc *replenishmentUseCase) CancelPurchaseOrder(ctx context.Context, id uuid.UUID) (*dto.PurchaseOrderResponse, error) {
+	return uc.changePurchaseOrderStatus(ctx, id, (*entities.PurchaseOrder).Cancel)
+}
+
+// changePurchaseOrderStatus loads a purchase order, applies a status transition and saves it
+func (uc *replenishmentUseCase) changePurchaseOrderStatus(ctx context.Context, id uuid.UUID, transition func(*entities.PurchaseOrder) error) (*dto.PurchaseOrderResponse, error) {
+	po, err := uc.purchaseOrderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if po == nil {
+		return nil, entities.ErrPurchaseOrderNotFound
+	}
+
+	if err := transition(po); err != nil {
+		return nil, err
+	}
+
+	if err := uc.purchaseOrderRepo.UpdateStatus(ctx, po); err != nil {
+		return nil, err
+	}
+
+	return uc.purchaseOrderToResponse(po), nil
+}
+
+// groupBySupplier groups proposals by supplier; the group without a supplier comes last
+func (uc *replenishmentUseCase) groupBySupplier(ctx context.Context, proposals []*entities.ReplenishmentProposal) ([]dto.SupplierProposalsResponse, error) {
+	index := make(map[uuid.UUID]int)
+	var groups []dto.SupplierProposalsResponse
+	var unassigned *dto.SupplierProposalsResponse
+
+	for _, proposal := range proposals {
+		var group *dto.SupplierProposalsResponse
+		if proposal.SupplierID == nil {
+			if unassigned == nil {
+				unassigned = &dto.SupplierProposalsResponse{}
+			}
+			group = unassigned
+		} else {
+			i, ok := index[*proposal.SupplierID]
+			if !ok {
+				supplier, err := uc.supplierRepo.GetByID(ctx, *proposal.SupplierID)
+				if err != nil {
+					return nil, err
+				}
+
+				name := ""
+				if supplier != nil {
+					name = supplier.Name
+				}
+
+				i = len(groups)
+				index[*proposal.SupplierID] = i
+				groups = append(groups, dto.SupplierProposalsResponse{SupplierID: proposal.SupplierID, SupplierName: name})
+			}
+			group = &groups[i]
+		}
+
+		group.Proposals = append(group.Proposals, uc.proposalToResponse(proposal))
+		group.TotalCost += proposal.LineCost()
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].SupplierName < groups[j].SupplierName })
+	if unassigned != nil {
+		groups = append(groups, *unassigned)
+	}
+
+	return groups, nil
+}
+
+// proposalToResponse converts a replenishment proposal to response DTO
+func (uc *replenishmentUseCase) proposalToResponse(proposal *entities.ReplenishmentProposal) dto.ReplenishmentProposalResponse {
+	return dto.ReplenishmentProposalResponse{
+		ProductID:          proposal.ProductID,
+		SKU:                proposal.SKU,
+		Name:               proposal.Name,
+		Stock:              proposal.Stock,
+		Reserved:           proposal.Reserved,
+		OnOrder:            proposal.OnOrder,
+		InventoryPosition:  proposal.InventoryPosition,
+		ReorderPoint:       proposal.ReorderPoint,
+		MinStock:           proposal.MinStock,
+		MaxStock:           proposal.MaxStock,
+		AverageDailyDemand: proposal.AverageDailyDemand,
+		LeadTimeDays:       proposal.LeadTimeDays,
+		MinOrderQty:        proposal.MinOrderQty,
+		OrderMultiple:      proposal.OrderMultiple,
+		SuggestedQuantity:  proposal.SuggestedQuantity,
+		UnitCost:           proposal.UnitCost,
+		LineCost:           proposal.LineCost(),
+	}
+}
+
+// purchaseOrderToResponse converts purchase order entity to response DTO
+func (uc *replenishmentUseCase) purchaseOrderToResponse(po *entities.PurchaseOrder) *dto.PurchaseOrderResponse {
+	response := &dto.PurchaseOrderResponse{
+		ID:         po.ID,
+		Number:     po.Number,
+		SupplierID: po.SupplierID,
+		Status:     po.Status,
+		ExpectedAt: po.ExpectedAt,
+		Notes:      po.Notes,
+		Total:      po.Total(),
+		CreatedBy:  po.CreatedBy,
+		CreatedAt:  po.CreatedAt,
+		UpdatedAt:  po.UpdatedAt,
+	}
+
+	for _, line := range po.Lines {
+		response.Lines = append(response.Lines, dto.PurchaseOrderLineResponse{
+			ID:               line.ID,
+			ProductID:        line.ProductID,
+			Quantity:         line.Quantity,
+			ReceivedQuantity: line.ReceivedQuantity,
+			UnitCost:         line.UnitCost,
+		})
+	}
+
+	return response
+}
+
+// uuidSet builds a lookup set from a list of IDs
+func uuidSet(ids []uuid.UUID) map[uuid.UUID]bool {
+	set := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/domain/services"
+)
+
+// StreamUseCase handles live streams of stock changes
+type StreamUseCase interface {
+	// OpenStockStream subscribes to the stock changes of the caller's tenant that pass the request filters
+	OpenStockStream(ctx context.Context, req *dto.StockStreamRequest) (*services.StockSubscription, error)
+	CloseStockStream(subscription *services.StockSubscription)
+	// StockStreamEvent converts a stock event to the event sent to stream clients
+	StockStreamEvent(event *entities.Event) (*dto.StockStreamEvent, error)
+}
+
+type streamUseCase struct {
+	stockStream  services.StockStreamService
+	categoryRepo repositories.CategoryRepository
+}
+
+// NewStreamUseCase creates a new stream use case
+func NewStreamUseCase(stockStream services.StockStreamService, categoryRepo repositories.CategoryRepository) StreamUseCase {
+	return &streamUseCase{
+		stockStream:  stockStream,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// OpenStockStream expands the category filter to subcategories and subscribes
+func (uc *streamUseCase) OpenStockStream(ctx context.Context, req *dto.StockStreamRequest) (*services.StockSubscription, error) {
+	filter := entities.StockStreamFilter{ProductIDs: req.ProductIDs}
+	if len(req.CategoryIDs) > 0 {
+		categoryIDs, err := withSubcategories(ctx, uc.categoryRepo, req.CategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		filter.CategoryIDs = categoryIDs
+	}
+
+	return uc.stockStream.Subscribe(ctx, filter, req.LastEventID)
+}
+
+// CloseStockStream unsubscribes a stream
+func (uc *streamUseCase) CloseStockStream(subscription *services.StockSubscription) {
+	uc.stockStream.Unsubscribe(subscription)
+}
+
+// StockStreamEvent converts a stock event to a stream event
+func (uc *streamUseCase) StockStreamEvent(event *entities.Event) (*dto.StockStreamEvent, error) {
+	var payload entities.StockEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode stock event: %w", err)
+	}
+
+	transactionType := entities.TransactionTypeAdjustment
+	switch event.Type {
+	case entities.EventStockReceived:
+		transactionType = entities.TransactionTypeIn
+	case entities.EventStockShipped:
+		transactionType = entities.TransactionTypeOut
+	}
+
+	return &dto.StockStreamEvent{
+		ID:   event.Sequence,
+		Type: event.Type,
+		Data: dto.StockChangeResponse{
+			ProductID:       payload.ProductID,
+			SKU:             payload.SKU,
+			CategoryID:      payload.CategoryID,
+			TransactionID:   payload.TransactionID,
+			TransactionType: transactionType,
+			Quantity:        payload.Quantity,
+			StockBefore:     payload.StockBefore,
+			StockAfter:      payload.StockAfter,
+			Reference:       payload.Reference,
+			OccurredAt:      event.OccurredAt,
+		},
+	}, nil
+}
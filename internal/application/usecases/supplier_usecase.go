@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// SupplierUseCase handles supplier-related operations
+type SupplierUseCase interface {
+	CreateSupplier(ctx context.Context, req *dto.SupplierRequest) (*dto.SupplierResponse, error)
+	GetSupplier(ctx context.Context, id uuid.UUID) (*dto.SupplierResponse, error)
+	UpdateSupplier(ctx context.Context, id uuid.UUID, req *dto.SupplierRequest) (*dto.SupplierResponse, error)
+	ListSuppliers(ctx context.Context, page, limit int) (*dto.SupplierListResponse, error)
+	SetProductSupplier(ctx context.Context, supplierID, productID uuid.UUID, req *dto.ProductSupplierRequest) (*dto.ProductSupplierResponse, error)
+	GetProductSuppliers(ctx context.Context, productID uuid.UUID) ([]dto.ProductSupplierResponse, error)
+}
+
+type supplierUseCase struct {
+	supplierRepo repositories.SupplierRepository
+	productRepo  repositories.ProductRepository
+}
+
+// NewSupplierUseCase creates a new supplier use case
+func NewSupplierUseCase(supplierRepo repositories.SupplierRepository, productRepo repositories.ProductRepository) SupplierUseCase {
+	return &supplierUseCase{
+		supplierRepo: supplierRepo,
+		productRepo:  productRepo,
+	}
+}
+
+// CreateSupplier creates a new supplier
+func (uc *supplierUseCase) CreateSupplier(ctx context.Context, req *dto.SupplierRequest) (*dto.SupplierResponse, error) {
+	supplier := entities.NewSupplier(req.Name, req.Email, req.Phone, req.LeadTimeDays)
+
+	if err := uc.supplierRepo.Create(ctx, supplier); err != nil {
+		return nil, err
+	}
+
+	return uc.entityToResponse(supplier), nil
+}
+
+// GetSupplier retrieves a supplier by ID
+func (uc *supplierUseCase) GetSupplier(ctx context.Context, id uuid.UUID) (*dto.SupplierResponse, error) {
+	supplier, err := uc.supplierRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if supplier == nil {
+		return nil, entities.ErrSupplierNotFound
+	}
+
+	return uc.entityToResponse(supplier), nil
+}
+
+// UpdateSupplier updates an existing supplier
+func (uc *supplierUseCase) UpdateSupplier(ctx context.Context, id uuid.UUID, req *dto.SupplierRequest) (*dto.SupplierResponse, error) {
+	supplier, err := uc.supplierRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if supplier == nil {
+		return nil, entities.ErrSupplierNotFound
+	}
+
+	supplier.Name = req.Name
+	supplier.Email = req.Email
+	supplier.Phone = req.Phone
+	supplier.LeadTimeDays = req.LeadTimeDays
+
+	if err := uc.supplierRepo.Update(ctx, supplier); err != nil {
+		return nil, err
+	}
+
+	return uc.entityToResponse(supplier), nil
+}
+
+// ListSuppliers retrieves a paginated list of suppliers
+func (uc *supplierUseCase) ListSuppliers(ctx context.Context, page, limit int) (*dto.SupplierListResponse, error) {
+	offset := (page - 1) * limit
+	suppliers, err := uc.supplierRepo.GetAll(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SupplierListResponse{
+		Suppliers: make([]dto.SupplierResponse, len(suppliers)),
+		Total:     len(suppliers), // In a real implementation, you'd get the total count separately
+		Page:      page,
+		Limit:     limit,
+	}
+
+	for i, supplier := range suppliers {
+		response.Suppliers[i] = *uc.entityToResponse(supplier)
+	}
+
+	return response, nil
+}
+
+// SetProductSupplier creates or updates the purchasing terms of a product at a supplier
+func (uc *supplierUseCase) SetProductSupplier(ctx context.Context, supplierID, productID uuid.UUID, req *dto.ProductSupplierRequest) (*dto.ProductSupplierResponse, error) {
+	supplier, err := uc.supplierRepo.GetByID(ctx, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	if supplier == nil {
+		return nil, entities.ErrSupplierNotFound
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	productSupplier := entities.NewProductSupplier(productID, supplierID, req.SupplierSKU, req.UnitCost,
+		req.LeadTimeDays, req.MinOrderQty, req.OrderMultiple, req.IsPreferred)
+
+	if err := uc.supplierRepo.UpsertProductSupplier(ctx, productSupplier); err != nil {
+		return nil, err
+	}
+
+	response := uc.productSupplierToResponse(productSupplier)
+	return &response, nil
+}
+
+// GetProductSuppliers retrieves the purchasing terms of a product at all of its suppliers
+func (uc *supplierUseCase) GetProductSuppliers(ctx context.Context, productID uuid.UUID) ([]dto.ProductSupplierResponse, error) {
+	productSuppliers, err := uc.supplierRepo.GetProductSuppliers(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ProductSupplierResponse, len(productSuppliers))
+	for i, ps := range productSuppliers {
+		response[i] = uc.productSupplierToResponse(ps)
+	}
+
+	return response, nil
+}
+
+// entityToResponse converts supplier entity to response DTO
+func (uc *supplierUseCase) entityToResponse(supplier *entities.Supplier) *dto.SupplierResponse {
+	return &dto.SupplierResponse{
+		ID:           supplier.ID,
+		Name:         supplier.Name,
+		Email:        supplier.Email,
+		Phone:        supplier.Phone,
+		LeadTimeDays: supplier.LeadTimeDays,
+		Status:       supplier.Status,
+		CreatedAt:    supplier.CreatedAt,
+		UpdatedAt:    supplier.UpdatedAt,
+	}
+}
+
+// productSupplierToResponse converts product supplier terms to response DTO
+func (uc *supplierUseCase) productSupplierToResponse(ps *entities.ProductSupplier) dto.ProductSupplierResponse {
+	return dto.ProductSupplierResponse{
+		ProductID:     ps.ProductID,
+		SupplierID:    ps.SupplierID,
+		SupplierSKU:   ps.SupplierSKU,
+		UnitCost:      ps.UnitCost,
+		LeadTimeDays:  ps.LeadTimeDays,
+		MinOrderQty:   ps.MinOrderQty,
+		OrderMultiple: ps.OrderMultiple,
+		IsPreferred:   ps.IsPreferred,
+	}
+}
@@ -0,0 +1,300 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// webhookSecretPrefix marks generated webhook secrets
+const webhookSecretPrefix = "whsec_"
+
+// WebhookUseCase handles webhook subscriptions and their delivery log
+type WebhookUseCase interface {
+	CreateWebhook(ctx context.Context, req *dto.WebhookRequest) (*dto.WebhookCreatedResponse, error)
+	GetWebhook(ctx context.Context, id uuid.UUID) (*dto.WebhookResponse, error)
+	ListWebhooks(ctx context.Context) ([]dto.WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, id uuid.UUID, req *dto.WebhookRequest) (*dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, status string, page, limit int) (*dto.WebhookDeliveryListResponse, error)
+	GetDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (*dto.WebhookDeliveryResponse, error)
+	RedeliverDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (*dto.WebhookDeliveryResponse, error)
+}
+
+type webhookUseCase struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+}
+
+// NewWebhookUseCase creates a new webhook use case
+func NewWebhookUseCase(webhookRepo repositories.WebhookRepository, deliveryRepo repositories.WebhookDeliveryRepository) WebhookUseCase {
+	return &webhookUseCase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// CreateWebhook subscribes a URL to event types; without a secret in the request one is generated
+func (uc *webhookUseCase) CreateWebhook(ctx context.Context, req *dto.WebhookRequest) (*dto.WebhookCreatedResponse, error) {
+	target, eventTypes, err := validateWebhook(req)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription := entities.NewWebhookSubscription(target, eventTypes, secret)
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	if err := uc.webhookRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &dto.WebhookCreatedResponse{WebhookResponse: *uc.webhookToResponse(subscription), Secret: secret}, nil
+}
+
+// GetWebhook retrieves a webhook subscription
+func (uc *webhookUseCase) GetWebhook(ctx context.Context, id uuid.UUID) (*dto.WebhookResponse, error) {
+	subscription, err := uc.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.webhookToResponse(subscription), nil
+}
+
+// ListWebhooks lists all webhook subscriptions
+func (uc *webhookUseCase) ListWebhooks(ctx context.Context) ([]dto.WebhookResponse, error) {
+	subscriptions, err := uc.webhookRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		response[i] = *uc.webhookToResponse(subscription)
+	}
+
+	return response, nil
+}
+
+// UpdateWebhook replaces the URL and event types of a subscription, and its secret and state when given.
+// Deliveries already queued go to the new URL, signed with the new secret.
+func (uc *webhookUseCase) UpdateWebhook(ctx context.Context, id uuid.UUID, req *dto.WebhookRequest) (*dto.WebhookResponse, error) {
+	subscription, err := uc.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	target, eventTypes, err := validateWebhook(req)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.URL = target
+	subscription.EventTypes = eventTypes
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := uc.webhookRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return uc.webhookToResponse(subscription), nil
+}
+
+// DeleteWebhook deletes a subscription together with its delivery log
+func (uc *webhookUseCase) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	return uc.webhookRepo.Delete(ctx, id)
+}
+
+// ListDeliveries retrieves the delivery log of a subscription, optionally restricted to a status
+func (uc *webhookUseCase) ListDeliveries(ctx context.Context, webhookID uuid.UUID, status string, page, limit int) (*dto.WebhookDeliveryListResponse, error) {
+	if status != "" && !entities.IsValidDeliveryStatus(status) {
+		return nil, entities.ErrInvalidDeliveryStatus
+	}
+
+	if _, err := uc.getWebhook(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	deliveries, err := uc.deliveryRepo.GetBySubscription(ctx, webhookID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.WebhookDeliveryListResponse{
+		Deliveries: make([]dto.WebhookDeliveryResponse, len(deliveries)),
+		Total:      len(deliveries), // In a real implementation, you'd get the total count separately
+		Page:       page,
+		Limit:      limit,
+	}
+
+	for i, delivery := range deliveries {
+		response.Deliveries[i] = *uc.deliveryToResponse(delivery, nil)
+	}
+
+	return response, nil
+}
+
+// GetDelivery retrieves a delivery with the log of its attempts
+func (uc *webhookUseCase) GetDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (*dto.WebhookDeliveryResponse, error) {
+	delivery, err := uc.getDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := uc.deliveryRepo.GetAttempts(ctx, delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.deliveryToResponse(delivery, attempts), nil
+}
+
+// RedeliverDelivery queues a delivery again, whatever its status, with a fresh set of attempts
+func (uc *webhookUseCase) RedeliverDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (*dto.WebhookDeliveryResponse, error) {
+	if _, err := uc.getDelivery(ctx, webhookID, deliveryID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.deliveryRepo.Requeue(ctx, deliveryID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetDelivery(ctx, webhookID, deliveryID)
+}
+
+// getWebhook retrieves a subscription, failing with ErrWebhookNotFound when there is none
+func (uc *webhookUseCase) getWebhook(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	subscription, err := uc.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription == nil {
+		return nil, entities.ErrWebhookNotFound
+	}
+
+	return subscription, nil
+}
+
+// getDelivery retrieves a delivery of a subscription, failing with ErrWebhookDeliveryNotFound when there is none
+func (uc *webhookUseCase) getDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (*entities.WebhookDelivery, error) {
+	delivery, err := uc.deliveryRepo.GetByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery == nil || delivery.SubscriptionID != webhookID {
+		return nil, entities.ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, nil
+}
+
+// validateWebhook checks the URL and event types of a request and returns them normalized
+func validateWebhook(req *dto.WebhookRequest) (string, []string, error) {
+	target := strings.TrimSpace(req.URL)
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", nil, entities.ErrInvalidWebhookURL
+	}
+
+	if len(req.EventTypes) == 0 {
+		return "", nil, fmt.Errorf("%w: at least one event type is required", entities.ErrInvalidEventType)
+	}
+
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	seen := make(map[string]bool)
+	for _, eventType := range req.EventTypes {
+		if eventType != entities.AllEventTypes && !entities.IsValidEventType(eventType) {
+			return "", nil, fmt.Errorf("%w: %s", entities.ErrInvalidEventType, eventType)
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	if len(req.Secret) > 255 {
+		return "", nil, fmt.Errorf("%w: secret must be at most 255 characters", entities.ErrInvalidField)
+	}
+
+	return target, eventTypes, nil
+}
+
+// generateWebhookSecret returns a random signing secret
+func generateWebhookSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(bytes), nil
+}
+
+// webhookToResponse converts a webhook subscription to a response DTO
+func (uc *webhookUseCase) webhookToResponse(subscription *entities.WebhookSubscription) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+// deliveryToResponse converts a delivery and its attempts to a response DTO
+func (uc *webhookUseCase) deliveryToResponse(delivery *entities.WebhookDelivery, attempts []*entities.WebhookAttempt) *dto.WebhookDeliveryResponse {
+	response := &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+
+	if delivery.Status == entities.DeliveryStatusPending {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	for _, attempt := range attempts {
+		response.AttemptLog = append(response.AttemptLog, dto.WebhookAttemptResponse{
+			Attempt:     attempt.Attempt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMS:  attempt.DurationMS,
+			AttemptedAt: attempt.AttemptedAt,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,164 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock alert types
+const (
+	AlertTypeLowStock  = "low_stock"  // stock at or below the minimum
+	AlertTypeOverStock = "over_stock" // stock at or above the maximum
+)
+
+// Alert notification channels
+const (
+	AlertChannelEmail = "email"  // an email to the subscription target
+	AlertChannelSlack = "slack"  // a message to the Slack-compatible incoming webhook URL of the target
+	AlertChannelInApp = "in_app" // an entry in the subscriber's alert feed
+)
+
+// Alert statuses used to filter alerts
+const (
+	AlertStatusOpen     = "open"
+	AlertStatusResolved = "resolved"
+)
+
+// Alert records a product whose stock left its bounds. At most one alert per product and type is open;
+// it is resolved by the movement that brings the stock back within bounds.
+type Alert struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	ProductID  uuid.UUID  `json:"product_id" db:"product_id"`
+	Type       string     `json:"type" db:"alert_type"`
+	Stock      int        `json:"stock" db:"stock"`         // stock when the alert was opened
+	Threshold  int        `json:"threshold" db:"threshold"` // the min or max stock that was crossed
+	OpenedAt   time.Time  `json:"opened_at" db:"opened_at"`
+	ResolvedAt *time.Time `json:"resolved_at" db:"resolved_at"`
+	TenantID   uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+}
+
+// NewAlert creates a new open alert of a product
+func NewAlert(productID uuid.UUID, alertType string, stock, threshold int) *Alert {
+	return &Alert{
+		ID:        uuid.New(),
+		ProductID: productID,
+		Type:      alertType,
+		Stock:     stock,
+		Threshold: threshold,
+		OpenedAt:  time.Now(),
+	}
+}
+
+// IsOpen checks if the alert has not been resolved
+func (a *Alert) IsOpen() bool {
+	return a.ResolvedAt == nil
+}
+
+// AlertConditions returns the alert types whose condition the product's stock currently meets.
+// Products without a maximum stock are never over stock.
+func AlertConditions(product *Product) map[string]bool {
+	return map[string]bool{
+		AlertTypeLowStock:  product.IsLowStock(),
+		AlertTypeOverStock: product.MaxStock > 0 && product.IsOverStock(),
+	}
+}
+
+// AlertThreshold returns the stock bound of the product that an alert type watches
+func AlertThreshold(product *Product, alertType string) int {
+	if alertType == AlertTypeOverStock {
+		return product.MaxStock
+	}
+	return product.MinStock
+}
+
+// AlertSubscription sends the alerts of products in a category, or of every product when CategoryID is nil,
+// to a user through a channel. Subscriptions to a category cover its subcategories.
+type AlertSubscription struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	CategoryID *uuid.UUID `json:"category_id" db:"category_id"`
+	Channel    string     `json:"channel" db:"channel"`
+	Target     string     `json:"target" db:"target"` // email address or webhook URL; empty for in-app
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	TenantID   uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+}
+
+// NewAlertSubscription creates a new alert subscription
+func NewAlertSubscription(userID uuid.UUID, categoryID *uuid.UUID, channel, target string) *AlertSubscription {
+	return &AlertSubscription{
+		ID:         uuid.New(),
+		UserID:     userID,
+		CategoryID: categoryID,
+		Channel:    channel,
+		Target:     target,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// IsValidAlertChannel checks if the notification channel exists
+func IsValidAlertChannel(channel string) bool {
+	return channel == AlertChannelEmail || channel == AlertChannelSlack || channel == AlertChannelInApp
+}
+
+// AlertFeedItem is an alert notification in a user's in-app feed
+type AlertFeedItem struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	AlertID   uuid.UUID  `json:"alert_id" db:"alert_id"`
+	EventType string     `json:"event_type" db:"event_type"` // alert.opened or alert.resolved
+	Subject   string     `json:"subject" db:"subject"`
+	Message   string     `json:"message" db:"message"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ReadAt    *time.Time `json:"read_at" db:"read_at"`
+	TenantID  uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+}
+
+// NewAlertFeedItem creates a new unread feed item for a notification
+func NewAlertFeedItem(userID uuid.UUID, notification *AlertNotification) *AlertFeedItem {
+	return &AlertFeedItem{
+		ID:        uuid.New(),
+		UserID:    userID,
+		AlertID:   notification.AlertID,
+		EventType: notification.EventType,
+		Subject:   notification.Subject(),
+		Message:   notification.Message(),
+		CreatedAt: time.Now(),
+	}
+}
+
+// AlertNotification is an alert that was opened or resolved, as sent to subscribers
+type AlertNotification struct {
+	EventType string // alert.opened or alert.resolved
+	AlertEventPayload
+}
+
+// IsResolved checks if the notification is about a resolved alert
+func (n *AlertNotification) IsResolved() bool {
+	return n.EventType == EventAlertResolved
+}
+
+// Subject returns a one-line summary of the notification
+func (n *AlertNotification) Subject() string {
+	what := "Low stock"
+	if n.AlertType == AlertTypeOverStock {
+		what = "Over stock"
+	}
+	if n.IsResolved() {
+		return fmt.Sprintf("Resolved: %s of %s", what, n.SKU)
+	}
+	return fmt.Sprintf("%s: %s", what, n.SKU)
+}
+
+// Message returns the text of the notification
+func (n *AlertNotification) Message() string {
+	bound, side, back := "minimum", "at or below", "above"
+	if n.AlertType == AlertTypeOverStock {
+		bound, side, back = "maximum", "at or above", "below"
+	}
+	if n.IsResolved() {
+		return fmt.Sprintf("Stock of %s (%s) is back to %d, %s its %s of %d.", n.SKU, n.Name, n.Stock, back, bound, n.Threshold)
+	}
+	return fmt.Sprintf("Stock of %s (%s) is %d, %s its %s of %d.", n.SKU, n.Name, n.Stock, side, bound, n.Threshold)
+}
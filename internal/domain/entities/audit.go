@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited entity types
+const (
+	AuditEntityProduct  = "product"
+	AuditEntityCategory = "category"
+)
+
+// Audited actions
+const (
+	AuditActionCreate     = "create"
+	AuditActionUpdate     = "update"
+	AuditActionDelete     = "delete"
+	AuditActionActivate   = "activate"
+	AuditActionDeactivate = "deactivate"
+	AuditActionRestore    = "restore"
+)
+
+// AuditEntry records one change of an entity: who made it, from which request, and the fields it changed.
+// Entries are append-only.
+type AuditEntry struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	EntityType string        `json:"entity_type" db:"entity_type"`
+	EntityID   uuid.UUID     `json:"entity_id" db:"entity_id"`
+	Action     string        `json:"action" db:"action"`
+	Changes    []FieldChange `json:"changes" db:"changes"`
+	ActorID    *uuid.UUID    `json:"actor_id" db:"actor_id"` // nil for changes made by jobs
+	RequestID  string        `json:"request_id" db:"request_id"`
+	IP         string        `json:"ip" db:"ip"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+	TenantID   uuid.UUID     `json:"tenant_id" db:"tenant_id"`
+}
+
+// NewAuditEntry creates a new audit entry
+func NewAuditEntry(entityType string, entityID uuid.UUID, action string, changes []FieldChange, actorID *uuid.UUID, requestID, ip string) *AuditEntry {
+	return &AuditEntry{
+		ID:         uuid.New(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Changes:    changes,
+		ActorID:    actorID,
+		RequestID:  requestID,
+		IP:         ip,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// IsValidAuditEntity checks if the entity type is audited
+func IsValidAuditEntity(entityType string) bool {
+	return entityType == AuditEntityProduct || entityType == AuditEntityCategory
+}
@@ -14,6 +14,8 @@ type Category struct {
 	Status      string     `json:"status" db:"status"`
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	TenantID    uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	DeletedAt   *time.Time `json:"deleted_at" db:"deleted_at"` // set while the category is soft-deleted
 }
 
 // NewCategory creates a new category instance
@@ -34,6 +36,11 @@ func (c *Category) IsSubCategory() bool {
 	return c.ParentID != nil
 }
 
+// IsDeleted checks if the category is soft-deleted
+func (c *Category) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
 // Deactivate marks the category as inactive
 func (c *Category) Deactivate() {
 	c.Status = "inactive"
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+// ABC classes rank products by consumption value
+const (
+	ClassA = "A"
+	ClassB = "B"
+	ClassC = "C"
+)
+
+// XYZ classes rank products by demand variability
+const (
+	ClassX = "X"
+	ClassY = "Y"
+	ClassZ = "Z"
+)
+
+// ClassificationParams holds the window and thresholds of an ABC/XYZ classification
+type ClassificationParams struct {
+	WindowDays int     // days of outbound transactions analysed
+	PeriodDays int     // length of the periods demand variability is measured over
+	AThreshold float64 // cumulative value share covered by A products, e.g. 0.8
+	BThreshold float64 // cumulative value share covered by A and B products, e.g. 0.95
+	XThreshold float64 // highest coefficient of variation of X products, e.g. 0.5
+	YThreshold float64 // highest coefficient of variation of Y products, e.g. 1.0
+}
+
+// Validate checks that the window holds at least one period and the thresholds are ordered
+func (p ClassificationParams) Validate() error {
+	if p.PeriodDays <= 0 || p.WindowDays < p.PeriodDays {
+		return ErrInvalidClassificationParams
+	}
+	if p.AThreshold <= 0 || p.AThreshold >= p.BThreshold || p.BThreshold > 1 {
+		return ErrInvalidClassificationParams
+	}
+	if p.XThreshold <= 0 || p.XThreshold >= p.YThreshold {
+		return ErrInvalidClassificationParams
+	}
+	return nil
+}
+
+// Periods returns the number of whole periods in the window
+func (p ClassificationParams) Periods() int {
+	return p.WindowDays / p.PeriodDays
+}
+
+// ProductClassification is the ABC/XYZ class of a product and the figures behind it
+type ProductClassification struct {
+	ProductID           uuid.UUID `json:"product_id"`
+	SKU                 string    `json:"sku"`
+	Name                string    `json:"name"`
+	ConsumptionQuantity int       `json:"consumption_quantity"`
+	ConsumptionValue    float64   `json:"consumption_value"`
+	ValueShare          float64   `json:"value_share"`
+	CumulativeShare     float64   `json:"cumulative_share"`
+	DemandCV            *float64  `json:"demand_cv"` // nil when there was no demand
+	ABCClass            string    `json:"abc_class"`
+	XYZClass            string    `json:"xyz_class"`
+}
+
+// Class returns the combined class, e.g. "AX"
+func (c *ProductClassification) Class() string {
+	return c.ABCClass + c.XYZClass
+}
+
+// IsValidABCClass checks if the class is A, B or C
+func IsValidABCClass(class string) bool {
+	return class == ClassA || class == ClassB || class == ClassC
+}
+
+// IsValidXYZClass checks if the class is X, Y or Z
+func IsValidXYZClass(class string) bool {
+	return class == ClassX || class == ClassY || class == ClassZ
+}
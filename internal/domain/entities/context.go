@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// requestContextKey is the type of the context keys set by the request middleware
+type requestContextKey string
+
+// Context keys under which the request middleware stores the acting user, the tenant, the request ID
+// and the client IP. Values stored in fiber locals under these keys are visible through the request context.
+const (
+	UserIDContextKey    = requestContextKey("user_id")
+	TenantIDContextKey  = requestContextKey("tenant_id")
+	RequestIDContextKey = requestContextKey("request_id")
+	ClientIPContextKey  = requestContextKey("client_ip")
+)
+
+// UserIDFromContext returns the acting user of a request, or uuid.Nil when there is none
+func UserIDFromContext(ctx context.Context) uuid.UUID {
+	userID, _ := ctx.Value(UserIDContextKey).(uuid.UUID)
+	return userID
+}
+
+// TenantIDFromContext returns the tenant whose data a request or job works on, or uuid.Nil when there is none
+func TenantIDFromContext(ctx context.Context) uuid.UUID {
+	tenantID, _ := ctx.Value(TenantIDContextKey).(uuid.UUID)
+	return tenantID
+}
+
+// WithTenantID returns a context scoped to a tenant, for work outside a request such as jobs
+func WithTenantID(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, TenantIDContextKey, tenantID)
+}
+
+// RequestIDFromContext returns the ID of the current request, or "" when there is none
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDContextKey).(string)
+	return requestID
+}
+
+// ClientIPFromContext returns the IP address the current request came from, or "" when there is none
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(ClientIPContextKey).(string)
+	return ip
+}
@@ -10,4 +10,75 @@ var (
 	ErrInvalidSKU        = errors.New("invalid SKU")
 	ErrInvalidQuantity   = errors.New("invalid quantity")
 	ErrDuplicateSKU      = errors.New("duplicate SKU")
+
+	ErrInvalidVariantMatrix  = errors.New("invalid variant matrix")
+	ErrVariantAxesMismatch   = errors.New("variant attributes do not match the product's existing variant axes")
+	ErrNestedVariant         = errors.New("a variant cannot have variants of its own")
+	ErrNotVariantParent      = errors.New("product has no variants")
+	ErrVariantParentStock    = errors.New("stock of a product with variants is tracked on its variants")
+	ErrVariantParentHasStock = errors.New("product stock must be zero before variants can be generated")
+
+	ErrSupplierNotFound           = errors.New("supplier not found")
+	ErrPurchaseOrderNotFound      = errors.New("purchase order not found")
+	ErrInvalidPurchaseOrderStatus = errors.New("purchase order status does not allow this action")
+	ErrInvalidReplenishmentPolicy = errors.New("invalid replenishment policy")
+	ErrNoReplenishmentProposals   = errors.New("no replenishment proposals to convert")
+	ErrReservationNotFound        = errors.New("reservation not found")
+	ErrReservationNotActive       = errors.New("reservation is not active")
+
+	ErrInvalidForecastModel  = errors.New("invalid forecast model")
+	ErrForecastRangeTooLarge = errors.New("history_days must be at most 3650 and window and horizon at most 365")
+	ErrInvalidServiceLevel   = errors.New("service level must be between 0.5 and 1 (exclusive)")
+
+	ErrInvalidClassificationParams = errors.New("invalid classification window or thresholds")
+	ErrInvalidClass                = errors.New("invalid ABC/XYZ class")
+	ErrInvalidDateRange            = errors.New("invalid date range")
+	ErrInvalidReportGrouping       = errors.New("invalid report grouping")
+
+	ErrReconciliationRunNotFound = errors.New("reconciliation run not found")
+
+	ErrInvalidImportMode = errors.New("invalid import mode")
+	ErrInvalidImportFile = errors.New("invalid import file")
+	ErrImportTooLarge    = errors.New("import file is larger than the limit")
+
+	ErrInvalidTransactionType = errors.New("invalid transaction type")
+	ErrInvalidMovementBatch   = errors.New("a movement batch must have between 1 and 1000 lines")
+	ErrMovementBatchRejected  = errors.New("movement batch rejected, no line was applied")
+
+	ErrIdempotencyKeyConflict  = errors.New("idempotency key was already used for a different request or is still being processed")
+	ErrDuplicateIdempotencyKey = errors.New("a transaction was already recorded for this idempotency key")
+
+	ErrVersionMismatch = errors.New("resource was modified by another request")
+
+	ErrInvalidPatch    = errors.New("invalid patch")
+	ErrPatchTestFailed = errors.New("patch test operation failed")
+	ErrInvalidField    = errors.New("invalid field value")
+
+	ErrForbidden   = errors.New("permission denied")
+	ErrInvalidRole = errors.New("invalid role")
+
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	ErrInvalidAPIKey  = errors.New("invalid API key")
+	ErrInvalidScope   = errors.New("invalid API key scope")
+
+	ErrCategoryInUse         = errors.New("category still has products or subcategories")
+	ErrDuplicateCategoryName = errors.New("category name already exists")
+	ErrParentDeleted         = errors.New("the category or parent it belongs to is deleted, restore it first")
+	ErrInvalidAuditEntity    = errors.New("invalid audit entity type")
+
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL       = errors.New("webhook URL must be an absolute http or https URL")
+	ErrInvalidEventType        = errors.New("invalid event type")
+	ErrInvalidDeliveryStatus   = errors.New("invalid webhook delivery status")
+
+	ErrAlertSubscriptionNotFound = errors.New("alert subscription not found")
+	ErrAlertFeedItemNotFound     = errors.New("alert feed item not found")
+	ErrInvalidAlertChannel       = errors.New("invalid alert channel")
+	ErrAlertChannelDisabled      = errors.New("alert channel is not configured")
+	ErrInvalidAlertTarget        = errors.New("invalid alert target for the channel")
+	ErrInvalidAlertStatus        = errors.New("invalid alert status")
+
+	ErrInvalidProductStatus = errors.New("invalid product status")
+	ErrInvalidPriceBuckets  = errors.New("price buckets must be at most 20 positive, strictly ascending bounds")
 )
@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain event types
+const (
+	EventStockReceived       = "stock.received"
+	EventStockShipped        = "stock.shipped"
+	EventStockAdjusted       = "stock.adjusted"
+	EventLowStockReached     = "stock.low_stock_reached"
+	EventProductCreated      = "product.created"
+	EventProductPriceChanged = "product.price_changed"
+	EventAlertOpened         = "alert.opened"
+	EventAlertResolved       = "alert.resolved"
+)
+
+// Event is a domain event about a product. Events are written to the outbox in the transaction of the
+// change and relayed to publishers afterwards, at least once and in Sequence order per product.
+type Event struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	Sequence   int64           `json:"sequence" db:"sequence"` // assigned by the outbox
+	Type       string          `json:"type" db:"event_type"`
+	ProductID  uuid.UUID       `json:"product_id" db:"product_id"`
+	Payload    json.RawMessage `json:"payload" db:"payload"`
+	OccurredAt time.Time       `json:"occurred_at" db:"occurred_at"`
+	TenantID   uuid.UUID       `json:"tenant_id" db:"tenant_id"`
+
+	// Delivery state, kept by the outbox
+	Attempts      int        `json:"-" db:"attempts"`
+	LastError     string     `json:"-" db:"last_error"`
+	NextAttemptAt time.Time  `json:"-" db:"next_attempt_at"`
+	PublishedAt   *time.Time `json:"-" db:"published_at"` // nil until every publisher accepted the event
+}
+
+// NewEvent creates a new domain event
+func NewEvent(eventType string, productID uuid.UUID, payload json.RawMessage) *Event {
+	now := time.Now()
+	return &Event{
+		ID:            uuid.New(),
+		Type:          eventType,
+		ProductID:     productID,
+		Payload:       payload,
+		OccurredAt:    now,
+		NextAttemptAt: now,
+	}
+}
+
+// StockEventPayload is the payload of the stock.received, stock.shipped and stock.adjusted events
+type StockEventPayload struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	SKU           string    `json:"sku"`
+	CategoryID    uuid.UUID `json:"category_id"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Quantity      int       `json:"quantity"` // signed change for adjustments
+	StockBefore   int       `json:"stock_before"`
+	StockAfter    int       `json:"stock_after"`
+	Reference     string    `json:"reference,omitempty"`
+}
+
+// LowStockEventPayload is the payload of the stock.low_stock_reached event
+type LowStockEventPayload struct {
+	ProductID  uuid.UUID `json:"product_id"`
+	SKU        string    `json:"sku"`
+	Name       string    `json:"name"`
+	CategoryID uuid.UUID `json:"category_id"`
+	Stock      int       `json:"stock"`
+	MinStock   int       `json:"min_stock"`
+}
+
+// ProductCreatedEventPayload is the payload of the product.created event
+type ProductCreatedEventPayload struct {
+	ProductID  uuid.UUID  `json:"product_id"`
+	SKU        string     `json:"sku"`
+	Name       string     `json:"name"`
+	CategoryID uuid.UUID  `json:"category_id"`
+	Price      float64    `json:"price"`
+	Cost       float64    `json:"cost"`
+	MinStock   int        `json:"min_stock"`
+	MaxStock   int        `json:"max_stock"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// PriceChangedEventPayload is the payload of the product.price_changed event
+type PriceChangedEventPayload struct {
+	ProductID uuid.UUID `json:"product_id"`
+	SKU       string    `json:"sku"`
+	OldPrice  float64   `json:"old_price"`
+	NewPrice  float64   `json:"new_price"`
+}
+
+// AlertEventPayload is the payload of the alert.opened and alert.resolved events
+type AlertEventPayload struct {
+	AlertID    uuid.UUID `json:"alert_id"`
+	AlertType  string    `json:"alert_type"`
+	ProductID  uuid.UUID `json:"product_id"`
+	SKU        string    `json:"sku"`
+	Name       string    `json:"name"`
+	CategoryID uuid.UUID `json:"category_id"`
+	Stock      int       `json:"stock"`
+	Threshold  int       `json:"threshold"`
+}
+
+// EventTypes lists every domain event type
+var EventTypes = []string{
+	EventStockReceived, EventStockShipped, EventStockAdjusted, EventLowStockReached,
+	EventProductCreated, EventProductPriceChanged, EventAlertOpened, EventAlertResolved,
+}
+
+// IsValidEventType checks if the event type exists
+func IsValidEventType(eventType string) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// StockEventTypes lists the event types of stock movements
+var StockEventTypes = []string{EventStockReceived, EventStockShipped, EventStockAdjusted}
+
+// IsStockEventType checks if the event type records a stock movement
+func IsStockEventType(eventType string) bool {
+	for _, t := range StockEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// StockEventType returns the event type recording a transaction of the given type
+func StockEventType(transactionType string) string {
+	switch transactionType {
+	case TransactionTypeIn:
+		return EventStockReceived
+	case TransactionTypeOut:
+		return EventStockShipped
+	default:
+		return EventStockAdjusted
+	}
+}
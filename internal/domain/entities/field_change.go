@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FieldChange records the old and new value of a field changed by an edit.
+// A nil value means the field was absent or null.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// DiffDocuments lists the top-level fields that differ between two JSON documents, sorted by name
+func DiffDocuments(before, after map[string]interface{}) []FieldChange {
+	fields := make(map[string]bool)
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	var changes []FieldChange
+	for field := range fields {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changes = append(changes, FieldChange{Field: field, From: before[field], To: after[field]})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Forecast models
+const (
+	ForecastModelMovingAverage        = "moving_average"
+	ForecastModelExponentialSmoothing = "exponential_smoothing"
+)
+
+// Largest forecast settings a request may ask for; the history and the forecast are held in memory per day
+const (
+	MaxForecastHistoryDays = 3650
+	MaxForecastWindow      = 365
+	MaxForecastHorizon     = 365
+)
+
+// ForecastParams holds the settings used to forecast demand
+type ForecastParams struct {
+	Model        string
+	HistoryDays  int     // days of outbound transactions used as history
+	Window       int     // moving average window in days
+	Alpha        float64 // level smoothing factor
+	Beta         float64 // trend smoothing factor
+	Gamma        float64 // seasonal smoothing factor
+	SeasonLength int     // season length in days, e.g. 7 for weekly seasonality
+	Horizon      int     // days to forecast
+	ServiceLevel float64 // target probability of not running out during the lead time
+	LeadTimeDays int     // fallback lead time for products without a supplier
+}
+
+// DemandForecast is the forecast and the resulting stock recommendation for a product
+type DemandForecast struct {
+	ProductID          uuid.UUID `json:"product_id"`
+	Model              string    `json:"model"`
+	HistoryStart       time.Time `json:"history_start"`
+	HistoryEnd         time.Time `json:"history_end"`
+	AverageDailyDemand float64   `json:"average_daily_demand"`
+	DemandStdDev       float64   `json:"demand_std_dev"`
+	LeadTimeDays       int       `json:"lead_time_days"`
+	ServiceLevel       float64   `json:"service_level"`
+	SafetyStock        int       `json:"safety_stock"`
+	ReorderPoint       int       `json:"reorder_point"`
+	DailyForecast      []float64 `json:"daily_forecast"`
+}
+
+// IsValidForecastModel checks if the model is supported
+func IsValidForecastModel(model string) bool {
+	return model == ForecastModelMovingAverage || model == ForecastModelExponentialSmoothing
+}
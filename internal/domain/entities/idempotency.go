@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"context"
+	"time"
+)
+
+// idempotencyContextKey is the type of the context key holding the request's Idempotency-Key
+type idempotencyContextKey struct{}
+
+// IdempotencyKeyContextKey is the context key under which the Idempotency-Key of the current request is stored
+var IdempotencyKeyContextKey = idempotencyContextKey{}
+
+// IdempotencyKeyFromContext returns the Idempotency-Key of the current request, or "" when there is none
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(IdempotencyKeyContextKey).(string)
+	return key
+}
+
+// IdempotencyRecord stores the response to a request sent with an Idempotency-Key
+type IdempotencyRecord struct {
+	Key         string    `json:"key"`
+	RequestHash string    `json:"request_hash"`
+	StatusCode  int       `json:"status_code"` // zero while the request is being processed
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// NewIdempotencyRecord creates a record for a request that is about to be processed
+func NewIdempotencyRecord(key, requestHash string, ttl time.Duration) *IdempotencyRecord {
+	now := time.Now()
+	return &IdempotencyRecord{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+// IsComplete checks if the response of the request has been stored
+func (r *IdempotencyRecord) IsComplete() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductMovement summarizes a product's stock movements over a date range
+type ProductMovement struct {
+	ProductID      uuid.UUID
+	SKU            string
+	Name           string
+	CategoryID     uuid.UUID
+	CategoryName   string
+	UnitCost       float64
+	OpeningStock   int
+	In             int
+	Out            int
+	Adjustments    int // signed sum of adjustments
+	ClosingStock   int
+	LastMovementAt *time.Time
+}
+
+// DeadStockItem is a product holding stock that has not moved since a cut-off
+type DeadStockItem struct {
+	ProductID      uuid.UUID
+	SKU            string
+	Name           string
+	CategoryID     uuid.UUID
+	CategoryName   string
+	Stock          int
+	UnitCost       float64
+	LastMovementAt *time.Time
+}
+
+// Value returns the stock value at the product's current cost
+func (d *DeadStockItem) Value() float64 {
+	return float64(d.Stock) * d.UnitCost
+}
+
+// InventoryTurnover returns how many times the average stock was shipped, or nil without average stock
+func InventoryTurnover(out int, averageStock float64) *float64 {
+	if averageStock <= 0 {
+		return nil
+	}
+	turnover := float64(out) / averageStock
+	return &turnover
+}
+
+// DaysOnHand returns how many days the average stock lasts at the shipping rate of the period,
+// or nil when nothing shipped
+func DaysOnHand(out int, averageStock float64, days int) *float64 {
+	if out <= 0 {
+		return nil
+	}
+	onHand := averageStock / (float64(out) / float64(days))
+	return &onHand
+}
@@ -7,19 +7,29 @@ import (
 
 // Product represents a product entity in the inventory domain
 type Product struct {
-	ID          uuid.UUID `json:"id" db:"id"`
-	SKU         string    `json:"sku" db:"sku"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description" db:"description"`
-	CategoryID  uuid.UUID `json:"category_id" db:"category_id"`
-	Price       float64   `json:"price" db:"price"`
-	Cost        float64   `json:"cost" db:"cost"`
-	Stock       int       `json:"stock" db:"stock"`
-	MinStock    int       `json:"min_stock" db:"min_stock"`
-	MaxStock    int       `json:"max_stock" db:"max_stock"`
-	Status      string    `json:"status" db:"status"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID          uuid.UUID  `json:"id" db:"id"`
+	SKU         string     `json:"sku" db:"sku"`
+	Name        string     `json:"name" db:"name"`
+	Description string     `json:"description" db:"description"`
+	CategoryID  uuid.UUID  `json:"category_id" db:"category_id"`
+	Price       float64    `json:"price" db:"price"`
+	Cost        float64    `json:"cost" db:"cost"`
+	Stock       int        `json:"stock" db:"stock"`
+	MinStock    int        `json:"min_stock" db:"min_stock"`
+	MaxStock    int        `json:"max_stock" db:"max_stock"`
+	Status      string     `json:"status" db:"status"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	Version     int        `json:"version" db:"version"` // incremented on every catalog edit, not on stock movements
+	TenantID    uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	DeletedAt   *time.Time `json:"deleted_at" db:"deleted_at"` // set while the product is soft-deleted
+
+	// Variant support: a parent product lists its VariantAxes (e.g. "size",
+	// "color") while each child variant points to its parent through ParentID
+	// and carries its own Attributes (e.g. size=M, color=red).
+	ParentID    *uuid.UUID        `json:"parent_id" db:"parent_id"`
+	VariantAxes []string          `json:"variant_axes" db:"variant_axes"`
+	Attributes  map[string]string `json:"attributes" db:"attributes"`
 }
 
 // NewProduct creates a new product instance
@@ -38,9 +48,26 @@ func NewProduct(sku, name, description string, categoryID uuid.UUID, price, cost
 		Status:      "active",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
+		Version:     1,
 	}
 }
 
+// IsVariant checks if the product is a variant of a parent product
+func (p *Product) IsVariant() bool {
+	return p.ParentID != nil
+}
+
+// IsVariantParent checks if the product groups variants; its stock is the
+// sum of its variants' stock and cannot be moved directly
+func (p *Product) IsVariantParent() bool {
+	return len(p.VariantAxes) > 0
+}
+
+// IsDeleted checks if the product is soft-deleted
+func (p *Product) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
+
 // IsLowStock checks if the product stock is below minimum threshold
 func (p *Product) IsLowStock() bool {
 	return p.Stock <= p.MinStock
@@ -61,6 +88,11 @@ func (p *Product) UpdateStock(quantity int) error {
 	return nil
 }
 
+// IsValidProductStatus checks if a product can have the status
+func IsValidProductStatus(status string) bool {
+	return status == "active" || status == "inactive"
+}
+
 // Deactivate marks the product as inactive
 func (p *Product) Deactivate() {
 	p.Status = "inactive"
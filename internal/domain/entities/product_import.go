@@ -0,0 +1,14 @@
+package entities
+
+// Product import modes
+const (
+	// ImportModeAllOrNothing commits the import only when every row is valid
+	ImportModeAllOrNothing = "all_or_nothing"
+	// ImportModeBestEffort commits the valid rows and reports the others
+	ImportModeBestEffort = "best_effort"
+)
+
+// IsValidImportMode checks if the import mode is supported
+func IsValidImportMode(mode string) bool {
+	return mode == ImportModeAllOrNothing || mode == ImportModeBestEffort
+}
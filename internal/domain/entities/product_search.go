@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Markers around the matched words in search highlights. Control characters cannot clash with the markup
+// the highlights are rendered in, so the caller can escape the text before replacing them.
+const (
+	SearchHighlightStart = "\x02"
+	SearchHighlightStop  = "\x03"
+)
+
+// Stock states counted by the search facets
+const (
+	StockStateInStock = "in_stock" // stock above the minimum
+	StockStateLow     = "low"      // stock at or below the minimum
+	StockStateOut     = "out"      // no stock
+)
+
+// StockStates lists the stock states in display order
+var StockStates = []string{StockStateInStock, StockStateLow, StockStateOut}
+
+// maxPriceBucketBounds limits the number of price facet buckets
+const maxPriceBucketBounds = 20
+
+// ProductSearch holds the criteria of a product search
+type ProductSearch struct {
+	// Query is matched against the words of the SKU, name and description, the name allowing for typos,
+	// and as a prefix of the SKU. An empty query matches every product.
+	Query          string
+	CategoryIDs    []uuid.UUID // empty matches every category
+	Statuses       []string    // product statuses to match
+	InStockOnly    bool
+	IncludeDeleted bool
+	Limit          int
+	Offset         int
+
+	// Facets asks for the facet counts of all hits, bucketing prices by PriceBuckets
+	Facets       bool
+	PriceBuckets []float64
+}
+
+// ProductSearchHit is a product found by a search
+type ProductSearchHit struct {
+	Product *Product
+	Rank    float64 // relevance; higher ranks first
+	// Name and DescriptionSnippet are the name and the best matching fragments of the description with the
+	// matched words between SearchHighlightStart and SearchHighlightStop
+	Name               string
+	DescriptionSnippet string
+}
+
+// ProductSearchResult is a page of search hits, most relevant first
+type ProductSearchResult struct {
+	Hits   []*ProductSearchHit
+	Total  int            // hits across all pages
+	Facets *ProductFacets // set when asked for
+}
+
+// ProductFacets counts the hits of a search across all pages by their values
+type ProductFacets struct {
+	Categories  map[uuid.UUID]int // by the product's own category, not rolled up to parents
+	Statuses    map[string]int
+	StockStates map[string]int
+	// PriceBuckets has one count more than there are bucket bounds: bucket i counts the prices from
+	// bound i-1 up to bound i, the first from 0 and the last without an upper bound
+	PriceBuckets []int
+}
+
+// ParsePriceBuckets parses comma-separated, ascending price bucket bounds
+func ParsePriceBuckets(value string) ([]float64, error) {
+	var bounds []float64
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		bound, err := strconv.ParseFloat(field, 64)
+		if err != nil || math.IsInf(bound, 0) || math.IsNaN(bound) || bound <= 0 {
+			return nil, ErrInvalidPriceBuckets
+		}
+		if len(bounds) > 0 && bound <= bounds[len(bounds)-1] {
+			return nil, ErrInvalidPriceBuckets
+		}
+		bounds = append(bounds, bound)
+	}
+
+	if len(bounds) > maxPriceBucketBounds {
+		return nil, ErrInvalidPriceBuckets
+	}
+
+	return bounds, nil
+}
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// PurchaseOrder represents an order placed with a supplier
+type PurchaseOrder struct {
+	ID         uuid.UUID            `json:"id" db:"id"`
+	Number     string               `json:"number" db:"number"`
+	SupplierID uuid.UUID            `json:"supplier_id" db:"supplier_id"`
+	Status     string               `json:"status" db:"status"` // "draft", "open", "received", "cancelled"
+	ExpectedAt *time.Time           `json:"expected_at" db:"expected_at"`
+	Notes      string               `json:"notes" db:"notes"`
+	Lines      []*PurchaseOrderLine `json:"lines"`
+	CreatedBy  uuid.UUID            `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// PurchaseOrderLine represents a product ordered on a purchase order
+type PurchaseOrderLine struct {
+	ID               uuid.UUID `json:"id" db:"id"`
+	PurchaseOrderID  uuid.UUID `json:"purchase_order_id" db:"purchase_order_id"`
+	ProductID        uuid.UUID `json:"product_id" db:"product_id"`
+	Quantity         int       `json:"quantity" db:"quantity"`
+	ReceivedQuantity int       `json:"received_quantity" db:"received_quantity"`
+	UnitCost         float64   `json:"unit_cost" db:"unit_cost"`
+}
+
+const (
+	PurchaseOrderStatusDraft     = "draft"
+	PurchaseOrderStatusOpen      = "open"
+	PurchaseOrderStatusReceived  = "received"
+	PurchaseOrderStatusCancelled = "cancelled"
+)
+
+// NewPurchaseOrder creates a new draft purchase order
+func NewPurchaseOrder(number string, supplierID uuid.UUID, expectedAt *time.Time, notes string, createdBy uuid.UUID) *PurchaseOrder {
+	return &PurchaseOrder{
+		ID:         uuid.New(),
+		Number:     number,
+		SupplierID: supplierID,
+		Status:     PurchaseOrderStatusDraft,
+		ExpectedAt: expectedAt,
+		Notes:      notes,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// AddLine adds a product line to the purchase order
+func (po *PurchaseOrder) AddLine(productID uuid.UUID, quantity int, unitCost float64) {
+	po.Lines = append(po.Lines, &PurchaseOrderLine{
+		ID:              uuid.New(),
+		PurchaseOrderID: po.ID,
+		ProductID:       productID,
+		Quantity:        quantity,
+		UnitCost:        unitCost,
+	})
+}
+
+// Total returns the total cost of the purchase order
+func (po *PurchaseOrder) Total() float64 {
+	var total float64
+	for _, line := range po.Lines {
+		total += float64(line.Quantity) * line.UnitCost
+	}
+	return total
+}
+
+// Submit moves a draft purchase order to open
+func (po *PurchaseOrder) Submit() error {
+	if po.Status != PurchaseOrderStatusDraft {
+		return ErrInvalidPurchaseOrderStatus
+	}
+	po.Status = PurchaseOrderStatusOpen
+	po.UpdatedAt = time.Now()
+	return nil
+}
+
+// Cancel cancels a draft or open purchase order
+func (po *PurchaseOrder) Cancel() error {
+	if po.Status != PurchaseOrderStatusDraft && po.Status != PurchaseOrderStatusOpen {
+		return ErrInvalidPurchaseOrderStatus
+	}
+	po.Status = PurchaseOrderStatusCancelled
+	po.UpdatedAt = time.Now()
+	return nil
+}
+
+// OutstandingQuantity returns the quantity still expected on the line
+func (l *PurchaseOrderLine) OutstandingQuantity() int {
+	if l.ReceivedQuantity >= l.Quantity {
+		return 0
+	}
+	return l.Quantity - l.ReceivedQuantity
+}
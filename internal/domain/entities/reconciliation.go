@@ -0,0 +1,104 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reconciliation run statuses
+const (
+	ReconciliationStatusCompleted = "completed"
+	ReconciliationStatusFailed    = "failed"
+)
+
+// LedgerBalance compares a product's stored stock with the sum of its signed transactions
+type LedgerBalance struct {
+	ProductID         uuid.UUID
+	SKU               string
+	Name              string
+	Stock             int
+	LedgerStock       int
+	Transactions      int
+	LastTransactionAt *time.Time
+}
+
+// Difference returns how far the stored stock is ahead of the ledger
+func (b *LedgerBalance) Difference() int {
+	return b.Stock - b.LedgerStock
+}
+
+// ReconciliationRun records one comparison of stored stock against the transaction ledger
+type ReconciliationRun struct {
+	ID              uuid.UUID             `json:"id" db:"id"`
+	Repair          bool                  `json:"repair" db:"repair"`
+	Status          string                `json:"status" db:"status"` // "completed", "failed"
+	ProductsChecked int                   `json:"products_checked" db:"products_checked"`
+	Discrepancies   int                   `json:"discrepancies" db:"discrepancies"`
+	Repaired        int                   `json:"repaired" db:"repaired"`
+	Error           string                `json:"error" db:"error"`
+	CreatedBy       uuid.UUID             `json:"created_by" db:"created_by"`
+	StartedAt       time.Time             `json:"started_at" db:"started_at"`
+	FinishedAt      time.Time             `json:"finished_at" db:"finished_at"`
+	Items           []*ReconciliationItem `json:"items"`
+}
+
+// ReconciliationItem is a product whose stored stock did not match its ledger
+type ReconciliationItem struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	RunID             uuid.UUID  `json:"run_id" db:"run_id"`
+	ProductID         uuid.UUID  `json:"product_id" db:"product_id"`
+	SKU               string     `json:"sku" db:"sku"`
+	Name              string     `json:"name" db:"name"`
+	Stock             int        `json:"stock" db:"stock"`
+	LedgerStock       int        `json:"ledger_stock" db:"ledger_stock"`
+	Difference        int        `json:"difference" db:"difference"`
+	Transactions      int        `json:"transactions" db:"transactions"`
+	LastTransactionAt *time.Time `json:"last_transaction_at" db:"last_transaction_at"`
+	AdjustmentID      *uuid.UUID `json:"adjustment_id" db:"adjustment_id"`
+}
+
+// NewReconciliationRun starts a new reconciliation run
+func NewReconciliationRun(repair bool, createdBy uuid.UUID) *ReconciliationRun {
+	return &ReconciliationRun{
+		ID:        uuid.New(),
+		Repair:    repair,
+		CreatedBy: createdBy,
+		StartedAt: time.Now(),
+	}
+}
+
+// AddDiscrepancy records a product whose stock does not match its ledger
+func (r *ReconciliationRun) AddDiscrepancy(balance *LedgerBalance) *ReconciliationItem {
+	item := &ReconciliationItem{
+		ID:                uuid.New(),
+		RunID:             r.ID,
+		ProductID:         balance.ProductID,
+		SKU:               balance.SKU,
+		Name:              balance.Name,
+		Stock:             balance.Stock,
+		LedgerStock:       balance.LedgerStock,
+		Difference:        balance.Difference(),
+		Transactions:      balance.Transactions,
+		LastTransactionAt: balance.LastTransactionAt,
+	}
+	r.Items = append(r.Items, item)
+	r.Discrepancies++
+	return item
+}
+
+// Reference returns the reference used on the correcting adjustments of the run
+func (r *ReconciliationRun) Reference() string {
+	return fmt.Sprintf("RECON-%s", r.ID.String()[:8])
+}
+
+// Finish marks the run as completed, or failed when err is not nil
+func (r *ReconciliationRun) Finish(err error) {
+	r.Status = ReconciliationStatusCompleted
+	if err != nil {
+		r.Status = ReconciliationStatusFailed
+		r.Error = err.Error()
+	}
+	r.FinishedAt = time.Now()
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+// Replenishment policies
+const (
+	// ReplenishmentPolicyMax orders enough to bring the inventory position back up to MaxStock
+	ReplenishmentPolicyMax = "max"
+	// ReplenishmentPolicyEOQ orders the economic order quantity
+	ReplenishmentPolicyEOQ = "eoq"
+)
+
+// ReplenishmentParams holds the cost parameters used by the EOQ policy
+type ReplenishmentParams struct {
+	OrderingCost    float64 // fixed cost of placing one order
+	HoldingCostRate float64 // yearly holding cost as a fraction of the unit cost
+}
+
+// ReplenishmentProposal is a suggested order for a product that reached its reorder point
+type ReplenishmentProposal struct {
+	ProductID          uuid.UUID  `json:"product_id"`
+	SKU                string     `json:"sku"`
+	Name               string     `json:"name"`
+	SupplierID         *uuid.UUID `json:"supplier_id"`
+	Policy             string     `json:"policy"`
+	Stock              int        `json:"stock"`
+	Reserved           int        `json:"reserved"`
+	OnOrder            int        `json:"on_order"`
+	InventoryPosition  int        `json:"inventory_position"`
+	ReorderPoint       int        `json:"reorder_point"`
+	MinStock           int        `json:"min_stock"`
+	MaxStock           int        `json:"max_stock"`
+	AverageDailyDemand float64    `json:"average_daily_demand"`
+	LeadTimeDays       int        `json:"lead_time_days"`
+	MinOrderQty        int        `json:"min_order_qty"`
+	OrderMultiple      int        `json:"order_multiple"`
+	SuggestedQuantity  int        `json:"suggested_quantity"`
+	UnitCost           float64    `json:"unit_cost"`
+}
+
+// IsValidReplenishmentPolicy checks if the policy is supported
+func IsValidReplenishmentPolicy(policy string) bool {
+	return policy == ReplenishmentPolicyMax || policy == ReplenishmentPolicyEOQ
+}
+
+// LineCost returns the cost of ordering the suggested quantity
+func (p *ReplenishmentProposal) LineCost() float64 {
+	return float64(p.SuggestedQuantity) * p.UnitCost
+}
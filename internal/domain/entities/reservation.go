@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Reservation represents stock set aside for an order that has not shipped yet
+type Reservation struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	ProductID uuid.UUID  `json:"product_id" db:"product_id"`
+	Quantity  int        `json:"quantity" db:"quantity"`
+	Reference string     `json:"reference" db:"reference"`
+	Status    string     `json:"status" db:"status"` // "active", "released", "fulfilled"
+	ExpiresAt *time.Time `json:"expires_at" db:"expires_at"`
+	CreatedBy uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusReleased  = "released"
+	ReservationStatusFulfilled = "fulfilled"
+)
+
+// NewReservation creates a new active reservation
+func NewReservation(productID uuid.UUID, quantity int, reference string, expiresAt *time.Time, createdBy uuid.UUID) *Reservation {
+	return &Reservation{
+		ID:        uuid.New(),
+		ProductID: productID,
+		Quantity:  quantity,
+		Reference: reference,
+		Status:    ReservationStatusActive,
+		ExpiresAt: expiresAt,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// IsActive checks if the reservation still holds stock
+func (r *Reservation) IsActive() bool {
+	return r.Status == ReservationStatusActive
+}
+
+// Release releases the reserved stock
+func (r *Reservation) Release() error {
+	if !r.IsActive() {
+		return ErrReservationNotActive
+	}
+	r.Status = ReservationStatusReleased
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Fulfill marks the reservation as shipped
+func (r *Reservation) Fulfill() error {
+	if !r.IsActive() {
+		return ErrReservationNotActive
+	}
+	r.Status = ReservationStatusFulfilled
+	r.UpdatedAt = time.Now()
+	return nil
+}
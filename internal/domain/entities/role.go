@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Roles, from least to most privileged; each role has every permission of the roles before it
+const (
+	RoleViewer     = "viewer"
+	RoleClerk      = "clerk"
+	RoleSupervisor = "supervisor"
+	RoleAdmin      = "admin"
+)
+
+// Permission names an operation that a role may be granted
+type Permission string
+
+const (
+	PermissionProductView       Permission = "product:view"
+	PermissionProductCreate     Permission = "product:create"
+	PermissionProductUpdate     Permission = "product:update"
+	PermissionProductDelete     Permission = "product:delete"
+	PermissionProductImport     Permission = "product:import"
+	PermissionCategoryView      Permission = "category:view"
+	PermissionCategoryUpdate    Permission = "category:update"
+	PermissionCategoryDelete    Permission = "category:delete"
+	PermissionStockView         Permission = "stock:view"
+	PermissionStockIn           Permission = "stock:in"
+	PermissionStockOut          Permission = "stock:out"
+	PermissionStockAdjust       Permission = "stock:adjust"
+	PermissionStockReserve      Permission = "stock:reserve"
+	PermissionSupplierView      Permission = "supplier:view"
+	PermissionSupplierManage    Permission = "supplier:manage"
+	PermissionPurchaseView      Permission = "purchase:view"
+	PermissionPurchaseManage    Permission = "purchase:manage"
+	PermissionReportView        Permission = "report:view"
+	PermissionReconciliationRun Permission = "reconciliation:run"
+	PermissionRoleManage        Permission = "role:manage"
+	PermissionAPIKeyManage      Permission = "apikey:manage"
+	PermissionAuditView         Permission = "audit:view"
+	PermissionWebhookManage     Permission = "webhook:manage"
+)
+
+// Roles lists the roles from least to most privileged
+var Roles = []string{RoleViewer, RoleClerk, RoleSupervisor, RoleAdmin}
+
+// roleGrants lists the permissions each role adds to the role before it
+var roleGrants = map[string][]Permission{
+	RoleViewer: {
+		PermissionProductView, PermissionCategoryView, PermissionStockView, PermissionSupplierView,
+		PermissionPurchaseView, PermissionReportView,
+	},
+	RoleClerk: {
+		PermissionStockIn, PermissionStockOut, PermissionStockReserve,
+	},
+	RoleSupervisor: {
+		PermissionStockAdjust, PermissionProductCreate, PermissionProductUpdate, PermissionProductImport,
+		PermissionCategoryUpdate, PermissionSupplierManage, PermissionPurchaseManage, PermissionReconciliationRun,
+		PermissionAuditView,
+	},
+	RoleAdmin: {
+		PermissionProductDelete, PermissionCategoryDelete, PermissionRoleManage, PermissionAPIKeyManage,
+		PermissionWebhookManage,
+	},
+}
+
+// IsValidRole checks if the role is supported
+func IsValidRole(role string) bool {
+	_, ok := roleGrants[role]
+	return ok
+}
+
+// IsValidPermission checks if the permission exists
+func IsValidPermission(permission Permission) bool {
+	return RoleHasPermission(RoleAdmin, permission)
+}
+
+// RolePermissions returns every permission of a role, including those inherited from lower roles
+func RolePermissions(role string) []Permission {
+	var permissions []Permission
+	for _, r := range Roles {
+		permissions = append(permissions, roleGrants[r]...)
+		if r == role {
+			return permissions
+		}
+	}
+	return nil
+}
+
+// RoleHasPermission checks if a role grants a permission
+func RoleHasPermission(role string, permission Permission) bool {
+	for _, p := range RolePermissions(role) {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleAssignment records the role given to a user
+type RoleAssignment struct {
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	Role       string    `json:"role" db:"role"`
+	AssignedBy uuid.UUID `json:"assigned_by" db:"assigned_by"`
+	AssignedAt time.Time `json:"assigned_at" db:"assigned_at"`
+}
+
+// NewRoleAssignment creates a new role assignment
+func NewRoleAssignment(userID uuid.UUID, role string, assignedBy uuid.UUID) *RoleAssignment {
+	return &RoleAssignment{
+		UserID:     userID,
+		Role:       role,
+		AssignedBy: assignedBy,
+		AssignedAt: time.Now(),
+	}
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+// MaxMovementBatchSize is the maximum number of lines in one stock movement batch
+const MaxMovementBatchSize = 1000
+
+// StockMovement is one line of a stock movement batch.
+// Quantity is the number of units moved for in and out lines, and the new stock level for adjustments.
+type StockMovement struct {
+	ProductID uuid.UUID
+	Type      string
+	Quantity  int
+	Notes     string
+}
+
+// StockMovementResult is the outcome of one line of a stock movement batch
+type StockMovementResult struct {
+	Line        int
+	Movement    *StockMovement
+	StockBefore int
+	StockAfter  int
+	Transaction *Transaction
+	Err         error
+}
+
+// Apply applies the movement to the product's stock and returns the transaction recording it
+func (m *StockMovement) Apply(product *Product, reference string, userID uuid.UUID) (*Transaction, error) {
+	if !IsValidTransactionType(m.Type) {
+		return nil, ErrInvalidTransactionType
+	}
+
+	if product.IsVariantParent() {
+		return nil, ErrVariantParentStock
+	}
+
+	quantity := m.Quantity
+	switch m.Type {
+	case TransactionTypeIn:
+		if quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		if err := product.UpdateStock(quantity); err != nil {
+			return nil, err
+		}
+	case TransactionTypeOut:
+		if quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		if err := product.UpdateStock(-quantity); err != nil {
+			return nil, err
+		}
+	case TransactionTypeAdjustment:
+		if quantity < 0 {
+			return nil, ErrInvalidQuantity
+		}
+		// The ledger records the change, not the new level
+		quantity = m.Quantity - product.Stock
+		if err := product.UpdateStock(quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewTransaction(product.ID, m.Type, quantity, reference, m.Notes, userID), nil
+}
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+// StockPosition is the quantity a product had on hand at a point in time
+type StockPosition struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	SKU          string    `json:"sku"`
+	Name         string    `json:"name"`
+	CategoryID   uuid.UUID `json:"category_id"`
+	CategoryName string    `json:"category_name"`
+	Quantity     int       `json:"quantity"`
+	UnitCost     float64   `json:"unit_cost"`
+}
+
+// Value returns the stock value at the product's current cost
+func (p *StockPosition) Value() float64 {
+	return float64(p.Quantity) * p.UnitCost
+}
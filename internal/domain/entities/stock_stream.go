@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// StockStreamFilter selects the stock events a stream subscriber receives; empty lists match everything
+type StockStreamFilter struct {
+	ProductIDs  []uuid.UUID
+	CategoryIDs []uuid.UUID // the categories of the products, subcategories included by the caller
+}
+
+// Matches checks if a stock event passes the filter
+func (f *StockStreamFilter) Matches(event *Event) bool {
+	if !IsStockEventType(event.Type) {
+		return false
+	}
+
+	if len(f.ProductIDs) > 0 && !containsUUID(f.ProductIDs, event.ProductID) {
+		return false
+	}
+
+	if len(f.CategoryIDs) > 0 {
+		var payload StockEventPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil || !containsUUID(f.CategoryIDs, payload.CategoryID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsUUID checks if a list holds an ID
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
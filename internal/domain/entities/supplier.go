@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Supplier represents a vendor products are purchased from
+type Supplier struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	Email        string    `json:"email" db:"email"`
+	Phone        string    `json:"phone" db:"phone"`
+	LeadTimeDays int       `json:"lead_time_days" db:"lead_time_days"`
+	Status       string    `json:"status" db:"status"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ProductSupplier holds the purchasing terms of a product at a supplier
+type ProductSupplier struct {
+	ProductID     uuid.UUID `json:"product_id" db:"product_id"`
+	SupplierID    uuid.UUID `json:"supplier_id" db:"supplier_id"`
+	SupplierSKU   string    `json:"supplier_sku" db:"supplier_sku"`
+	UnitCost      float64   `json:"unit_cost" db:"unit_cost"`
+	LeadTimeDays  int       `json:"lead_time_days" db:"lead_time_days"`
+	MinOrderQty   int       `json:"min_order_qty" db:"min_order_qty"`
+	OrderMultiple int       `json:"order_multiple" db:"order_multiple"`
+	IsPreferred   bool      `json:"is_preferred" db:"is_preferred"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewSupplier creates a new supplier instance
+func NewSupplier(name, email, phone string, leadTimeDays int) *Supplier {
+	return &Supplier{
+		ID:           uuid.New(),
+		Name:         name,
+		Email:        email,
+		Phone:        phone,
+		LeadTimeDays: leadTimeDays,
+		Status:       "active",
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+// NewProductSupplier creates new purchasing terms for a product at a supplier
+func NewProductSupplier(productID, supplierID uuid.UUID, supplierSKU string, unitCost float64, leadTimeDays, minOrderQty, orderMultiple int, isPreferred bool) *ProductSupplier {
+	return &ProductSupplier{
+		ProductID:     productID,
+		SupplierID:    supplierID,
+		SupplierSKU:   supplierSKU,
+		UnitCost:      unitCost,
+		LeadTimeDays:  leadTimeDays,
+		MinOrderQty:   minOrderQty,
+		OrderMultiple: orderMultiple,
+		IsPreferred:   isPreferred,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// IsActive checks if the supplier is active
+func (s *Supplier) IsActive() bool {
+	return s.Status == "active"
+}
+
+// Deactivate marks the supplier as inactive
+func (s *Supplier) Deactivate() {
+	s.Status = "inactive"
+	s.UpdatedAt = time.Now()
+}
+
+// Activate marks the supplier as active
+func (s *Supplier) Activate() {
+	s.Status = "active"
+	s.UpdatedAt = time.Now()
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenantID is the tenant that owns the data created before multi-tenancy was introduced
+var DefaultTenantID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Tenant is an organization whose inventory is kept apart from every other tenant's
+type Tenant struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
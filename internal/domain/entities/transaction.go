@@ -15,6 +15,9 @@ type Transaction struct {
 	Notes     string    `json:"notes" db:"notes"`
 	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	TenantID  uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	// IdempotencyKey is the Idempotency-Key of the request that recorded the transaction, if any
+	IdempotencyKey string `json:"-" db:"idempotency_key"`
 }
 
 const (
@@ -23,6 +26,15 @@ const (
 	TransactionTypeAdjustment = "adjustment"
 )
 
+// IsValidTransactionType checks if the transaction type is supported
+func IsValidTransactionType(transactionType string) bool {
+	switch transactionType {
+	case TransactionTypeIn, TransactionTypeOut, TransactionTypeAdjustment:
+		return true
+	}
+	return false
+}
+
 // NewTransaction creates a new transaction instance
 func NewTransaction(productID uuid.UUID, transactionType string, quantity int, reference, notes string, createdBy uuid.UUID) *Transaction {
 	return &Transaction{
@@ -0,0 +1,152 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// VariantAttribute represents one axis of a variant matrix, e.g. size with values S, M, L
+type VariantAttribute struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// VariantMatrix is an ordered list of variant attributes; the order drives SKU generation
+type VariantMatrix []VariantAttribute
+
+// Validate checks that the matrix has uniquely named axes with unique, non-empty values
+func (m VariantMatrix) Validate() error {
+	if len(m) == 0 {
+		return ErrInvalidVariantMatrix
+	}
+
+	names := make(map[string]bool, len(m))
+	for _, attr := range m {
+		name := strings.TrimSpace(attr.Name)
+		if name == "" || names[name] || len(attr.Values) == 0 {
+			return ErrInvalidVariantMatrix
+		}
+		names[name] = true
+
+		values := make(map[string]bool, len(attr.Values))
+		for _, value := range attr.Values {
+			// Values differing only in case or spaces would give their variants the same SKU
+			part := variantSKUPart(strings.TrimSpace(value))
+			if part == "" || values[part] {
+				return ErrInvalidVariantMatrix
+			}
+			values[part] = true
+		}
+	}
+
+	return nil
+}
+
+// Axes returns the attribute names in matrix order
+func (m VariantMatrix) Axes() []string {
+	axes := make([]string, len(m))
+	for i, attr := range m {
+		axes[i] = strings.TrimSpace(attr.Name)
+	}
+	return axes
+}
+
+// Combinations returns the cartesian product of all attribute values
+func (m VariantMatrix) Combinations() []map[string]string {
+	combinations := []map[string]string{{}}
+	for _, attr := range m {
+		name := strings.TrimSpace(attr.Name)
+		next := make([]map[string]string, 0, len(combinations)*len(attr.Values))
+		for _, combination := range combinations {
+			for _, value := range attr.Values {
+				variant := make(map[string]string, len(combination)+1)
+				for k, v := range combination {
+					variant[k] = v
+				}
+				variant[name] = strings.TrimSpace(value)
+				next = append(next, variant)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// VariantSKU builds the SKU of a variant from its parent SKU and attribute values in axis order
+func VariantSKU(parentSKU string, axes []string, attributes map[string]string) string {
+	parts := []string{parentSKU}
+	for _, axis := range axes {
+		parts = append(parts, variantSKUPart(attributes[axis]))
+	}
+	return strings.Join(parts, "-")
+}
+
+// variantSKUPart returns the part of a variant SKU that stands for an attribute value
+func variantSKUPart(value string) string {
+	return strings.ToUpper(strings.ReplaceAll(value, " ", "-"))
+}
+
+// VariantPolicy holds values applied across all variants of a parent product.
+// Nil fields are left untouched.
+type VariantPolicy struct {
+	Price    *float64 `json:"price"`
+	Cost     *float64 `json:"cost"`
+	MinStock *int     `json:"min_stock"`
+	MaxStock *int     `json:"max_stock"`
+}
+
+// IsEmpty checks if the policy does not change anything
+func (p VariantPolicy) IsEmpty() bool {
+	return p.Price == nil && p.Cost == nil && p.MinStock == nil && p.MaxStock == nil
+}
+
+// Apply applies the policy to a product
+func (p VariantPolicy) Apply(product *Product) {
+	if p.Price != nil {
+		product.Price = *p.Price
+	}
+	if p.Cost != nil {
+		product.Cost = *p.Cost
+	}
+	if p.MinStock != nil {
+		product.MinStock = *p.MinStock
+	}
+	if p.MaxStock != nil {
+		product.MaxStock = *p.MaxStock
+	}
+	product.UpdatedAt = time.Now()
+}
+
+// NewVariant creates a child variant of the product with the given attributes.
+// The variant inherits the parent's name, category, pricing and stock thresholds.
+func (p *Product) NewVariant(sku string, attributes map[string]string) *Product {
+	variant := NewProduct(sku, p.Name+" "+variantLabel(p.VariantAxes, attributes), p.Description,
+		p.CategoryID, p.Price, p.Cost, p.MinStock, p.MaxStock)
+
+	parentID := p.ID
+	variant.ParentID = &parentID
+	variant.Attributes = attributes
+	return variant
+}
+
+// variantLabel builds a human readable label such as "(M / Red)"
+func variantLabel(axes []string, attributes map[string]string) string {
+	values := make([]string, 0, len(axes))
+	for _, axis := range axes {
+		values = append(values, attributes[axis])
+	}
+	return "(" + strings.Join(values, " / ") + ")"
+}
+
+// SameAxes checks if the given axes match the product's variant axes
+func (p *Product) SameAxes(axes []string) bool {
+	if len(p.VariantAxes) != len(axes) {
+		return false
+	}
+	for i := range axes {
+		if p.VariantAxes[i] != axes[i] {
+			return false
+		}
+	}
+	return true
+}
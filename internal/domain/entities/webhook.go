@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AllEventTypes subscribes a webhook to every event type
+const AllEventTypes = "*"
+
+// Webhook delivery statuses
+const (
+	DeliveryStatusPending   = "pending"   // waiting for its first or next attempt
+	DeliveryStatusDelivered = "delivered" // acknowledged with a 2xx response
+	DeliveryStatusDead      = "dead"      // gave up after the maximum number of attempts
+)
+
+// WebhookSubscription sends the events of the listed types to a URL, signed with the secret
+type WebhookSubscription struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	URL        string    `json:"url" db:"url"`
+	EventTypes []string  `json:"event_types" db:"event_types"`
+	Secret     string    `json:"-" db:"secret"`
+	Active     bool      `json:"active" db:"active"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	TenantID   uuid.UUID `json:"tenant_id" db:"tenant_id"`
+}
+
+// NewWebhookSubscription creates a new active webhook subscription
+func NewWebhookSubscription(url string, eventTypes []string, secret string) *WebhookSubscription {
+	return &WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        url,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Active:     true,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// Matches checks if the subscription wants events of a type
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType || t == AllEventTypes {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event to be sent to one subscription, with the state of its attempts
+type WebhookDelivery struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	SubscriptionID uuid.UUID  `json:"subscription_id" db:"subscription_id"`
+	EventID        uuid.UUID  `json:"event_id" db:"event_id"`
+	EventType      string     `json:"event_type" db:"event_type"`
+	Payload        []byte     `json:"-" db:"payload"` // the request body, identical on every attempt
+	Status         string     `json:"status" db:"status"`
+	Attempts       int        `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" db:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code" db:"last_status_code"` // 0 when no response was received
+	LastError      string     `json:"last_error" db:"last_error"`
+	DeliveredAt    *time.Time `json:"delivered_at" db:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	TenantID       uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+}
+
+// NewWebhookDelivery creates a new pending delivery of an event
+func NewWebhookDelivery(subscriptionID uuid.UUID, event *Event, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        payload,
+		Status:         DeliveryStatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+// WebhookAttempt records one HTTP request made for a delivery
+type WebhookAttempt struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	DeliveryID  uuid.UUID `json:"delivery_id" db:"delivery_id"`
+	Attempt     int       `json:"attempt" db:"attempt"`
+	StatusCode  int       `json:"status_code" db:"status_code"` // 0 when no response was received
+	Error       string    `json:"error" db:"error"`
+	DurationMS  int64     `json:"duration_ms" db:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at" db:"attempted_at"`
+}
+
+// IsValidDeliveryStatus checks if the delivery status exists
+func IsValidDeliveryStatus(status string) bool {
+	return status == DeliveryStatusPending || status == DeliveryStatusDelivered || status == DeliveryStatusDead
+}
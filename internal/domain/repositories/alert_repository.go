@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// AlertRepository defines the interface for stock alert persistence operations
+type AlertRepository interface {
+	// Open stores an open alert and reports false, storing nothing, when the product already has an open
+	// alert of the type
+	Open(ctx context.Context, alert *entities.Alert) (bool, error)
+	// GetOpenByProduct retrieves the open alerts of a product
+	GetOpenByProduct(ctx context.Context, productID uuid.UUID) ([]*entities.Alert, error)
+	// Resolve marks an open alert resolved
+	Resolve(ctx context.Context, id uuid.UUID, resolvedAt time.Time) error
+	// GetAll retrieves alerts newest first; an empty status or type matches any
+	GetAll(ctx context.Context, status, alertType string, limit, offset int) ([]*entities.Alert, error)
+}
+
+// AlertSubscriptionRepository defines the interface for alert subscriptions and the record of their notifications
+type AlertSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entities.AlertSubscription) error
+	GetByUser(ctx context.Context, userID uuid.UUID) ([]*entities.AlertSubscription, error)
+	// GetByCategories retrieves the subscriptions to any of the categories or to every category
+	GetByCategories(ctx context.Context, categoryIDs []uuid.UUID) ([]*entities.AlertSubscription, error)
+	// Delete deletes a subscription of a user
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+	// IsNotified checks if a subscription was already notified of an alert event
+	IsNotified(ctx context.Context, subscriptionID, alertID uuid.UUID, eventType string) (bool, error)
+	// MarkNotified records that a subscription was notified of an alert event
+	MarkNotified(ctx context.Context, subscriptionID, alertID uuid.UUID, eventType string) error
+}
+
+// AlertFeedRepository defines the interface for the in-app alert feed
+type AlertFeedRepository interface {
+	Create(ctx context.Context, item *entities.AlertFeedItem) error
+	// GetByUser retrieves the feed of a user newest first, only the unread items when unreadOnly is set
+	GetByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entities.AlertFeedItem, error)
+	// MarkRead marks a feed item of a user read
+	MarkRead(ctx context.Context, id, userID uuid.UUID, readAt time.Time) error
+}
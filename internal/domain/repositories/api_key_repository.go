@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// APIKeyRepository defines the interface for API key persistence operations
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entities.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error)
+	GetAll(ctx context.Context) ([]*entities.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	// TouchLastUsed records a use of the key, writing at most once per interval
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time, interval time.Duration) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// AuditRepository defines the interface for audit log persistence operations.
+// The log is append-only, so there is no update or delete.
+type AuditRepository interface {
+	Create(ctx context.Context, entry *entities.AuditEntry) error
+	// GetAll retrieves entries newest first; an empty entity type or a nil entity ID matches any
+	GetAll(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]*entities.AuditEntry, error)
+}
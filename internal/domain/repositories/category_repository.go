@@ -2,17 +2,23 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"inventory-app/internal/domain/entities"
 )
 
-// CategoryRepository defines the interface for category persistence operations
+// CategoryRepository defines the interface for category persistence operations.
+// Soft-deleted categories are left out of every read unless a method says otherwise.
 type CategoryRepository interface {
 	Create(ctx context.Context, category *entities.Category) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error)
-	GetAll(ctx context.Context) ([]*entities.Category, error)
+	GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*entities.Category, error)
+	GetAll(ctx context.Context, includeDeleted bool) ([]*entities.Category, error)
 	GetByParentID(ctx context.Context, parentID uuid.UUID) ([]*entities.Category, error)
 	GetRootCategories(ctx context.Context) ([]*entities.Category, error)
 	Update(ctx context.Context, category *entities.Category) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete soft-deletes a category
+	Delete(ctx context.Context, id uuid.UUID, deletedAt time.Time) error
+	Restore(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// ClassificationRepository defines the interface for persisting the scheduled ABC/XYZ classification
+type ClassificationRepository interface {
+	// Replace stores the classification of the tenant's products in place of the previous one
+	Replace(ctx context.Context, classifications []*entities.ProductClassification, classifiedAt time.Time) error
+	// GetProductIDs returns the products in the given classes; an empty class matches any
+	GetProductIDs(ctx context.Context, abcClass, xyzClass string) ([]uuid.UUID, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"inventory-app/internal/domain/entities"
+)
+
+// IdempotencyRepository defines the interface for Idempotency-Key persistence operations
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, record *entities.IdempotencyRecord) (bool, error)
+	GetByKey(ctx context.Context, key string) (*entities.IdempotencyRecord, error)
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	Delete(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
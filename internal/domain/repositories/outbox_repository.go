@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// OutboxRepository defines the interface for the outbox of domain events
+type OutboxRepository interface {
+	// Create writes an event for the tenant of the context and assigns its sequence; call it in the transaction of the change
+	Create(ctx context.Context, event *entities.Event) error
+	// Lock takes the dispatcher lock for the rest of the current transaction; false means another dispatcher holds it
+	Lock(ctx context.Context) (bool, error)
+	// GetPending retrieves up to limit undelivered events of every tenant in sequence order. An event waiting
+	// for a retry holds back the later events of its product, so they are never delivered out of order.
+	GetPending(ctx context.Context, limit int) ([]*entities.Event, error)
+	// GetBySequence retrieves an event of the tenant by its sequence, or nil when it does not exist (anymore)
+	GetBySequence(ctx context.Context, sequence int64) (*entities.Event, error)
+	// GetPublishedSince retrieves up to limit delivered events of the tenant of the given types, published at or
+	// after since, in the order they were published
+	GetPublishedSince(ctx context.Context, since time.Time, eventTypes []string, limit int) ([]*entities.Event, error)
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error
+	// DeletePublished deletes events delivered before the given time and returns how many were removed
+	DeletePublished(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
@@ -2,19 +2,43 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"inventory-app/internal/domain/entities"
 )
 
-// ProductRepository defines the interface for product persistence operations
+// ProductRepository defines the interface for product persistence operations.
+// Soft-deleted products are left out of every read unless a method says otherwise.
 type ProductRepository interface {
 	Create(ctx context.Context, product *entities.Product) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error)
+	GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*entities.Product, error)
 	GetBySKU(ctx context.Context, sku string) (*entities.Product, error)
-	GetAll(ctx context.Context, limit, offset int) ([]*entities.Product, error)
+	GetBySKUs(ctx context.Context, skus []string) ([]*entities.Product, error)
+	GetAll(ctx context.Context, limit, offset int, includeDeleted bool) ([]*entities.Product, error)
+	Count(ctx context.Context, includeDeleted bool) (int, error)
+	// GetByClass retrieves the products in the given classes of the stored classification; an empty class matches any
+	GetByClass(ctx context.Context, abcClass, xyzClass string, limit, offset int) ([]*entities.Product, error)
+	CountByClass(ctx context.Context, abcClass, xyzClass string) (int, error)
+	ForEach(ctx context.Context, ids []uuid.UUID, fn func(*entities.Product) error) error
+	GetForUpdate(ctx context.Context, ids []uuid.UUID) ([]*entities.Product, error)
 	GetByCategory(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*entities.Product, error)
 	Update(ctx context.Context, product *entities.Product) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	UpsertBySKU(ctx context.Context, products []*entities.Product) error
+	UpdateStock(ctx context.Context, id uuid.UUID, stock int) error
+	UpdateMinStock(ctx context.Context, id uuid.UUID, minStock int) error
+	// Delete soft-deletes a product together with its variants
+	Delete(ctx context.Context, id uuid.UUID, version int, deletedAt time.Time) error
+	// Restore undeletes a product and the variants deleted with it, returning the IDs of those variants
+	Restore(ctx context.Context, id uuid.UUID, version int) ([]uuid.UUID, error)
+	// PurgeDeleted hard-deletes products soft-deleted before the given time that have no ledger history
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 	GetLowStockProducts(ctx context.Context) ([]*entities.Product, error)
-	Search(ctx context.Context, query string, limit, offset int) ([]*entities.Product, error)
+	GetStockedProducts(ctx context.Context) ([]*entities.Product, error)
+	// Search finds the products matching a search, most relevant first
+	Search(ctx context.Context, search *entities.ProductSearch) (*entities.ProductSearchResult, error)
+	GetVariants(ctx context.Context, parentID uuid.UUID) ([]*entities.Product, error)
+	CreateVariants(ctx context.Context, parent *entities.Product, variants []*entities.Product) error
+	UpdateVariantPolicy(ctx context.Context, parentID uuid.UUID, policy entities.VariantPolicy) error
 }
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// PurchaseOrderRepository defines the interface for purchase order persistence operations
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, purchaseOrder *entities.PurchaseOrder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrder, error)
+	GetAll(ctx context.Context, status string, limit, offset int) ([]*entities.PurchaseOrder, error)
+	UpdateStatus(ctx context.Context, purchaseOrder *entities.PurchaseOrder) error
+	GetOpenQuantities(ctx context.Context) (map[uuid.UUID]int, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// ReconciliationRepository defines the interface for ledger reconciliation persistence operations
+type ReconciliationRepository interface {
+	GetLedgerBalances(ctx context.Context) ([]*entities.LedgerBalance, error)
+	PostCorrection(ctx context.Context, productID uuid.UUID, reference, notes string, createdBy uuid.UUID) (*entities.Transaction, error)
+	CreateRun(ctx context.Context, run *entities.ReconciliationRun) error
+	GetRunByID(ctx context.Context, id uuid.UUID) (*entities.ReconciliationRun, error)
+	GetRuns(ctx context.Context, limit, offset int) ([]*entities.ReconciliationRun, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// ReservationRepository defines the interface for stock reservation persistence operations
+type ReservationRepository interface {
+	Create(ctx context.Context, reservation *entities.Reservation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Reservation, error)
+	GetByProductID(ctx context.Context, productID uuid.UUID) ([]*entities.Reservation, error)
+	UpdateStatus(ctx context.Context, reservation *entities.Reservation) error
+	GetActiveQuantities(ctx context.Context) (map[uuid.UUID]int, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// RoleRepository defines the interface for role assignment persistence operations
+type RoleRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.RoleAssignment, error)
+	GetAll(ctx context.Context) ([]*entities.RoleAssignment, error)
+	Assign(ctx context.Context, assignment *entities.RoleAssignment) error
+	Revoke(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"inventory-app/internal/domain/entities"
+	"time"
+)
+
+// StockSnapshotRepository defines the interface for daily stock snapshots and point-in-time stock queries
+type StockSnapshotRepository interface {
+	CreateSnapshot(ctx context.Context, day time.Time) (int, error)
+	GetLatestSnapshotDate(ctx context.Context) (*time.Time, error)
+	GetStockAsOf(ctx context.Context, at time.Time) ([]*entities.StockPosition, error)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// SupplierRepository defines the interface for supplier persistence operations
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier *entities.Supplier) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Supplier, error)
+	GetAll(ctx context.Context, limit, offset int) ([]*entities.Supplier, error)
+	Update(ctx context.Context, supplier *entities.Supplier) error
+	UpsertProductSupplier(ctx context.Context, productSupplier *entities.ProductSupplier) error
+	GetProductSuppliers(ctx context.Context, productID uuid.UUID) ([]*entities.ProductSupplier, error)
+	GetPreferredProductSuppliers(ctx context.Context) ([]*entities.ProductSupplier, error)
+}
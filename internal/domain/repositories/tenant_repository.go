@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"inventory-app/internal/domain/entities"
+)
+
+// TenantRepository defines the interface for tenant persistence operations
+type TenantRepository interface {
+	GetAll(ctx context.Context) ([]*entities.Tenant, error)
+}
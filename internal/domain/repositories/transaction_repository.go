@@ -15,4 +15,10 @@ type TransactionRepository interface {
 	GetByType(ctx context.Context, transactionType string, limit, offset int) ([]*entities.Transaction, error)
 	GetByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) ([]*entities.Transaction, error)
 	GetAll(ctx context.Context, limit, offset int) ([]*entities.Transaction, error)
+	ForEach(ctx context.Context, startDate, endDate *time.Time, transactionType string, fn func(*entities.Transaction) error) error
+	GetOutboundQuantities(ctx context.Context, since time.Time) (map[uuid.UUID]int, error)
+	GetOutboundByPeriod(ctx context.Context, start time.Time, periodDays, periods int) (map[uuid.UUID][]int, error)
+	GetProductOutboundByPeriod(ctx context.Context, productID uuid.UUID, start time.Time, periodDays, periods int) ([]int, error)
+	GetMovementSummary(ctx context.Context, startDate, endDate time.Time, categoryID *uuid.UUID) ([]*entities.ProductMovement, error)
+	GetDeadStock(ctx context.Context, since time.Time, categoryID *uuid.UUID) ([]*entities.DeadStockItem, error)
 }
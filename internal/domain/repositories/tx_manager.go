@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+)
+
+// TxManager runs a function inside a single database transaction.
+// Repository calls made with the context passed to fn take part in the transaction.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// WebhookRepository defines the interface for webhook subscription persistence operations
+type WebhookRepository interface {
+	Create(ctx context.Context, subscription *entities.WebhookSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+	GetAll(ctx context.Context) ([]*entities.WebhookSubscription, error)
+	// GetActiveByEventType retrieves the active subscriptions that want events of a type
+	GetActiveByEventType(ctx context.Context, eventType string) ([]*entities.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entities.WebhookSubscription) error
+	// Delete deletes a subscription together with its deliveries
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// WebhookDeliveryRepository defines the interface for webhook deliveries and their attempts
+type WebhookDeliveryRepository interface {
+	// Create stores a pending delivery; a delivery of the same event to the same subscription is kept instead
+	Create(ctx context.Context, delivery *entities.WebhookDelivery) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error)
+	// GetBySubscription retrieves the deliveries of a subscription newest first; an empty status matches any
+	GetBySubscription(ctx context.Context, subscriptionID uuid.UUID, status string, limit, offset int) ([]*entities.WebhookDelivery, error)
+	// ClaimDue leases up to limit due deliveries of active subscriptions of every tenant, postponing their
+	// next attempt by lease so that no other worker picks them up meanwhile
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error)
+	// RecordAttempt stores an attempt and the delivery state it resulted in
+	RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) error
+	// Requeue makes a delivery pending again with a fresh set of attempts, due immediately
+	Requeue(ctx context.Context, id uuid.UUID) error
+	GetAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*entities.WebhookAttempt, error)
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// AlertService opens and resolves stock alerts as products cross their stock bounds
+type AlertService interface {
+	// Evaluate opens an alert for every bound the product's stock is now outside of and resolves the open
+	// alerts of bounds it is back within, recording an event for each. Call it in the transaction of the
+	// movement, after the new stock was written.
+	Evaluate(ctx context.Context, product *entities.Product) error
+}
+
+type alertService struct {
+	alertRepo repositories.AlertRepository
+	events    EventService
+}
+
+// NewAlertService creates a new alert service
+func NewAlertService(alertRepo repositories.AlertRepository, events EventService) AlertService {
+	return &alertService{
+		alertRepo: alertRepo,
+		events:    events,
+	}
+}
+
+// Evaluate compares the product's stock against its bounds and its open alerts
+func (s *alertService) Evaluate(ctx context.Context, product *entities.Product) error {
+	open, err := s.alertRepo.GetOpenByProduct(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+
+	conditions := entities.AlertConditions(product)
+	now := time.Now()
+	for _, alert := range open {
+		if conditions[alert.Type] {
+			// Still outside the bound; the open alert covers it
+			delete(conditions, alert.Type)
+			continue
+		}
+
+		if err := s.alertRepo.Resolve(ctx, alert.ID, now); err != nil {
+			return err
+		}
+		alert.ResolvedAt = &now
+		if err := s.events.AlertChanged(ctx, product, alert); err != nil {
+			return err
+		}
+	}
+
+	for _, alertType := range []string{entities.AlertTypeLowStock, entities.AlertTypeOverStock} {
+		if !conditions[alertType] {
+			continue
+		}
+
+		alert := entities.NewAlert(product.ID, alertType, product.Stock, entities.AlertThreshold(product, alertType))
+		opened, err := s.alertRepo.Open(ctx, alert)
+		if err != nil {
+			return err
+		}
+		if !opened {
+			continue
+		}
+		if err := s.events.AlertChanged(ctx, product, alert); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
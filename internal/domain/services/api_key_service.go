@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/pkg/logger"
+)
+
+// apiKeyTouchInterval limits how often the last use of a key is written
+const apiKeyTouchInterval = time.Minute
+
+// APIKeyService issues API keys and authenticates requests presenting them.
+// A key is "<prefix>.<secret>"; the prefix is stored in clear to look the key up, the secret only as a hash.
+type APIKeyService interface {
+	Issue(ctx context.Context, name string, scopes []entities.Permission, expiresAt *time.Time, createdBy uuid.UUID) (*entities.APIKey, string, error)
+	Authenticate(ctx context.Context, token string) (*entities.APIKey, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo repositories.APIKeyRepository
+	logger     *logger.Logger
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, logger *logger.Logger) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+// Issue creates and stores a new key and returns it with the full token, which is not recoverable later
+func (s *apiKeyService) Issue(ctx context.Context, name string, scopes []entities.Permission, expiresAt *time.Time, createdBy uuid.UUID) (*entities.APIKey, string, error) {
+	prefix, err := randomToken(6)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := entities.NewAPIKey(name, prefix, hashSecret(secret), scopes, expiresAt, createdBy)
+	if err := s.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, prefix + "." + secret, nil
+}
+
+// Authenticate returns the active key matching a token and records its use
+func (s *apiKeyService) Authenticate(ctx context.Context, token string) (*entities.APIKey, error) {
+	prefix, secret, ok := strings.Cut(token, ".")
+	if !ok || prefix == "" || secret == "" {
+		return nil, fmt.Errorf("%w: malformed key", entities.ErrInvalidAPIKey)
+	}
+
+	key, err := s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(key.SecretHash)) != 1 {
+		return nil, fmt.Errorf("%w: unknown key", entities.ErrInvalidAPIKey)
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil {
+		return nil, fmt.Errorf("%w: key revoked", entities.ErrInvalidAPIKey)
+	}
+	if !key.IsActive(now) {
+		return nil, fmt.Errorf("%w: key expired", entities.ErrInvalidAPIKey)
+	}
+
+	// A failed write of the last use must not reject a valid key
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, key.ID, now, apiKeyTouchInterval); err != nil {
+		s.logger.Error("Failed to record API key use", s.logger.WithFields(map[string]interface{}{
+			"api_key_id": key.ID,
+			"error":      err,
+		})...)
+	}
+
+	return key, nil
+}
+
+// randomToken returns n random bytes encoded for use in a header
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashSecret returns the hex SHA-256 of a key secret
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
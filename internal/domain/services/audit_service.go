@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// auditIgnoredFields are bookkeeping fields left out of audit diffs; they change with every write
+var auditIgnoredFields = []string{"id", "tenant_id", "created_at", "updated_at", "version"}
+
+// AuditService records entity changes in the audit log
+type AuditService interface {
+	// Record diffs the JSON form of an entity before and after a change and appends an entry with the
+	// actor, request ID and client IP of the context. before is nil for a create; an update that changed
+	// nothing is not recorded. Call it in the transaction of the change.
+	Record(ctx context.Context, entityType string, entityID uuid.UUID, action string, before, after interface{}) error
+}
+
+type auditService struct {
+	auditRepo repositories.AuditRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo repositories.AuditRepository) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// Record appends an audit entry for a change of an entity
+func (s *auditService) Record(ctx context.Context, entityType string, entityID uuid.UUID, action string, before, after interface{}) error {
+	from, err := auditDocument(before)
+	if err != nil {
+		return err
+	}
+	to, err := auditDocument(after)
+	if err != nil {
+		return err
+	}
+
+	changes := entities.DiffDocuments(from, to)
+	if len(changes) == 0 && action == entities.AuditActionUpdate {
+		return nil
+	}
+
+	var actorID *uuid.UUID
+	if userID := entities.UserIDFromContext(ctx); userID != uuid.Nil {
+		actorID = &userID
+	}
+
+	entry := entities.NewAuditEntry(entityType, entityID, action, changes, actorID,
+		entities.RequestIDFromContext(ctx), entities.ClientIPFromContext(ctx))
+	return s.auditRepo.Create(ctx, entry)
+}
+
+// auditDocument converts an entity to its JSON form without bookkeeping fields; nil converts to an empty document
+func auditDocument(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audited entity: %w", err)
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode audited entity: %w", err)
+	}
+
+	for _, field := range auditIgnoredFields {
+		delete(document, field)
+	}
+	return document, nil
+}
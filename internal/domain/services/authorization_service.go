@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/pkg/logger"
+)
+
+// AuthorizationService decides whether the acting user of a request may perform an operation
+type AuthorizationService interface {
+	RoleOf(ctx context.Context, userID uuid.UUID) (string, error)
+	Authorize(ctx context.Context, permissions ...entities.Permission) error
+}
+
+type authorizationService struct {
+	roleRepo       repositories.RoleRepository
+	defaultRole    string
+	bootstrapAdmin uuid.UUID
+	logger         *logger.Logger
+}
+
+// NewAuthorizationService creates a new authorization service.
+// Users without an assigned role get defaultRole, which may be empty for no access. The
+// bootstrapAdmin user is always an admin so the first roles can be assigned; uuid.Nil disables it.
+func NewAuthorizationService(roleRepo repositories.RoleRepository, defaultRole string, bootstrapAdmin uuid.UUID, logger *logger.Logger) AuthorizationService {
+	return &authorizationService{
+		roleRepo:       roleRepo,
+		defaultRole:    defaultRole,
+		bootstrapAdmin: bootstrapAdmin,
+		logger:         logger,
+	}
+}
+
+// RoleOf returns the role of a user
+func (s *authorizationService) RoleOf(ctx context.Context, userID uuid.UUID) (string, error) {
+	if userID == uuid.Nil {
+		return "", nil
+	}
+
+	if userID == s.bootstrapAdmin {
+		return entities.RoleAdmin, nil
+	}
+
+	assignment, err := s.roleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if assignment == nil {
+		return s.defaultRole, nil
+	}
+
+	return assignment.Role, nil
+}
+
+// Authorize returns ErrForbidden unless the acting user is granted one of the permissions: through
+// the scopes of the API key the request was authenticated with, or otherwise through the user's role.
+// Denials are logged with the request ID.
+func (s *authorizationService) Authorize(ctx context.Context, permissions ...entities.Permission) error {
+	userID := entities.UserIDFromContext(ctx)
+	fields := map[string]interface{}{
+		"request_id":  entities.RequestIDFromContext(ctx),
+		"user_id":     userID,
+		"permissions": permissions,
+	}
+
+	var principal string
+	if key := entities.APIKeyFromContext(ctx); key != nil {
+		for _, permission := range permissions {
+			if key.HasScope(permission) {
+				return nil
+			}
+		}
+		fields["api_key"] = key.Name
+		principal = "API key " + key.Name
+	} else {
+		role, err := s.RoleOf(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, permission := range permissions {
+			if entities.RoleHasPermission(role, permission) {
+				return nil
+			}
+		}
+		fields["role"] = role
+		principal = roleName(role)
+	}
+
+	s.logger.Warn("Permission denied", s.logger.WithFields(fields)...)
+
+	return fmt.Errorf("%w: %s does not grant %s", entities.ErrForbidden, principal, permissionList(permissions))
+}
+
+// permissionList formats permissions for error messages
+func permissionList(permissions []entities.Permission) string {
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = string(permission)
+	}
+	return strings.Join(names, " or ")
+}
+
+// roleName describes a role in error messages
+func roleName(role string) string {
+	if role == "" {
+		return "a user without a role"
+	}
+	return "role " + role
+}
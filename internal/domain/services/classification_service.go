@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// ClassificationService classifies products by consumption value (ABC) and demand variability (XYZ)
+type ClassificationService interface {
+	Classify(ctx context.Context, params entities.ClassificationParams) ([]*entities.ProductClassification, error)
+	Refresh(ctx context.Context, params entities.ClassificationParams) (int, error)
+}
+
+type classificationService struct {
+	productRepo        repositories.ProductRepository
+	transactionRepo    repositories.TransactionRepository
+	classificationRepo repositories.ClassificationRepository
+}
+
+// NewClassificationService creates a new classification service
+func NewClassificationService(productRepo repositories.ProductRepository, transactionRepo repositories.TransactionRepository, classificationRepo repositories.ClassificationRepository) ClassificationService {
+	return &classificationService{
+		productRepo:        productRepo,
+		transactionRepo:    transactionRepo,
+		classificationRepo: classificationRepo,
+	}
+}
+
+// Classify classifies every stocked product over the window ending at the start of today (UTC).
+// Products are returned by descending consumption value.
+func (s *classificationService) Classify(ctx context.Context, params entities.ClassificationParams) ([]*entities.ProductClassification, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	products, err := s.productRepo.GetStockedProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := params.Periods()
+	start, _ := ClassificationWindow(params)
+	demand, err := s.transactionRepo.GetOutboundByPeriod(ctx, start, params.PeriodDays, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	classifications := make([]*entities.ProductClassification, 0, len(products))
+	var totalValue float64
+	for _, product := range products {
+		series := demand[product.ID]
+
+		var quantity int
+		for _, q := range series {
+			quantity += q
+		}
+
+		classification := &entities.ProductClassification{
+			ProductID:           product.ID,
+			SKU:                 product.SKU,
+			Name:                product.Name,
+			ConsumptionQuantity: quantity,
+			ConsumptionValue:    float64(quantity) * product.Cost,
+			DemandCV:            coefficientOfVariation(series, periods),
+		}
+		classification.XYZClass = xyzClass(classification.DemandCV, params)
+
+		totalValue += classification.ConsumptionValue
+		classifications = append(classifications, classification)
+	}
+
+	sort.SliceStable(classifications, func(i, j int) bool {
+		if classifications[i].ConsumptionValue != classifications[j].ConsumptionValue {
+			return classifications[i].ConsumptionValue > classifications[j].ConsumptionValue
+		}
+		return classifications[i].SKU < classifications[j].SKU
+	})
+
+	// A product belongs to the class whose share was not yet filled before it was added,
+	// so the top product is always A even when it alone exceeds the A threshold
+	var cumulative float64
+	for _, classification := range classifications {
+		if totalValue > 0 {
+			classification.ValueShare = classification.ConsumptionValue / totalValue
+		}
+
+		switch {
+		case classification.ConsumptionValue <= 0:
+			classification.ABCClass = entities.ClassC
+		case cumulative < params.AThreshold:
+			classification.ABCClass = entities.ClassA
+		case cumulative < params.BThreshold:
+			classification.ABCClass = entities.ClassB
+		default:
+			classification.ABCClass = entities.ClassC
+		}
+
+		cumulative += classification.ValueShare
+		classification.CumulativeShare = cumulative
+	}
+
+	return classifications, nil
+}
+
+// Refresh classifies the stocked products and stores their classes for the product list filters.
+// It returns the number of products classified.
+func (s *classificationService) Refresh(ctx context.Context, params entities.ClassificationParams) (int, error) {
+	classifications, err := s.Classify(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.classificationRepo.Replace(ctx, classifications, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return len(classifications), nil
+}
+
+// ClassificationWindow returns the analysed window of whole periods ending at the start of today (UTC)
+func ClassificationWindow(params entities.ClassificationParams) (time.Time, time.Time) {
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	return end.AddDate(0, 0, -params.Periods()*params.PeriodDays), end
+}
+
+// coefficientOfVariation returns the standard deviation of the period demand divided by its mean,
+// or nil when there was no demand
+func coefficientOfVariation(series []int, periods int) *float64 {
+	values := make([]float64, periods)
+	for i, q := range series {
+		values[i] = float64(q)
+	}
+
+	m := mean(values)
+	if m <= 0 {
+		return nil
+	}
+
+	cv := stdDev(values) / m
+	return &cv
+}
+
+// xyzClass assigns the variability class; products without demand are Z
+func xyzClass(cv *float64, params entities.ClassificationParams) string {
+	switch {
+	case cv == nil:
+		return entities.ClassZ
+	case *cv <= params.XThreshold:
+		return entities.ClassX
+	case *cv <= params.YThreshold:
+		return entities.ClassY
+	default:
+		return entities.ClassZ
+	}
+}
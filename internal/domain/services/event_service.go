@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// EventService writes domain events to the outbox. Call it in the transaction of the change, after the
+// product row has been written or locked, so events of one product are sequenced in commit order.
+type EventService interface {
+	// StockMoved records the event of a stock movement, and stock.low_stock_reached when the movement
+	// took the product from above its minimum stock to or below it
+	StockMoved(ctx context.Context, product *entities.Product, transaction *entities.Transaction, stockBefore int) error
+	// ProductSaved records product.created when before is nil, and product.price_changed when the price changed
+	ProductSaved(ctx context.Context, before, after *entities.Product) error
+	// AlertChanged records alert.opened for an open alert of the product and alert.resolved for a resolved one
+	AlertChanged(ctx context.Context, product *entities.Product, alert *entities.Alert) error
+}
+
+type eventService struct {
+	outboxRepo repositories.OutboxRepository
+}
+
+// NewEventService creates a new event service
+func NewEventService(outboxRepo repositories.OutboxRepository) EventService {
+	return &eventService{outboxRepo: outboxRepo}
+}
+
+// StockMoved records the events of a stock movement
+func (s *eventService) StockMoved(ctx context.Context, product *entities.Product, transaction *entities.Transaction, stockBefore int) error {
+	err := s.emit(ctx, entities.StockEventType(transaction.Type), product.ID, entities.StockEventPayload{
+		ProductID:     product.ID,
+		SKU:           product.SKU,
+		CategoryID:    product.CategoryID,
+		TransactionID: transaction.ID,
+		Quantity:      transaction.Quantity,
+		StockBefore:   stockBefore,
+		StockAfter:    product.Stock,
+		Reference:     transaction.Reference,
+	})
+	if err != nil {
+		return err
+	}
+
+	if stockBefore <= product.MinStock || !product.IsLowStock() {
+		return nil
+	}
+
+	return s.emit(ctx, entities.EventLowStockReached, product.ID, entities.LowStockEventPayload{
+		ProductID:  product.ID,
+		SKU:        product.SKU,
+		Name:       product.Name,
+		CategoryID: product.CategoryID,
+		Stock:      product.Stock,
+		MinStock:   product.MinStock,
+	})
+}
+
+// ProductSaved records the events of a catalog change
+func (s *eventService) ProductSaved(ctx context.Context, before, after *entities.Product) error {
+	if before == nil {
+		return s.emit(ctx, entities.EventProductCreated, after.ID, entities.ProductCreatedEventPayload{
+			ProductID:  after.ID,
+			SKU:        after.SKU,
+			Name:       after.Name,
+			CategoryID: after.CategoryID,
+			Price:      after.Price,
+			Cost:       after.Cost,
+			MinStock:   after.MinStock,
+			MaxStock:   after.MaxStock,
+			ParentID:   after.ParentID,
+		})
+	}
+
+	if before.Price == after.Price {
+		return nil
+	}
+
+	return s.emit(ctx, entities.EventProductPriceChanged, after.ID, entities.PriceChangedEventPayload{
+		ProductID: after.ID,
+		SKU:       after.SKU,
+		OldPrice:  before.Price,
+		NewPrice:  after.Price,
+	})
+}
+
+// AlertChanged records the event of an opened or resolved stock alert
+func (s *eventService) AlertChanged(ctx context.Context, product *entities.Product, alert *entities.Alert) error {
+	eventType := entities.EventAlertOpened
+	if !alert.IsOpen() {
+		eventType = entities.EventAlertResolved
+	}
+
+	return s.emit(ctx, eventType, product.ID, entities.AlertEventPayload{
+		AlertID:    alert.ID,
+		AlertType:  alert.Type,
+		ProductID:  product.ID,
+		SKU:        product.SKU,
+		Name:       product.Name,
+		CategoryID: product.CategoryID,
+		Stock:      product.Stock,
+		Threshold:  alert.Threshold,
+	})
+}
+
+// emit writes an event with the JSON form of payload to the outbox
+func (s *eventService) emit(ctx context.Context, eventType string, productID uuid.UUID, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	return s.outboxRepo.Create(ctx, entities.NewEvent(eventType, productID, data))
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// ForecastService forecasts product demand from the daily outbound quantities of the transaction ledger
+type ForecastService interface {
+	ForecastProduct(ctx context.Context, productID uuid.UUID, params entities.ForecastParams) (*entities.DemandForecast, error)
+	ForecastAll(ctx context.Context, params entities.ForecastParams) ([]*entities.DemandForecast, error)
+}
+
+type forecastService struct {
+	productRepo     repositories.ProductRepository
+	transactionRepo repositories.TransactionRepository
+	supplierRepo    repositories.SupplierRepository
+}
+
+// NewForecastService creates a new forecast service
+func NewForecastService(productRepo repositories.ProductRepository, transactionRepo repositories.TransactionRepository, supplierRepo repositories.SupplierRepository) ForecastService {
+	return &forecastService{
+		productRepo:     productRepo,
+		transactionRepo: transactionRepo,
+		supplierRepo:    supplierRepo,
+	}
+}
+
+// ForecastProduct forecasts the demand of a single product
+func (s *forecastService) ForecastProduct(ctx context.Context, productID uuid.UUID, params entities.ForecastParams) (*entities.DemandForecast, error) {
+	if err := validateForecastParams(params); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product == nil {
+		return nil, entities.ErrProductNotFound
+	}
+
+	start, end := forecastHistoryRange(params.HistoryDays)
+	shipped, err := s.transactionRepo.GetProductOutboundByPeriod(ctx, productID, start, 1, params.HistoryDays)
+	if err != nil {
+		return nil, err
+	}
+
+	leadTimes, err := s.leadTimes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return forecast(productID, toFloats(shipped), start, end, leadTime(leadTimes, productID, params), params), nil
+}
+
+// ForecastAll forecasts the demand of every stocked product from one aggregate query over the ledger
+func (s *forecastService) ForecastAll(ctx context.Context, params entities.ForecastParams) ([]*entities.DemandForecast, error) {
+	if err := validateForecastParams(params); err != nil {
+		return nil, err
+	}
+
+	products, err := s.productRepo.GetStockedProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := forecastHistoryRange(params.HistoryDays)
+	demand, err := s.transactionRepo.GetOutboundByPeriod(ctx, start, 1, params.HistoryDays)
+	if err != nil {
+		return nil, err
+	}
+
+	leadTimes, err := s.leadTimes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts := make([]*entities.DemandForecast, 0, len(products))
+	for _, product := range products {
+		history := toFloats(demand[product.ID])
+		if history == nil {
+			history = make([]float64, params.HistoryDays)
+		}
+		forecasts = append(forecasts, forecast(product.ID, history, start, end, leadTime(leadTimes, product.ID, params), params))
+	}
+
+	return forecasts, nil
+}
+
+// toFloats converts a series of daily quantities for the forecast models
+func toFloats(quantities []int) []float64 {
+	if quantities == nil {
+		return nil
+	}
+
+	values := make([]float64, len(quantities))
+	for i, q := range quantities {
+		values[i] = float64(q)
+	}
+	return values
+}
+
+// leadTimes returns the lead time of each product's preferred supplier
+func (s *forecastService) leadTimes(ctx context.Context) (map[uuid.UUID]int, error) {
+	productSuppliers, err := s.supplierRepo.GetPreferredProductSuppliers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	leadTimes := make(map[uuid.UUID]int, len(productSuppliers))
+	for _, ps := range productSuppliers {
+		leadTimes[ps.ProductID] = ps.LeadTimeDays
+	}
+
+	return leadTimes, nil
+}
+
+// leadTime returns the supplier lead time of a product, falling back to the configured default
+func leadTime(leadTimes map[uuid.UUID]int, productID uuid.UUID, params entities.ForecastParams) int {
+	if days, ok := leadTimes[productID]; ok && days > 0 {
+		return days
+	}
+	return params.LeadTimeDays
+}
+
+// validateForecastParams checks the model and service level
+func validateForecastParams(params entities.ForecastParams) error {
+	if !entities.IsValidForecastModel(params.Model) {
+		return entities.ErrInvalidForecastModel
+	}
+	if params.ServiceLevel < 0.5 || params.ServiceLevel >= 1 {
+		return entities.ErrInvalidServiceLevel
+	}
+	return nil
+}
+
+// forecastHistoryRange returns the history window ending at the start of today (UTC)
+func forecastHistoryRange(historyDays int) (time.Time, time.Time) {
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	return end.AddDate(0, 0, -historyDays), end
+}
+
+// forecast runs the selected model over the history and derives safety stock and reorder point
+func forecast(productID uuid.UUID, history []float64, start, end time.Time, leadTimeDays int, params entities.ForecastParams) *entities.DemandForecast {
+	var daily []float64
+	var deviation float64
+	switch params.Model {
+	case entities.ForecastModelExponentialSmoothing:
+		daily, deviation = exponentialSmoothing(history, params)
+	default:
+		daily, deviation = movingAverage(history, params)
+	}
+
+	average := mean(daily)
+
+	// Safety stock covers demand variability over the lead time at the target service level
+	z := math.Sqrt2 * math.Erfinv(2*params.ServiceLevel-1)
+	safetyStock := int(math.Ceil(z * deviation * math.Sqrt(float64(leadTimeDays))))
+
+	return &entities.DemandForecast{
+		ProductID:          productID,
+		Model:              params.Model,
+		HistoryStart:       start,
+		HistoryEnd:         end,
+		AverageDailyDemand: average,
+		DemandStdDev:       deviation,
+		LeadTimeDays:       leadTimeDays,
+		ServiceLevel:       params.ServiceLevel,
+		SafetyStock:        safetyStock,
+		ReorderPoint:       int(math.Ceil(average*float64(leadTimeDays))) + safetyStock,
+		DailyForecast:      daily,
+	}
+}
+
+// movingAverage forecasts a flat demand equal to the mean of the last window days.
+// Variability is the RMSE of the one-step-ahead forecasts over the history.
+func movingAverage(history []float64, params entities.ForecastParams) ([]float64, float64) {
+	window := params.Window
+	if window <= 0 || window > len(history) {
+		window = len(history)
+	}
+
+	var residuals []float64
+	for t := window; t < len(history); t++ {
+		residuals = append(residuals, history[t]-mean(history[t-window:t]))
+	}
+
+	level := mean(history[len(history)-window:])
+	daily := make([]float64, params.Horizon)
+	for i := range daily {
+		daily[i] = level
+	}
+
+	return daily, forecastError(residuals, history)
+}
+
+// exponentialSmoothing forecasts demand with additive Holt-Winters (level, trend and season).
+// Without two full seasons of history it falls back to simple exponential smoothing.
+func exponentialSmoothing(history []float64, params entities.ForecastParams) ([]float64, float64) {
+	season := params.SeasonLength
+	if season <= 1 || len(history) < 2*season {
+		return simpleExponentialSmoothing(history, params)
+	}
+
+	level := mean(history[:season])
+	trend := (mean(history[season:2*season]) - level) / float64(season)
+	seasonal := make([]float64, season)
+	for i := 0; i < season; i++ {
+		seasonal[i] = history[i] - level
+	}
+
+	var residuals []float64
+	for t := season; t < len(history); t++ {
+		i := t % season
+		residuals = append(residuals, history[t]-(level+trend+seasonal[i]))
+
+		lastLevel := level
+		level = params.Alpha*(history[t]-seasonal[i]) + (1-params.Alpha)*(level+trend)
+		trend = params.Beta*(level-lastLevel) + (1-params.Beta)*trend
+		seasonal[i] = params.Gamma*(history[t]-level) + (1-params.Gamma)*seasonal[i]
+	}
+
+	daily := make([]float64, params.Horizon)
+	for h := range daily {
+		daily[h] = math.Max(0, level+float64(h+1)*trend+seasonal[(len(history)+h)%season])
+	}
+
+	return daily, forecastError(residuals, history)
+}
+
+// simpleExponentialSmoothing forecasts a flat demand equal to the smoothed level
+func simpleExponentialSmoothing(history []float64, params entities.ForecastParams) ([]float64, float64) {
+	daily := make([]float64, params.Horizon)
+	if len(history) == 0 {
+		return daily, 0
+	}
+
+	level := history[0]
+	var residuals []float64
+	for t := 1; t < len(history); t++ {
+		residuals = append(residuals, history[t]-level)
+		level = params.Alpha*history[t] + (1-params.Alpha)*level
+	}
+
+	for i := range daily {
+		daily[i] = level
+	}
+
+	return daily, forecastError(residuals, history)
+}
+
+// forecastError returns the RMSE of the forecast errors, or the standard deviation of the history when there are none
+func forecastError(residuals, history []float64) float64 {
+	if len(residuals) == 0 {
+		return stdDev(history)
+	}
+
+	var sum float64
+	for _, e := range residuals {
+		sum += e * e
+	}
+	return math.Sqrt(sum / float64(len(residuals)))
+}
+
+// mean returns the arithmetic mean of the values
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// stdDev returns the population standard deviation of the values
+func stdDev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	m := mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(values)))
+}
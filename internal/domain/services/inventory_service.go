@@ -1,7 +1,12 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+
 	"github.com/google/uuid"
 	"inventory-app/internal/domain/entities"
 	"inventory-app/internal/domain/repositories"
@@ -12,6 +17,7 @@ type InventoryService interface {
 	ProcessStockIn(ctx context.Context, productID uuid.UUID, quantity int, reference, notes string, userID uuid.UUID) error
 	ProcessStockOut(ctx context.Context, productID uuid.UUID, quantity int, reference, notes string, userID uuid.UUID) error
 	AdjustStock(ctx context.Context, productID uuid.UUID, newQuantity int, notes string, userID uuid.UUID) error
+	ApplyMovements(ctx context.Context, reference string, movements []*entities.StockMovement, userID uuid.UUID) ([]*entities.StockMovementResult, error)
 	TransferStock(ctx context.Context, fromProductID, toProductID uuid.UUID, quantity int, reference, notes string, userID uuid.UUID) error
 	GetLowStockAlerts(ctx context.Context) ([]*entities.Product, error)
 }
@@ -19,118 +25,216 @@ type InventoryService interface {
 type inventoryService struct {
 	productRepo     repositories.ProductRepository
 	transactionRepo repositories.TransactionRepository
+	events          EventService
+	alerts          AlertService
+	txManager       repositories.TxManager
 }
 
 // NewInventoryService creates a new inventory service
-func NewInventoryService(productRepo repositories.ProductRepository, transactionRepo repositories.TransactionRepository) InventoryService {
+func NewInventoryService(productRepo repositories.ProductRepository, transactionRepo repositories.TransactionRepository, events EventService, alerts AlertService, txManager repositories.TxManager) InventoryService {
 	return &inventoryService{
 		productRepo:     productRepo,
 		transactionRepo: transactionRepo,
+		events:          events,
+		alerts:          alerts,
+		txManager:       txManager,
 	}
 }
 
 // ProcessStockIn processes incoming stock
 func (s *inventoryService) ProcessStockIn(ctx context.Context, productID uuid.UUID, quantity int, reference, notes string, userID uuid.UUID) error {
-	product, err := s.productRepo.GetByID(ctx, productID)
-	if err != nil {
-		return err
+	if quantity <= 0 {
+		return entities.ErrInvalidQuantity
 	}
 
-	if product == nil {
-		return entities.ErrProductNotFound
-	}
+	return s.moveStock(ctx, productID, func(product *entities.Product) (*entities.Transaction, error) {
+		// Update product stock
+		if err := product.UpdateStock(quantity); err != nil {
+			return nil, err
+		}
 
+		return entities.NewTransaction(productID, entities.TransactionTypeIn, quantity, reference, notes, userID), nil
+	})
+}
+
+// ProcessStockOut processes outgoing stock
+func (s *inventoryService) ProcessStockOut(ctx context.Context, productID uuid.UUID, quantity int, reference, notes string, userID uuid.UUID) error {
 	if quantity <= 0 {
 		return entities.ErrInvalidQuantity
 	}
 
-	// Update product stock
-	err = product.UpdateStock(quantity)
-	if err != nil {
-		return err
-	}
+	return s.moveStock(ctx, productID, func(product *entities.Product) (*entities.Transaction, error) {
+		// Check if sufficient stock
+		if product.Stock < quantity {
+			return nil, entities.ErrInsufficientStock
+		}
 
-	// Create transaction record
-	transaction := entities.NewTransaction(productID, entities.TransactionTypeIn, quantity, reference, notes, userID)
+		// Update product stock
+		if err := product.UpdateStock(-quantity); err != nil {
+			return nil, err
+		}
 
-	// Save transaction
-	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
-		return err
+		return entities.NewTransaction(productID, entities.TransactionTypeOut, quantity, reference, notes, userID), nil
+	})
+}
+
+// AdjustStock adjusts stock to a specific quantity
+func (s *inventoryService) AdjustStock(ctx context.Context, productID uuid.UUID, newQuantity int, notes string, userID uuid.UUID) error {
+	if newQuantity < 0 {
+		return entities.ErrInvalidQuantity
 	}
 
-	// Update product
-	return s.productRepo.Update(ctx, product)
+	return s.moveStock(ctx, productID, func(product *entities.Product) (*entities.Transaction, error) {
+		// Calculate adjustment quantity
+		adjustmentQuantity := newQuantity - product.Stock
+
+		// Update product stock
+		product.Stock = newQuantity
+
+		return entities.NewTransaction(productID, entities.TransactionTypeAdjustment, adjustmentQuantity, "", notes, userID), nil
+	})
 }
 
-// ProcessStockOut processes outgoing stock
-func (s *inventoryService) ProcessStockOut(ctx context.Context, productID uuid.UUID, quantity int, reference, notes string, userID uuid.UUID) error {
-	product, err := s.productRepo.GetByID(ctx, productID)
-	if err != nil {
-		return err
-	}
+// moveStock locks a product, lets move change its stock and records the returned transaction, its events and
+// the alerts it opens or resolves.
+// Only the stock column is written, so concurrent catalog edits are not overwritten.
+func (s *inventoryService) moveStock(ctx context.Context, productID uuid.UUID, move func(product *entities.Product) (*entities.Transaction, error)) error {
+	return s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		products, err := s.productRepo.GetForUpdate(ctx, []uuid.UUID{productID})
+		if err != nil {
+			return err
+		}
 
-	if product == nil {
-		return entities.ErrProductNotFound
-	}
+		if len(products) == 0 {
+			return entities.ErrProductNotFound
+		}
 
-	if quantity <= 0 {
-		return entities.ErrInvalidQuantity
-	}
+		product := products[0]
+		if product.IsVariantParent() {
+			return entities.ErrVariantParentStock
+		}
 
-	// Check if sufficient stock
-	if product.Stock < quantity {
-		return entities.ErrInsufficientStock
-	}
+		stockBefore := product.Stock
+		transaction, err := move(product)
+		if err != nil {
+			return err
+		}
+		transaction.IdempotencyKey = entities.IdempotencyKeyFromContext(ctx)
 
-	// Update product stock
-	err = product.UpdateStock(-quantity)
-	if err != nil {
-		return err
-	}
+		// Save transaction
+		if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+			return err
+		}
 
-	// Create transaction record
-	transaction := entities.NewTransaction(productID, entities.TransactionTypeOut, quantity, reference, notes, userID)
+		if err := s.productRepo.UpdateStock(ctx, product.ID, product.Stock); err != nil {
+			return err
+		}
 
-	// Save transaction
-	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
-		return err
-	}
+		if err := s.events.StockMoved(ctx, product, transaction, stockBefore); err != nil {
+			return err
+		}
 
-	// Update product
-	return s.productRepo.Update(ctx, product)
+		return s.alerts.Evaluate(ctx, product)
+	})
 }
 
-// AdjustStock adjusts stock to a specific quantity
-func (s *inventoryService) AdjustStock(ctx context.Context, productID uuid.UUID, newQuantity int, notes string, userID uuid.UUID) error {
-	product, err := s.productRepo.GetByID(ctx, productID)
-	if err != nil {
-		return err
+// ApplyMovements applies a batch of stock movements in one transaction, all or nothing.
+// Every line is checked against the stock left by the lines before it. When any line fails, nothing is
+// written and ErrMovementBatchRejected is returned together with the per-line results.
+func (s *inventoryService) ApplyMovements(ctx context.Context, reference string, movements []*entities.StockMovement, userID uuid.UUID) ([]*entities.StockMovementResult, error) {
+	if len(movements) == 0 || len(movements) > entities.MaxMovementBatchSize {
+		return nil, entities.ErrInvalidMovementBatch
 	}
 
-	if product == nil {
-		return entities.ErrProductNotFound
+	// Lock in a fixed order so concurrent batches touching the same products cannot deadlock
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, movement := range movements {
+		if !seen[movement.ProductID] {
+			seen[movement.ProductID] = true
+			ids = append(ids, movement.ProductID)
+		}
 	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
 
-	if newQuantity < 0 {
-		return entities.ErrInvalidQuantity
-	}
+	results := make([]*entities.StockMovementResult, len(movements))
+	err := s.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		products, err := s.productRepo.GetForUpdate(ctx, ids)
+		if err != nil {
+			return err
+		}
 
-	// Calculate adjustment quantity
-	adjustmentQuantity := newQuantity - product.Stock
+		byID := make(map[uuid.UUID]*entities.Product, len(products))
+		for _, product := range products {
+			byID[product.ID] = product
+		}
 
-	// Update product stock
-	product.Stock = newQuantity
+		rejected := false
+		for i, movement := range movements {
+			result := &entities.StockMovementResult{Line: i + 1, Movement: movement}
+			results[i] = result
 
-	// Create transaction record
-	transaction := entities.NewTransaction(productID, entities.TransactionTypeAdjustment, adjustmentQuantity, "", notes, userID)
+			product, ok := byID[movement.ProductID]
+			if !ok {
+				result.Err = entities.ErrProductNotFound
+				rejected = true
+				continue
+			}
 
-	// Save transaction
-	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
-		return err
+			result.StockBefore = product.Stock
+			result.Transaction, result.Err = movement.Apply(product, reference, userID)
+			result.StockAfter = product.Stock
+			if result.Err != nil {
+				rejected = true
+			}
+		}
+
+		if rejected {
+			return entities.ErrMovementBatchRejected
+		}
+
+		key := entities.IdempotencyKeyFromContext(ctx)
+		for _, result := range results {
+			if key != "" {
+				result.Transaction.IdempotencyKey = fmt.Sprintf("%s/%d", key, result.Line)
+			}
+			if err := s.transactionRepo.Create(ctx, result.Transaction); err != nil {
+				return err
+			}
+		}
+
+		for _, id := range ids {
+			if err := s.productRepo.UpdateStock(ctx, id, byID[id].Stock); err != nil {
+				return err
+			}
+		}
+
+		// Events carry the stock after each line, in line order
+		for _, result := range results {
+			line := *byID[result.Movement.ProductID]
+			line.Stock = result.StockAfter
+			if err := s.events.StockMoved(ctx, &line, result.Transaction, result.StockBefore); err != nil {
+				return err
+			}
+		}
+
+		// Alerts follow the stock the batch leaves behind
+		for _, id := range ids {
+			if err := s.alerts.Evaluate(ctx, byID[id]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, entities.ErrMovementBatchRejected) {
+			return results, err
+		}
+		return nil, err
 	}
 
-	// Update product
-	return s.productRepo.Update(ctx, product)
+	return results, nil
 }
 
 // TransferStock transfers stock between products (placeholder implementation)
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// ReconciliationService compares stored stock with the transaction ledger and optionally repairs the ledger
+type ReconciliationService interface {
+	Reconcile(ctx context.Context, repair bool, userID uuid.UUID) (*entities.ReconciliationRun, error)
+}
+
+type reconciliationService struct {
+	reconciliationRepo repositories.ReconciliationRepository
+}
+
+// NewReconciliationService creates a new reconciliation service
+func NewReconciliationService(reconciliationRepo repositories.ReconciliationRepository) ReconciliationService {
+	return &reconciliationService{reconciliationRepo: reconciliationRepo}
+}
+
+// Reconcile records every product whose stock differs from the sum of its signed transactions.
+// In repair mode each difference is closed with an adjustment transaction; the stored stock is
+// taken as the truth since it reflects what was counted and shipped. The run is always recorded,
+// also when it fails halfway.
+func (s *reconciliationService) Reconcile(ctx context.Context, repair bool, userID uuid.UUID) (*entities.ReconciliationRun, error) {
+	run := entities.NewReconciliationRun(repair, userID)
+	err := s.reconcile(ctx, run)
+	run.Finish(err)
+
+	if saveErr := s.reconciliationRepo.CreateRun(ctx, run); saveErr != nil {
+		return nil, saveErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// reconcile fills the run with the discrepancies found and repaired
+func (s *reconciliationService) reconcile(ctx context.Context, run *entities.ReconciliationRun) error {
+	balances, err := s.reconciliationRepo.GetLedgerBalances(ctx)
+	if err != nil {
+		return err
+	}
+
+	run.ProductsChecked = len(balances)
+	for _, balance := range balances {
+		if balance.Difference() == 0 {
+			continue
+		}
+
+		item := run.AddDiscrepancy(balance)
+		if !run.Repair {
+			continue
+		}
+
+		notes := fmt.Sprintf("Reconciliation: stock %d, ledger %d", balance.Stock, balance.LedgerStock)
+		adjustment, err := s.reconciliationRepo.PostCorrection(ctx, balance.ProductID, run.Reference(), notes, run.CreatedBy)
+		if err != nil {
+			return fmt.Errorf("failed to repair %s: %w", balance.SKU, err)
+		}
+
+		if adjustment != nil {
+			item.AdjustmentID = &adjustment.ID
+			run.Repaired++
+		}
+	}
+
+	return nil
+}
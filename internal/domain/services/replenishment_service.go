@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// ReplenishmentService computes reorder suggestions for products that reached their reorder point
+type ReplenishmentService interface {
+	ProposeReplenishment(ctx context.Context, policy string) ([]*entities.ReplenishmentProposal, error)
+}
+
+type replenishmentService struct {
+	productRepo       repositories.ProductRepository
+	transactionRepo   repositories.TransactionRepository
+	supplierRepo      repositories.SupplierRepository
+	purchaseOrderRepo repositories.PurchaseOrderRepository
+	reservationRepo   repositories.ReservationRepository
+	params            entities.ReplenishmentParams
+	demandWindowDays  int
+}
+
+// NewReplenishmentService creates a new replenishment service.
+// Average daily demand is derived from outbound transactions over the last demandWindowDays.
+func NewReplenishmentService(
+	productRepo repositories.ProductRepository,
+	transactionRepo repositories.TransactionRepository,
+	supplierRepo repositories.SupplierRepository,
+	purchaseOrderRepo repositories.PurchaseOrderRepository,
+	reservationRepo repositories.ReservationRepository,
+	params entities.ReplenishmentParams,
+	demandWindowDays int) ReplenishmentService {
+	if demandWindowDays <= 0 {
+		demandWindowDays = 90
+	}
+	return &replenishmentService{
+		productRepo:       productRepo,
+		transactionRepo:   transactionRepo,
+		supplierRepo:      supplierRepo,
+		purchaseOrderRepo: purchaseOrderRepo,
+		reservationRepo:   reservationRepo,
+		params:            params,
+		demandWindowDays:  demandWindowDays,
+	}
+}
+
+// ProposeReplenishment returns a proposal for every product whose inventory position
+// (stock - reserved + on order) is at or below its reorder point
+func (s *replenishmentService) ProposeReplenishment(ctx context.Context, policy string) ([]*entities.ReplenishmentProposal, error) {
+	if !entities.IsValidReplenishmentPolicy(policy) {
+		return nil, entities.ErrInvalidReplenishmentPolicy
+	}
+
+	products, err := s.productRepo.GetStockedProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productSuppliers, err := s.supplierRepo.GetPreferredProductSuppliers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make(map[uuid.UUID]*entities.ProductSupplier, len(productSuppliers))
+	for _, ps := range productSuppliers {
+		terms[ps.ProductID] = ps
+	}
+
+	onOrder, err := s.purchaseOrderRepo.GetOpenQuantities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved, err := s.reservationRepo.GetActiveQuantities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -s.demandWindowDays)
+	shipped, err := s.transactionRepo.GetOutboundQuantities(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var proposals []*entities.ReplenishmentProposal
+	for _, product := range products {
+		dailyDemand := float64(shipped[product.ID]) / float64(s.demandWindowDays)
+		proposal := s.propose(product, terms[product.ID], onOrder[product.ID], reserved[product.ID], dailyDemand, policy)
+		if proposal != nil {
+			proposals = append(proposals, proposal)
+		}
+	}
+
+	return proposals, nil
+}
+
+// propose computes the proposal for a single product, or nil when no order is needed
+func (s *replenishmentService) propose(product *entities.Product, terms *entities.ProductSupplier, onOrder, reserved int, dailyDemand float64, policy string) *entities.ReplenishmentProposal {
+	proposal := &entities.ReplenishmentProposal{
+		ProductID:          product.ID,
+		SKU:                product.SKU,
+		Name:               product.Name,
+		Policy:             policy,
+		Stock:              product.Stock,
+		Reserved:           reserved,
+		OnOrder:            onOrder,
+		InventoryPosition:  product.Stock - reserved + onOrder,
+		MinStock:           product.MinStock,
+		MaxStock:           product.MaxStock,
+		AverageDailyDemand: dailyDemand,
+		UnitCost:           product.Cost,
+	}
+
+	if terms != nil {
+		supplierID := terms.SupplierID
+		proposal.SupplierID = &supplierID
+		proposal.LeadTimeDays = terms.LeadTimeDays
+		proposal.MinOrderQty = terms.MinOrderQty
+		proposal.OrderMultiple = terms.OrderMultiple
+		if terms.UnitCost > 0 {
+			proposal.UnitCost = terms.UnitCost
+		}
+	}
+
+	// Cover the minimum stock plus the expected demand while waiting for the delivery
+	proposal.ReorderPoint = product.MinStock + int(math.Ceil(dailyDemand*float64(proposal.LeadTimeDays)))
+	if proposal.InventoryPosition > proposal.ReorderPoint {
+		return nil
+	}
+
+	// Never order less than what is needed to get back above the reorder point
+	shortfall := proposal.ReorderPoint - proposal.InventoryPosition + 1
+
+	var quantity int
+	switch policy {
+	case entities.ReplenishmentPolicyEOQ:
+		quantity = s.economicOrderQuantity(dailyDemand*365, proposal.UnitCost)
+	default:
+		quantity = product.MaxStock - proposal.InventoryPosition
+	}
+
+	if quantity < shortfall {
+		quantity = shortfall
+	}
+
+	if quantity < proposal.MinOrderQty {
+		quantity = proposal.MinOrderQty
+	}
+
+	if proposal.OrderMultiple > 1 && quantity%proposal.OrderMultiple != 0 {
+		quantity += proposal.OrderMultiple - quantity%proposal.OrderMultiple
+	}
+
+	proposal.SuggestedQuantity = quantity
+	return proposal
+}
+
+// economicOrderQuantity returns sqrt(2DS/H), or 0 when there is not enough data to compute it
+func (s *replenishmentService) economicOrderQuantity(annualDemand, unitCost float64) int {
+	holdingCost := unitCost * s.params.HoldingCostRate
+	if annualDemand <= 0 || holdingCost <= 0 || s.params.OrderingCost <= 0 {
+		return 0
+	}
+	return int(math.Ceil(math.Sqrt(2 * annualDemand * s.params.OrderingCost / holdingCost)))
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"inventory-app/internal/domain/repositories"
+)
+
+// StockSnapshotService materializes daily closing stock so point-in-time queries stay fast
+type StockSnapshotService interface {
+	TakeDailySnapshots(ctx context.Context) (int, error)
+}
+
+type stockSnapshotService struct {
+	snapshotRepo repositories.StockSnapshotRepository
+}
+
+// NewStockSnapshotService creates a new stock snapshot service
+func NewStockSnapshotService(snapshotRepo repositories.StockSnapshotRepository) StockSnapshotService {
+	return &stockSnapshotService{snapshotRepo: snapshotRepo}
+}
+
+// TakeDailySnapshots snapshots every completed day (UTC) since the latest snapshot, up to yesterday.
+// The first run only snapshots yesterday; older days are answered from the ledger.
+// It returns the number of days snapshotted.
+func (s *stockSnapshotService) TakeDailySnapshots(ctx context.Context) (int, error) {
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+
+	latest, err := s.snapshotRepo.GetLatestSnapshotDate(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	day := yesterday
+	if latest != nil {
+		day = latest.UTC().AddDate(0, 0, 1)
+	}
+
+	var days int
+	for ; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		if _, err := s.snapshotRepo.CreateSnapshot(ctx, day); err != nil {
+			return days, err
+		}
+		days++
+	}
+
+	return days, nil
+}
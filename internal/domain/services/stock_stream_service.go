@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// StockSubscription is a subscriber of the live stock stream
+type StockSubscription struct {
+	// Replay holds the events missed since the event the subscriber resumes after, oldest first.
+	// Live events may repeat some of them; skip those by ID.
+	Replay []*entities.Event
+	// Reset is set when the subscriber cannot resume, because the event it resumes after is unknown or too
+	// many events were missed; it should reload the current stock instead
+	Reset bool
+	// Events delivers live events. It is closed when the subscriber fell further behind than its buffer or
+	// the stream was disconnected; the client should then reconnect and resume after the last event it got.
+	Events <-chan *entities.Event
+
+	events   chan *entities.Event
+	tenantID uuid.UUID
+	filter   entities.StockStreamFilter
+}
+
+// StockStreamService fans committed stock events out to the live stream subscribers of their tenant
+type StockStreamService interface {
+	// Subscribe registers a subscriber of the tenant of the context. With afterSequence above 0 it resumes
+	// after the event of that sequence, replaying what was published since from the outbox.
+	Subscribe(ctx context.Context, filter entities.StockStreamFilter, afterSequence int64) (*StockSubscription, error)
+	Unsubscribe(subscription *StockSubscription)
+	// Publish hands a stock event to the matching subscribers without waiting for them; a subscriber whose
+	// buffer is full is dropped so that it resumes by replay instead of holding back the others
+	Publish(ctx context.Context, event *entities.Event) error
+	// DisconnectAll drops every subscriber, e.g. on shutdown or when events may have been missed
+	DisconnectAll()
+}
+
+type stockStreamService struct {
+	outboxRepo  repositories.OutboxRepository
+	bufferSize  int
+	replayLimit int
+
+	mu          sync.Mutex
+	subscribers map[*StockSubscription]bool
+}
+
+// NewStockStreamService creates a new stock stream service buffering bufferSize events per subscriber and
+// replaying at most replayLimit events on resume
+func NewStockStreamService(outboxRepo repositories.OutboxRepository, bufferSize, replayLimit int) StockStreamService {
+	return &stockStreamService{
+		outboxRepo:  outboxRepo,
+		bufferSize:  bufferSize,
+		replayLimit: replayLimit,
+		subscribers: make(map[*StockSubscription]bool),
+	}
+}
+
+// Subscribe registers the subscriber before reading the replay, so no event falls between the two
+func (s *stockStreamService) Subscribe(ctx context.Context, filter entities.StockStreamFilter, afterSequence int64) (*StockSubscription, error) {
+	events := make(chan *entities.Event, s.bufferSize)
+	subscription := &StockSubscription{
+		Events:   events,
+		events:   events,
+		tenantID: entities.TenantIDFromContext(ctx),
+		filter:   filter,
+	}
+
+	s.mu.Lock()
+	s.subscribers[subscription] = true
+	s.mu.Unlock()
+
+	if afterSequence > 0 {
+		if err := s.replay(ctx, subscription, afterSequence); err != nil {
+			s.Unsubscribe(subscription)
+			return nil, err
+		}
+	}
+
+	return subscription, nil
+}
+
+// replay fills in the events published after the event of afterSequence. Events are published in batches
+// sharing one publication time, in sequence order within a batch.
+func (s *stockStreamService) replay(ctx context.Context, subscription *StockSubscription, afterSequence int64) error {
+	last, err := s.outboxRepo.GetBySequence(ctx, afterSequence)
+	if err != nil {
+		return err
+	}
+
+	if last == nil {
+		// Unknown, or already removed from the outbox
+		subscription.Reset = true
+		return nil
+	}
+
+	if last.PublishedAt == nil {
+		// Its batch has not committed yet; the batches before it were streamed before it
+		return nil
+	}
+
+	events, err := s.outboxRepo.GetPublishedSince(ctx, *last.PublishedAt, entities.StockEventTypes, s.replayLimit+1)
+	if err != nil {
+		return err
+	}
+
+	if len(events) > s.replayLimit {
+		subscription.Reset = true
+		return nil
+	}
+
+	for _, event := range events {
+		if event.PublishedAt.Equal(*last.PublishedAt) && event.Sequence <= afterSequence {
+			continue
+		}
+		if subscription.filter.Matches(event) {
+			subscription.Replay = append(subscription.Replay, event)
+		}
+	}
+
+	return nil
+}
+
+// Unsubscribe removes a subscriber and closes its channel
+func (s *stockStreamService) Unsubscribe(subscription *StockSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(subscription)
+}
+
+// Publish delivers an event to the subscribers of its tenant whose filter matches it
+func (s *stockStreamService) Publish(ctx context.Context, event *entities.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscription := range s.subscribers {
+		if subscription.tenantID != event.TenantID || !subscription.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			// Too slow to keep up
+			s.remove(subscription)
+		}
+	}
+	return nil
+}
+
+// DisconnectAll removes every subscriber
+func (s *stockStreamService) DisconnectAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscription := range s.subscribers {
+		s.remove(subscription)
+	}
+}
+
+// remove removes a subscriber once; the caller holds the lock
+func (s *stockStreamService) remove(subscription *StockSubscription) {
+	if s.subscribers[subscription] {
+		delete(s.subscribers, subscription)
+		close(subscription.events)
+	}
+}
@@ -1,23 +1,46 @@
 package config
 
 import (
+	"crypto/rsa"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/pkg/jwt"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logger   LoggerConfig
+	Server         ServerConfig
+	Database       DatabaseConfig
+	Logger         LoggerConfig
+	Replenishment  ReplenishmentConfig
+	Forecast       ForecastConfig
+	Classification ClassificationConfig
+	Snapshot       SnapshotConfig
+	Reconciliation ReconciliationConfig
+	Idempotency    IdempotencyConfig
+	Auth           AuthConfig
+	CORS           CORSConfig
+	RBAC           RBACConfig
+	Tenancy        TenancyConfig
+	Purge          PurgeConfig
+	Outbox         OutboxConfig
+	Webhook        WebhookConfig
+	Alert          AlertConfig
+	Stream         StreamConfig
+	Search         SearchConfig
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Host string
-	Port int
+	Host            string
+	Port            int
+	BodyLimit       int   // largest request body in bytes
+	ImportBodyLimit int64 // largest product import file in bytes; imports are streamed, not held in memory
 }
 
 // DatabaseConfig holds database configuration
@@ -36,6 +59,132 @@ type LoggerConfig struct {
 	Format string
 }
 
+// ReplenishmentConfig holds reorder suggestion configuration
+type ReplenishmentConfig struct {
+	DemandWindowDays int     // days of outbound transactions used to estimate demand
+	OrderingCost     float64 // fixed cost of placing one purchase order, used by EOQ
+	HoldingCostRate  float64 // yearly holding cost as a fraction of unit cost, used by EOQ
+}
+
+// ForecastConfig holds demand forecasting defaults
+type ForecastConfig struct {
+	Model        string  // "moving_average" or "exponential_smoothing"
+	HistoryDays  int     // days of outbound transactions used as history
+	Window       int     // moving average window in days
+	Alpha        float64 // level smoothing factor
+	Beta         float64 // trend smoothing factor
+	Gamma        float64 // seasonal smoothing factor
+	SeasonLength int     // season length in days
+	Horizon      int     // days to forecast
+	ServiceLevel float64 // target service level for safety stock
+	LeadTimeDays int     // lead time for products without a supplier
+}
+
+// ClassificationConfig holds ABC/XYZ classification defaults
+type ClassificationConfig struct {
+	WindowDays int           // days of outbound transactions analysed
+	PeriodDays int           // period length used to measure demand variability
+	AThreshold float64       // cumulative value share of A products
+	BThreshold float64       // cumulative value share of A and B products
+	XThreshold float64       // highest coefficient of variation of X products
+	YThreshold float64       // highest coefficient of variation of Y products
+	Interval   time.Duration // how often the classes filtered on by the product list are refreshed; 0 disables it
+}
+
+// SnapshotConfig holds daily stock snapshot job configuration
+type SnapshotConfig struct {
+	Enabled  bool
+	Interval time.Duration // how often the job checks for days to snapshot
+}
+
+// ReconciliationConfig holds ledger reconciliation job configuration
+type ReconciliationConfig struct {
+	Interval time.Duration // how often the job runs; zero disables it
+	Repair   bool          // post correcting adjustments for discrepancies
+}
+
+// IdempotencyConfig holds Idempotency-Key handling configuration
+type IdempotencyConfig struct {
+	TTL             time.Duration // how long a key and its stored response are kept
+	CleanupInterval time.Duration // how often expired keys are deleted
+}
+
+// AuthConfig holds JWT authentication configuration
+type AuthConfig struct {
+	JWTSecret        string        // HS256 shared secret; empty disables HS256
+	JWTPublicKeyFile string        // PEM file holding the RS256 public key; empty disables RS256
+	JWTIssuer        string        // required iss claim; empty accepts any issuer
+	JWTAudience      string        // required aud claim; empty accepts any audience
+	Leeway           time.Duration // clock skew allowed when checking exp and nbf
+}
+
+// CORSConfig holds cross-origin request configuration
+type CORSConfig struct {
+	AllowOrigins string // comma-separated origins, or "*"
+}
+
+// RBACConfig holds role-based access control configuration
+type RBACConfig struct {
+	DefaultRole    string // role of users without an assigned role; empty denies them everything
+	BootstrapAdmin string // user ID that is always an admin, so the first roles can be assigned
+}
+
+// TenancyConfig holds multi-tenancy configuration
+type TenancyConfig struct {
+	DefaultTenant string // tenant of tokens without a tenant_id claim; empty makes the claim mandatory
+}
+
+// PurgeConfig holds the configuration of the job that purges soft-deleted products
+type PurgeConfig struct {
+	Interval  time.Duration // how often soft-deleted products are purged; 0 disables the job
+	Retention time.Duration // how long a soft-deleted product can still be restored
+}
+
+// OutboxConfig holds the configuration of the domain event outbox and its dispatcher
+type OutboxConfig struct {
+	PollInterval    time.Duration // how often the dispatcher relays pending events; 0 disables it
+	BatchSize       int           // events relayed per dispatcher transaction
+	MaxBackoff      time.Duration // longest wait between retries of an event
+	Retention       time.Duration // how long delivered events are kept
+	CleanupInterval time.Duration // how often delivered events past the retention are deleted
+	WebhookURL      string        // URL every event is posted to; empty disables the webhook publisher
+	WebhookTimeout  time.Duration // how long to wait for the webhook to respond
+}
+
+// WebhookConfig holds webhook subscription delivery configuration
+type WebhookConfig struct {
+	DeliveryInterval time.Duration // how often due deliveries are sent; 0 disables delivery
+	BatchSize        int           // deliveries sent concurrently per round
+	Timeout          time.Duration // how long to wait for a receiver to respond
+	MaxAttempts      int           // attempts before a delivery is dead
+	MinBackoff       time.Duration // wait before the first retry; doubles with every further one
+	MaxBackoff       time.Duration // longest wait between retries
+}
+
+// AlertConfig holds the notification channels of stock alerts; in-app notifications are always available
+type AlertConfig struct {
+	SMTPHost     string        // SMTP server of alert emails; empty disables the email channel
+	SMTPPort     int           // SMTP server port
+	SMTPUsername string        // SMTP user; empty sends without authentication
+	SMTPPassword string        // SMTP password
+	SMTPFrom     string        // sender address of alert emails
+	SMTPTimeout  time.Duration // how long sending one email may take
+	SlackEnabled bool          // whether users may subscribe Slack-compatible incoming webhooks
+	SlackTimeout time.Duration // how long to wait for a Slack webhook to respond
+}
+
+// StreamConfig holds live stock stream configuration
+type StreamConfig struct {
+	BufferSize  int           // events buffered per client before a slow client is disconnected
+	ReplayLimit int           // most events replayed to a resuming client; beyond it the client is told to reset
+	Heartbeat   time.Duration // how often an idle stream sends a heartbeat comment
+}
+
+// SearchConfig holds product search configuration
+type SearchConfig struct {
+	PriceBuckets string // comma-separated, ascending bounds of the default price facet buckets
+}
+
 // Load loads configuration using Viper
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
@@ -58,8 +207,10 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Host: viper.GetString("server.host"),
-			Port: viper.GetInt("server.port"),
+			Host:            viper.GetString("server.host"),
+			Port:            viper.GetInt("server.port"),
+			BodyLimit:       viper.GetInt("server.body_limit"),
+			ImportBodyLimit: viper.GetInt64("server.import_body_limit"),
 		},
 		Database: DatabaseConfig{
 			Host:     viper.GetString("database.host"),
@@ -73,6 +224,100 @@ func Load() (*Config, error) {
 			Level:  viper.GetString("logger.level"),
 			Format: viper.GetString("logger.format"),
 		},
+		Replenishment: ReplenishmentConfig{
+			DemandWindowDays: viper.GetInt("replenishment.demand_window_days"),
+			OrderingCost:     viper.GetFloat64("replenishment.ordering_cost"),
+			HoldingCostRate:  viper.GetFloat64("replenishment.holding_cost_rate"),
+		},
+		Forecast: ForecastConfig{
+			Model:        viper.GetString("forecast.model"),
+			HistoryDays:  viper.GetInt("forecast.history_days"),
+			Window:       viper.GetInt("forecast.window"),
+			Alpha:        viper.GetFloat64("forecast.alpha"),
+			Beta:         viper.GetFloat64("forecast.beta"),
+			Gamma:        viper.GetFloat64("forecast.gamma"),
+			SeasonLength: viper.GetInt("forecast.season_length"),
+			Horizon:      viper.GetInt("forecast.horizon"),
+			ServiceLevel: viper.GetFloat64("forecast.service_level"),
+			LeadTimeDays: viper.GetInt("forecast.lead_time_days"),
+		},
+		Classification: ClassificationConfig{
+			WindowDays: viper.GetInt("classification.window_days"),
+			PeriodDays: viper.GetInt("classification.period_days"),
+			AThreshold: viper.GetFloat64("classification.a_threshold"),
+			BThreshold: viper.GetFloat64("classification.b_threshold"),
+			XThreshold: viper.GetFloat64("classification.x_threshold"),
+			YThreshold: viper.GetFloat64("classification.y_threshold"),
+			Interval:   viper.GetDuration("classification.interval"),
+		},
+		Snapshot: SnapshotConfig{
+			Enabled:  viper.GetBool("snapshot.enabled"),
+			Interval: viper.GetDuration("snapshot.interval"),
+		},
+		Reconciliation: ReconciliationConfig{
+			Interval: viper.GetDuration("reconciliation.interval"),
+			Repair:   viper.GetBool("reconciliation.repair"),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:             viper.GetDuration("idempotency.ttl"),
+			CleanupInterval: viper.GetDuration("idempotency.cleanup_interval"),
+		},
+		Auth: AuthConfig{
+			JWTSecret:        viper.GetString("auth.jwt_secret"),
+			JWTPublicKeyFile: viper.GetString("auth.jwt_public_key_file"),
+			JWTIssuer:        viper.GetString("auth.jwt_issuer"),
+			JWTAudience:      viper.GetString("auth.jwt_audience"),
+			Leeway:           viper.GetDuration("auth.leeway"),
+		},
+		CORS: CORSConfig{
+			AllowOrigins: viper.GetString("cors.allow_origins"),
+		},
+		RBAC: RBACConfig{
+			DefaultRole:    viper.GetString("rbac.default_role"),
+			BootstrapAdmin: viper.GetString("rbac.bootstrap_admin"),
+		},
+		Tenancy: TenancyConfig{
+			DefaultTenant: viper.GetString("tenancy.default_tenant"),
+		},
+		Purge: PurgeConfig{
+			Interval:  viper.GetDuration("purge.interval"),
+			Retention: viper.GetDuration("purge.retention"),
+		},
+		Outbox: OutboxConfig{
+			PollInterval:    viper.GetDuration("outbox.poll_interval"),
+			BatchSize:       viper.GetInt("outbox.batch_size"),
+			MaxBackoff:      viper.GetDuration("outbox.max_backoff"),
+			Retention:       viper.GetDuration("outbox.retention"),
+			CleanupInterval: viper.GetDuration("outbox.cleanup_interval"),
+			WebhookURL:      viper.GetString("outbox.webhook_url"),
+			WebhookTimeout:  viper.GetDuration("outbox.webhook_timeout"),
+		},
+		Webhook: WebhookConfig{
+			DeliveryInterval: viper.GetDuration("webhook.delivery_interval"),
+			BatchSize:        viper.GetInt("webhook.batch_size"),
+			Timeout:          viper.GetDuration("webhook.timeout"),
+			MaxAttempts:      viper.GetInt("webhook.max_attempts"),
+			MinBackoff:       viper.GetDuration("webhook.min_backoff"),
+			MaxBackoff:       viper.GetDuration("webhook.max_backoff"),
+		},
+		Alert: AlertConfig{
+			SMTPHost:     viper.GetString("alert.smtp_host"),
+			SMTPPort:     viper.GetInt("alert.smtp_port"),
+			SMTPUsername: viper.GetString("alert.smtp_username"),
+			SMTPPassword: viper.GetString("alert.smtp_password"),
+			SMTPFrom:     viper.GetString("alert.smtp_from"),
+			SMTPTimeout:  viper.GetDuration("alert.smtp_timeout"),
+			SlackEnabled: viper.GetBool("alert.slack_enabled"),
+			SlackTimeout: viper.GetDuration("alert.slack_timeout"),
+		},
+		Stream: StreamConfig{
+			BufferSize:  viper.GetInt("stream.buffer_size"),
+			ReplayLimit: viper.GetInt("stream.replay_limit"),
+			Heartbeat:   viper.GetDuration("stream.heartbeat"),
+		},
+		Search: SearchConfig{
+			PriceBuckets: viper.GetString("search.price_buckets"),
+		},
 	}
 
 	return config, nil
@@ -83,6 +328,8 @@ func setDefaults() {
 	// Server defaults
 	viper.SetDefault("server.host", "localhost")
 	viper.SetDefault("server.port", 8080)
+	viper.SetDefault("server.body_limit", 4<<20)
+	viper.SetDefault("server.import_body_limit", 100<<20)
 
 	// Database defaults
 	viper.SetDefault("database.host", "localhost")
@@ -96,6 +343,91 @@ func setDefaults() {
 	viper.SetDefault("logger.level", "info")
 	viper.SetDefault("logger.format", "console")
 
+	// Replenishment defaults
+	viper.SetDefault("replenishment.demand_window_days", 90)
+	viper.SetDefault("replenishment.ordering_cost", 50.0)
+	viper.SetDefault("replenishment.holding_cost_rate", 0.25)
+
+	// Forecast defaults
+	viper.SetDefault("forecast.model", "moving_average")
+	viper.SetDefault("forecast.history_days", 180)
+	viper.SetDefault("forecast.window", 28)
+	viper.SetDefault("forecast.alpha", 0.3)
+	viper.SetDefault("forecast.beta", 0.1)
+	viper.SetDefault("forecast.gamma", 0.2)
+	viper.SetDefault("forecast.season_length", 7)
+	viper.SetDefault("forecast.horizon", 28)
+	viper.SetDefault("forecast.service_level", 0.95)
+	viper.SetDefault("forecast.lead_time_days", 7)
+
+	// Classification defaults
+	viper.SetDefault("classification.window_days", 360)
+	viper.SetDefault("classification.period_days", 30)
+	viper.SetDefault("classification.a_threshold", 0.8)
+	viper.SetDefault("classification.b_threshold", 0.95)
+	viper.SetDefault("classification.x_threshold", 0.5)
+	viper.SetDefault("classification.y_threshold", 1.0)
+	viper.SetDefault("classification.interval", "24h")
+
+	// Snapshot defaults
+	viper.SetDefault("snapshot.enabled", true)
+	viper.SetDefault("snapshot.interval", "1h")
+
+	// Reconciliation defaults
+	viper.SetDefault("reconciliation.interval", "0")
+	viper.SetDefault("reconciliation.repair", false)
+
+	// Idempotency defaults
+	viper.SetDefault("idempotency.ttl", "24h")
+	viper.SetDefault("idempotency.cleanup_interval", "1h")
+
+	// Auth defaults
+	viper.SetDefault("auth.leeway", "30s")
+
+	// CORS defaults
+	viper.SetDefault("cors.allow_origins", "*")
+
+	// RBAC defaults
+	viper.SetDefault("rbac.default_role", "viewer")
+
+	// Tenancy defaults
+	viper.SetDefault("tenancy.default_tenant", entities.DefaultTenantID.String())
+
+	// Purge defaults
+	viper.SetDefault("purge.interval", "24h")
+	viper.SetDefault("purge.retention", "720h")
+
+	// Outbox defaults
+	viper.SetDefault("outbox.poll_interval", "1s")
+	viper.SetDefault("outbox.batch_size", 100)
+	viper.SetDefault("outbox.max_backoff", "10m")
+	viper.SetDefault("outbox.retention", "168h")
+	viper.SetDefault("outbox.cleanup_interval", "1h")
+	viper.SetDefault("outbox.webhook_timeout", "10s")
+
+	// Webhook defaults
+	viper.SetDefault("webhook.delivery_interval", "1s")
+	viper.SetDefault("webhook.batch_size", 20)
+	viper.SetDefault("webhook.timeout", "10s")
+	viper.SetDefault("webhook.max_attempts", 8)
+	viper.SetDefault("webhook.min_backoff", "30s")
+	viper.SetDefault("webhook.max_backoff", "1h")
+
+	// Alert defaults
+	viper.SetDefault("alert.smtp_port", 25)
+	viper.SetDefault("alert.smtp_from", "inventory@localhost")
+	viper.SetDefault("alert.smtp_timeout", "30s")
+	viper.SetDefault("alert.slack_enabled", true)
+	viper.SetDefault("alert.slack_timeout", "10s")
+
+	// Stream defaults
+	viper.SetDefault("stream.buffer_size", 256)
+	viper.SetDefault("stream.replay_limit", 1000)
+	viper.SetDefault("stream.heartbeat", "15s")
+
+	// Search defaults
+	viper.SetDefault("search.price_buckets", "10,50,100,500")
+
 	// Environment
 	viper.SetDefault("env", "development")
 }
@@ -116,3 +448,65 @@ func (c *Config) DatabaseURL() string {
 func (c *Config) ServerAddress() string {
 	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
 }
+
+// JWTVerifier builds the token verifier from the configured keys.
+// At least one of the HS256 secret and the RS256 public key must be set.
+func (c *Config) JWTVerifier() (*jwt.Verifier, error) {
+	var publicKey *rsa.PublicKey
+	if c.Auth.JWTPublicKeyFile != "" {
+		data, err := os.ReadFile(c.Auth.JWTPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT public key: %w", err)
+		}
+		if publicKey, err = jwt.ParseRSAPublicKey(data); err != nil {
+			return nil, fmt.Errorf("failed to load JWT public key: %w", err)
+		}
+	}
+
+	if c.Auth.JWTSecret == "" && publicKey == nil {
+		return nil, fmt.Errorf("no JWT key configured: set AUTH_JWT_SECRET or AUTH_JWT_PUBLIC_KEY_FILE")
+	}
+
+	return jwt.NewVerifier([]byte(c.Auth.JWTSecret), publicKey, c.Auth.JWTIssuer, c.Auth.JWTAudience, c.Auth.Leeway), nil
+}
+
+// ReplenishmentParams returns the EOQ cost parameters
+func (c *Config) ReplenishmentParams() entities.ReplenishmentParams {
+	return entities.ReplenishmentParams{
+		OrderingCost:    c.Replenishment.OrderingCost,
+		HoldingCostRate: c.Replenishment.HoldingCostRate,
+	}
+}
+
+// SearchPriceBuckets returns the default price facet bucket bounds
+func (c *Config) SearchPriceBuckets() ([]float64, error) {
+	return entities.ParsePriceBuckets(c.Search.PriceBuckets)
+}
+
+// ForecastParams returns the default demand forecast settings
+func (c *Config) ForecastParams() entities.ForecastParams {
+	return entities.ForecastParams{
+		Model:        c.Forecast.Model,
+		HistoryDays:  c.Forecast.HistoryDays,
+		Window:       c.Forecast.Window,
+		Alpha:        c.Forecast.Alpha,
+		Beta:         c.Forecast.Beta,
+		Gamma:        c.Forecast.Gamma,
+		SeasonLength: c.Forecast.SeasonLength,
+		Horizon:      c.Forecast.Horizon,
+		ServiceLevel: c.Forecast.ServiceLevel,
+		LeadTimeDays: c.Forecast.LeadTimeDays,
+	}
+}
+
+// ClassificationParams returns the default ABC/XYZ classification settings
+func (c *Config) ClassificationParams() entities.ClassificationParams {
+	return entities.ClassificationParams{
+		WindowDays: c.Classification.WindowDays,
+		PeriodDays: c.Classification.PeriodDays,
+		AThreshold: c.Classification.AThreshold,
+		BThreshold: c.Classification.BThreshold,
+		XThreshold: c.Classification.XThreshold,
+		YThreshold: c.Classification.YThreshold,
+	}
+}
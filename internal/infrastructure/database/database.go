@@ -1,25 +1,44 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"inventory-app/internal/infrastructure/config"
 )
 
+// SystemRole is the database role of work that spans tenants, such as relaying events or cleaning up.
+// Row-level security lets it see the rows of every tenant; everything else sees only its own tenant.
+const SystemRole = "inventory_system"
+
 // DB holds the database connection
 type DB struct {
 	*sql.DB
 }
 
-// NewConnection creates a new database connection
+// NewConnection creates a new database connection whose queries are scoped to the tenant of their context
 func NewConnection(cfg *config.Config) (*DB, error) {
-	db, err := sql.Open("postgres", cfg.DatabaseURL())
+	return open(cfg, "")
+}
+
+// NewSystemConnection creates a database connection acting as SystemRole, for the dispatcher, cross-tenant
+// jobs and lookups made before the tenant is known
+func NewSystemConnection(cfg *config.Config) (*DB, error) {
+	return open(cfg, SystemRole)
+}
+
+// open opens a connection pool whose connections act as role, or as the connecting user when role is empty
+func open(cfg *config.Config, role string) (*DB, error) {
+	pqConnector, err := pq.NewConnector(cfg.DatabaseURL())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
+	db := sql.OpenDB(connector{Connector: pqConnector, role: role})
 
 	// Configure connection pool
 	db.SetMaxOpenConns(25)
@@ -28,12 +47,151 @@ func NewConnection(cfg *config.Config) (*DB, error) {
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
 	return &DB{DB: db}, nil
 }
 
+// driverConn is the set of driver interfaces implemented by lib/pq connections
+type driverConn interface {
+	driver.Conn
+	driver.QueryerContext
+	driver.ExecerContext
+	driver.ConnPrepareContext
+	driver.ConnBeginTx
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+// connector opens connections that act as a role, or that are scoped to a tenant per query
+type connector struct {
+	driver.Connector
+	role string
+}
+
+// Connect opens a connection and sets its role
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dc, ok := conn.(driverConn)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("unsupported database driver connection %T", conn)
+	}
+
+	if c.role == "" {
+		return &tenantScopedConn{driverConn: dc}, nil
+	}
+
+	if _, err := dc.ExecContext(ctx, "SET ROLE "+pq.QuoteIdentifier(c.role), nil); err != nil {
+		dc.Close()
+		return nil, fmt.Errorf("failed to set database role %s: %w", c.role, err)
+	}
+	return dc, nil
+}
+
+// errUnscopedStatement rejects statements prepared outside a transaction, as the tenant of the connection
+// could change between preparing and executing them
+var errUnscopedStatement = errors.New("statements can only be prepared inside a transaction")
+
+// tenantScopedConn is a connection that scopes each query outside a transaction to the tenant of the query's
+// context by setting app.tenant_id first, on the same connection. Inside a transaction WithinTransaction has
+// scoped the transaction already. A context without a tenant scopes to none, which row-level security lets
+// see nothing.
+type tenantScopedConn struct {
+	driverConn
+	tenant string // app.tenant_id of the session, as last set
+	scoped bool   // whether tenant is known to be in effect
+	inTx   bool
+}
+
+// scope sets the session's app.tenant_id to the tenant of the context unless it is set already
+func (c *tenantScopedConn) scope(ctx context.Context) error {
+	tenant := tenantSetting(ctx)
+	if c.scoped && c.tenant == tenant {
+		return nil
+	}
+
+	c.scoped = false
+	args := []driver.NamedValue{{Ordinal: 1, Value: tenant}}
+	if _, err := c.driverConn.ExecContext(ctx, `SELECT set_config('app.tenant_id', $1, false)`, args); err != nil {
+		if errors.Is(err, driver.ErrBadConn) {
+			return err
+		}
+		return fmt.Errorf("failed to scope connection to tenant: %w", err)
+	}
+
+	c.tenant, c.scoped = tenant, true
+	return nil
+}
+
+// QueryContext runs a query scoped to the tenant of the context
+func (c *tenantScopedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !c.inTx {
+		if err := c.scope(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return c.driverConn.QueryContext(ctx, query, args)
+}
+
+// ExecContext runs a statement scoped to the tenant of the context
+func (c *tenantScopedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if !c.inTx {
+		if err := c.scope(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return c.driverConn.ExecContext(ctx, query, args)
+}
+
+// PrepareContext prepares a statement of a transaction
+func (c *tenantScopedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if !c.inTx {
+		return nil, errUnscopedStatement
+	}
+	return c.driverConn.PrepareContext(ctx, query)
+}
+
+// Prepare prepares a statement of a transaction
+func (c *tenantScopedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// BeginTx starts a transaction; until it ends, its queries are left to the scope of the transaction
+func (c *tenantScopedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := c.driverConn.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.inTx = true
+	return &tenantScopedTx{Tx: tx, conn: c}, nil
+}
+
+// tenantScopedTx is a transaction of a tenantScopedConn
+type tenantScopedTx struct {
+	driver.Tx
+	conn *tenantScopedConn
+}
+
+// Commit commits the transaction
+func (tx *tenantScopedTx) Commit() error {
+	tx.conn.inTx = false
+	return tx.Tx.Commit()
+}
+
+// Rollback rolls the transaction back
+func (tx *tenantScopedTx) Rollback() error {
+	tx.conn.inTx = false
+	return tx.Tx.Rollback()
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	return db.DB.Close()
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Parent products list their variant axes, variants point to their parent
+ALTER TABLE products
+    ADD COLUMN parent_id UUID REFERENCES products(id) ON DELETE RESTRICT,
+    ADD COLUMN variant_axes TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN attributes JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_products_parent_id ON products(parent_id);
+
+-- Keep the stock of a parent product equal to the sum of its variants' stock
+CREATE OR REPLACE FUNCTION rollup_variant_stock()
+RETURNS TRIGGER AS $$
+DECLARE
+    parent_product_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        parent_product_id := OLD.parent_id;
+    ELSE
+        parent_product_id := NEW.parent_id;
+    END IF;
+
+    IF parent_product_id IS NOT NULL THEN
+        UPDATE products
+        SET stock = (SELECT COALESCE(SUM(stock), 0) FROM products WHERE parent_id = parent_product_id)
+        WHERE id = parent_product_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE 'plpgsql';
+
+CREATE TRIGGER rollup_products_variant_stock AFTER INSERT OR UPDATE OF stock OR DELETE ON products
+    FOR EACH ROW EXECUTE FUNCTION rollup_variant_stock();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS rollup_products_variant_stock ON products;
+DROP FUNCTION IF EXISTS rollup_variant_stock();
+DROP INDEX IF EXISTS idx_products_parent_id;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS variant_axes,
+    DROP COLUMN IF EXISTS parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Closing stock per product at the end of each day (UTC), materialized from the transaction ledger
+CREATE TABLE IF NOT EXISTS stock_snapshots (
+    snapshot_date DATE NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (snapshot_date, product_id)
+);
+
+-- Point-in-time queries sum a product's ledger between a snapshot and the requested time
+CREATE INDEX IF NOT EXISTS idx_transactions_product_id_created_at ON transactions(product_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_product_id_created_at;
+DROP TABLE IF EXISTS stock_snapshots;
+-- +goose StatementEnd
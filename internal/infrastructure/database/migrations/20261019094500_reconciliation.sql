@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Results of comparing products.stock with the transaction ledger
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    repair BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('completed', 'failed')),
+    products_checked INTEGER NOT NULL DEFAULT 0,
+    discrepancies INTEGER NOT NULL DEFAULT 0,
+    repaired INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_by UUID NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reconciliation_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id UUID NOT NULL REFERENCES reconciliation_runs(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    stock INTEGER NOT NULL,
+    ledger_stock INTEGER NOT NULL,
+    difference INTEGER NOT NULL,
+    transactions INTEGER NOT NULL DEFAULT 0,
+    last_transaction_at TIMESTAMP WITH TIME ZONE,
+    adjustment_id UUID REFERENCES transactions(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_started_at ON reconciliation_runs(started_at);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_items_run_id ON reconciliation_items(run_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_reconciliation_items_run_id;
+DROP INDEX IF EXISTS idx_reconciliation_runs_started_at;
+DROP TABLE IF EXISTS reconciliation_items;
+DROP TABLE IF EXISTS reconciliation_runs;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Responses stored per Idempotency-Key so retried requests are replayed instead of applied twice.
+-- status_code is NULL while the first request is still being processed.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(255) PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL,
+    status_code INTEGER,
+    content_type VARCHAR(255),
+    response_body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- Every transaction keeps the key of the request that produced it, so a retry arriving after the
+-- stored response expired still cannot post the movement twice
+ALTER TABLE transactions ADD COLUMN idempotency_key VARCHAR(300);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_idempotency_key ON transactions(idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_transactions_idempotency_key;
+ALTER TABLE transactions DROP COLUMN IF EXISTS idempotency_key;
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Incremented on every catalog edit; clients send it back in If-Match to detect concurrent edits.
+-- Stock movements do not change it.
+ALTER TABLE products ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE products DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users are identified by the subject of their API token; each user has at most one role.
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id UUID PRIMARY KEY,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('viewer', 'clerk', 'supervisor', 'admin')),
+    assigned_by UUID NOT NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_roles;
+-- +goose StatementEnd
@@ -0,0 +1,107 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Organizations sharing the deployment. Rows that existed before tenancy belong to the default tenant.
+CREATE TABLE IF NOT EXISTS tenants (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO tenants (id, name) VALUES ('00000000-0000-0000-0000-000000000001', 'default')
+ON CONFLICT (id) DO NOTHING;
+
+-- The tenant the current transaction is scoped to, or NULL outside a tenant scoped transaction
+CREATE OR REPLACE FUNCTION current_tenant_id() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')::uuid
+$$ LANGUAGE sql STABLE;
+
+-- Every tenant owned table gets a tenant_id without default, so a write that names no tenant fails,
+-- and a row-level security policy as a backstop to the tenant filter of every query. The policy is
+-- forced for the table owner too and applies whenever app.tenant_id is set.
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'categories', 'products', 'transactions', 'suppliers', 'product_suppliers', 'purchase_orders',
+        'reservations', 'stock_snapshots', 'reconciliation_runs', 'idempotency_keys', 'user_roles', 'api_keys'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN tenant_id UUID NOT NULL DEFAULT %L REFERENCES tenants(id)',
+                       t, '00000000-0000-0000-0000-000000000001');
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id DROP DEFAULT', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())', t);
+    END LOOP;
+END $$;
+
+-- Names, SKUs and keys are unique per tenant
+ALTER TABLE categories DROP CONSTRAINT categories_name_key;
+ALTER TABLE categories ADD CONSTRAINT categories_tenant_id_name_key UNIQUE (tenant_id, name);
+ALTER TABLE products DROP CONSTRAINT products_sku_key;
+ALTER TABLE products ADD CONSTRAINT products_tenant_id_sku_key UNIQUE (tenant_id, sku);
+ALTER TABLE suppliers DROP CONSTRAINT suppliers_name_key;
+ALTER TABLE suppliers ADD CONSTRAINT suppliers_tenant_id_name_key UNIQUE (tenant_id, name);
+ALTER TABLE purchase_orders DROP CONSTRAINT purchase_orders_number_key;
+ALTER TABLE purchase_orders ADD CONSTRAINT purchase_orders_tenant_id_number_key UNIQUE (tenant_id, number);
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (tenant_id, key);
+ALTER TABLE user_roles DROP CONSTRAINT user_roles_pkey;
+ALTER TABLE user_roles ADD PRIMARY KEY (tenant_id, user_id);
+
+DROP INDEX IF EXISTS idx_transactions_idempotency_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_idempotency_key ON transactions(tenant_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_tenant_id_created_at ON transactions(tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_product_suppliers_tenant_id ON product_suppliers(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_reservations_tenant_id ON reservations(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_stock_snapshots_tenant_id ON stock_snapshots(tenant_id, snapshot_date);
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_tenant_id ON reconciliation_runs(tenant_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_api_keys_tenant_id ON api_keys(tenant_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_api_keys_tenant_id;
+DROP INDEX IF EXISTS idx_reconciliation_runs_tenant_id;
+DROP INDEX IF EXISTS idx_stock_snapshots_tenant_id;
+DROP INDEX IF EXISTS idx_reservations_tenant_id;
+DROP INDEX IF EXISTS idx_product_suppliers_tenant_id;
+DROP INDEX IF EXISTS idx_transactions_tenant_id_created_at;
+
+DROP INDEX IF EXISTS idx_transactions_idempotency_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_idempotency_key ON transactions(idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+ALTER TABLE user_roles DROP CONSTRAINT user_roles_pkey;
+ALTER TABLE user_roles ADD PRIMARY KEY (user_id);
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (key);
+ALTER TABLE purchase_orders DROP CONSTRAINT purchase_orders_tenant_id_number_key;
+ALTER TABLE purchase_orders ADD CONSTRAINT purchase_orders_number_key UNIQUE (number);
+ALTER TABLE suppliers DROP CONSTRAINT suppliers_tenant_id_name_key;
+ALTER TABLE suppliers ADD CONSTRAINT suppliers_name_key UNIQUE (name);
+ALTER TABLE products DROP CONSTRAINT products_tenant_id_sku_key;
+ALTER TABLE products ADD CONSTRAINT products_sku_key UNIQUE (sku);
+ALTER TABLE categories DROP CONSTRAINT categories_tenant_id_name_key;
+ALTER TABLE categories ADD CONSTRAINT categories_name_key UNIQUE (name);
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'categories', 'products', 'transactions', 'suppliers', 'product_suppliers', 'purchase_orders',
+        'reservations', 'stock_snapshots', 'reconciliation_runs', 'idempotency_keys', 'user_roles', 'api_keys'
+    ] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS tenant_id', t);
+    END LOOP;
+END $$;
+
+DROP FUNCTION IF EXISTS current_tenant_id();
+DROP TABLE IF EXISTS tenants;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleted products and categories are kept with deleted_at set until purged. SKUs and category
+-- names only need to be unique among rows that are not deleted, so they can be reused.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE products DROP CONSTRAINT products_tenant_id_sku_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_tenant_id_sku ON products(tenant_id, sku) WHERE deleted_at IS NULL;
+ALTER TABLE categories DROP CONSTRAINT categories_tenant_id_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_tenant_id_name ON categories(tenant_id, name) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_deleted_at ON products(tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Soft-deleted rows become visible again; restoring the constraints fails if a deleted row shares a SKU or name
+DROP INDEX IF EXISTS idx_products_deleted_at;
+DROP INDEX IF EXISTS idx_categories_tenant_id_name;
+ALTER TABLE categories ADD CONSTRAINT categories_tenant_id_name_key UNIQUE (tenant_id, name);
+DROP INDEX IF EXISTS idx_products_tenant_id_sku;
+ALTER TABLE products ADD CONSTRAINT products_tenant_id_sku_key UNIQUE (tenant_id, sku);
+
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
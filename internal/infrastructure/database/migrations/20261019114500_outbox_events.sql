@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Domain events written in the transaction of the change and relayed to publishers by the dispatcher.
+-- sequence orders the events; events of one product are delivered in that order.
+CREATE TABLE IF NOT EXISTS outbox_events (
+    sequence BIGSERIAL PRIMARY KEY,
+    id UUID NOT NULL UNIQUE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    event_type VARCHAR(50) NOT NULL,
+    product_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(product_id, sequence) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events(published_at) WHERE published_at IS NOT NULL;
+
+ALTER TABLE outbox_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE outbox_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON outbox_events USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox_events;
+-- +goose StatementEnd
@@ -0,0 +1,76 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Stock alerts: a product whose stock left its bounds, open until a movement brings it back
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    alert_type VARCHAR(20) NOT NULL CHECK (alert_type IN ('low_stock', 'over_stock')),
+    stock INTEGER NOT NULL,
+    threshold INTEGER NOT NULL,
+    opened_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+-- At most one open alert per product and type
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_alerts_open ON stock_alerts(product_id, alert_type) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_stock_alerts_tenant_opened_at ON stock_alerts(tenant_id, opened_at);
+
+-- Alert subscriptions of users, to one category and its subcategories or to every category when category_id is NULL
+CREATE TABLE IF NOT EXISTS alert_subscriptions (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    user_id UUID NOT NULL,
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'slack', 'in_app')),
+    target TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_subscriptions_user_id ON alert_subscriptions(user_id);
+CREATE INDEX IF NOT EXISTS idx_alert_subscriptions_category_id ON alert_subscriptions(tenant_id, category_id);
+
+-- Notifications sent per subscription, so a redelivered alert event is not sent twice
+CREATE TABLE IF NOT EXISTS alert_notifications (
+    subscription_id UUID NOT NULL REFERENCES alert_subscriptions(id) ON DELETE CASCADE,
+    alert_id UUID NOT NULL REFERENCES stock_alerts(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (subscription_id, alert_id, event_type)
+);
+
+-- The in-app alert feed of each user
+CREATE TABLE IF NOT EXISTS alert_feed (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    user_id UUID NOT NULL,
+    alert_id UUID NOT NULL REFERENCES stock_alerts(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    subject TEXT NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_feed_user_id ON alert_feed(user_id, created_at);
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['stock_alerts', 'alert_subscriptions', 'alert_notifications', 'alert_feed'] LOOP
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())', t);
+    END LOOP;
+END $$;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS alert_feed;
+DROP TABLE IF EXISTS alert_notifications;
+DROP TABLE IF EXISTS alert_subscriptions;
+DROP TABLE IF EXISTS stock_alerts;
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Work that spans tenants, such as relaying events, cleaning up and looking up API keys, runs as this role.
+-- The application's user becomes it with SET ROLE on a separate connection pool.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'inventory_system') THEN
+        CREATE ROLE inventory_system NOLOGIN;
+    END IF;
+END $$;
+
+GRANT inventory_system TO CURRENT_USER;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO inventory_system;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO inventory_system;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO inventory_system;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO inventory_system;
+
+-- Without app.tenant_id a query sees no rows instead of every tenant's. Only inventory_system, which the
+-- session has to switch to explicitly, sees every tenant; mere membership is not enough.
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOR t IN SELECT tablename FROM pg_policies WHERE schemaname = 'public' AND policyname = 'tenant_isolation' LOOP
+        EXECUTE format('ALTER POLICY tenant_isolation ON %I USING (tenant_id = current_tenant_id())', t);
+        EXECUTE format('CREATE POLICY system_access ON %I USING (current_user = %L) WITH CHECK (current_user = %L)',
+                       t, 'inventory_system', 'inventory_system');
+    END LOOP;
+END $$;
+
+-- The tenant the current transaction or connection is scoped to, or NULL when it is not scoped
+CREATE OR REPLACE FUNCTION current_tenant_id() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')::uuid
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOR t IN SELECT tablename FROM pg_policies WHERE schemaname = 'public' AND policyname = 'tenant_isolation' LOOP
+        EXECUTE format('ALTER POLICY tenant_isolation ON %I USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())', t);
+        EXECUTE format('DROP POLICY IF EXISTS system_access ON %I', t);
+    END LOOP;
+END $$;
+
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE USAGE, SELECT ON SEQUENCES FROM inventory_system;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM inventory_system;
+REVOKE USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public FROM inventory_system;
+REVOKE SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public FROM inventory_system;
+REVOKE inventory_system FROM CURRENT_USER;
+DROP ROLE IF EXISTS inventory_system;
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Roll variant stock up into the parent as a delta. Summing the siblings instead lets two transactions that
+-- change sibling variants at the same time each read the other's old stock, so the last one to commit would
+-- overwrite the parent with a total missing the other's change. Adding to the locked parent row cannot.
+CREATE OR REPLACE FUNCTION rollup_variant_stock()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.parent_id IS NOT DISTINCT FROM NEW.parent_id THEN
+        IF NEW.parent_id IS NOT NULL AND NEW.stock <> OLD.stock THEN
+            UPDATE products SET stock = stock + NEW.stock - OLD.stock WHERE id = NEW.parent_id;
+        END IF;
+        RETURN NULL;
+    END IF;
+
+    -- A deleted variant, or one moved to another parent, leaves its old parent
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.parent_id IS NOT NULL THEN
+        UPDATE products SET stock = stock - OLD.stock WHERE id = OLD.parent_id;
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.parent_id IS NOT NULL THEN
+        UPDATE products SET stock = stock + NEW.stock WHERE id = NEW.parent_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE 'plpgsql';
+
+DROP TRIGGER IF EXISTS rollup_products_variant_stock ON products;
+CREATE TRIGGER rollup_products_variant_stock AFTER INSERT OR UPDATE OF stock, parent_id OR DELETE ON products
+    FOR EACH ROW EXECUTE FUNCTION rollup_variant_stock();
+
+-- Repair parents that already lost a concurrent change, in every tenant
+SET LOCAL ROLE inventory_system;
+UPDATE products p
+SET stock = v.stock
+FROM (SELECT parent_id, SUM(stock) AS stock FROM products WHERE parent_id IS NOT NULL GROUP BY parent_id) v
+WHERE p.id = v.parent_id AND p.stock <> v.stock;
+RESET ROLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION rollup_variant_stock()
+RETURNS TRIGGER AS $$
+DECLARE
+    parent_product_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        parent_product_id := OLD.parent_id;
+    ELSE
+        parent_product_id := NEW.parent_id;
+    END IF;
+
+    IF parent_product_id IS NOT NULL THEN
+        UPDATE products
+        SET stock = (SELECT COALESCE(SUM(stock), 0) FROM products WHERE parent_id = parent_product_id)
+        WHERE id = parent_product_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE 'plpgsql';
+
+DROP TRIGGER IF EXISTS rollup_products_variant_stock ON products;
+CREATE TRIGGER rollup_products_variant_stock AFTER INSERT OR UPDATE OF stock OR DELETE ON products
+    FOR EACH ROW EXECUTE FUNCTION rollup_variant_stock();
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Idempotency keys belong to the user or API key that sent them, so a stored response is only replayed
+-- to its own sender. Responses stored before have no sender and are left to expire.
+ALTER TABLE idempotency_keys ADD COLUMN subject UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE idempotency_keys ALTER COLUMN subject DROP DEFAULT;
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (tenant_id, subject, key);
+
+-- Transactions record the key prefixed with its sender
+ALTER TABLE transactions ALTER COLUMN idempotency_key TYPE TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions ALTER COLUMN idempotency_key TYPE VARCHAR(300) USING LEFT(idempotency_key, 300);
+
+-- Keep the latest record of keys that several senders used, in every tenant
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+SET LOCAL ROLE inventory_system;
+DELETE FROM idempotency_keys a USING idempotency_keys b
+WHERE a.tenant_id = b.tenant_id AND a.key = b.key AND (a.created_at, a.subject) < (b.created_at, b.subject);
+RESET ROLE;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (tenant_id, key);
+ALTER TABLE idempotency_keys DROP COLUMN subject;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The ABC/XYZ class of each stocked product as of the latest scheduled classification, which the product
+-- list filters on
+CREATE TABLE IF NOT EXISTS product_classifications (
+    product_id UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    abc_class CHAR(1) NOT NULL CHECK (abc_class IN ('A', 'B', 'C')),
+    xyz_class CHAR(1) NOT NULL CHECK (xyz_class IN ('X', 'Y', 'Z')),
+    classified_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_classifications_class ON product_classifications(tenant_id, abc_class, xyz_class);
+
+ALTER TABLE product_classifications ENABLE ROW LEVEL SECURITY;
+ALTER TABLE product_classifications FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON product_classifications USING (tenant_id = current_tenant_id());
+CREATE POLICY system_access ON product_classifications
+    USING (current_user = 'inventory_system') WITH CHECK (current_user = 'inventory_system');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_classifications;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Snapshots are now summed from the ledger; rebuild those copied from products.stock, in every tenant
+SET LOCAL ROLE inventory_system;
+UPDATE stock_snapshots s
+SET quantity = COALESCE((
+    SELECT SUM(CASE t.type WHEN 'out' THEN -t.quantity ELSE t.quantity END) FROM transactions t
+    WHERE t.product_id = s.product_id AND t.created_at < ((s.snapshot_date + 1)::timestamp AT TIME ZONE 'UTC')
+), 0);
+RESET ROLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- The snapshots are left as rebuilt from the ledger
@@ -0,0 +1,323 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+const alertColumns = `id, product_id, alert_type, stock, threshold, opened_at, resolved_at, tenant_id`
+
+const alertSubscriptionColumns = `id, user_id, category_id, channel, target, created_at, tenant_id`
+
+type alertRepository struct {
+	db *database.DB
+}
+
+// NewAlertRepository creates a new stock alert repository
+func NewAlertRepository(db *database.DB) repositories.AlertRepository {
+	return &alertRepository{db: db}
+}
+
+// Open stores an alert in the tenant of the context; the open-alert index keeps a second one out
+func (r *alertRepository) Open(ctx context.Context, alert *entities.Alert) (bool, error) {
+	query := `
+		INSERT INTO stock_alerts (id, product_id, alert_type, stock, threshold, opened_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (product_id, alert_type) WHERE resolved_at IS NULL DO NOTHING
+	`
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		alert.ID, alert.ProductID, alert.Type, alert.Stock, alert.Threshold, alert.OpenedAt, tenantID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to open stock alert: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	alert.TenantID = tenantID
+	return rowsAffected > 0, nil
+}
+
+// GetOpenByProduct retrieves the open alerts of a product
+func (r *alertRepository) GetOpenByProduct(ctx context.Context, productID uuid.UUID) ([]*entities.Alert, error) {
+	query := `
+		SELECT ` + alertColumns + ` FROM stock_alerts
+		WHERE product_id = $1 AND resolved_at IS NULL AND tenant_id = $2
+		ORDER BY opened_at
+	`
+
+	return r.query(ctx, query, productID, entities.TenantIDFromContext(ctx))
+}
+
+// Resolve marks an open alert of the tenant resolved
+func (r *alertRepository) Resolve(ctx context.Context, id uuid.UUID, resolvedAt time.Time) error {
+	query := `UPDATE stock_alerts SET resolved_at = $2 WHERE id = $1 AND resolved_at IS NULL AND tenant_id = $3`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, id, resolvedAt, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to resolve stock alert: %w", err)
+	}
+
+	return nil
+}
+
+// GetAll retrieves the alerts of the tenant, newest first
+func (r *alertRepository) GetAll(ctx context.Context, status, alertType string, limit, offset int) ([]*entities.Alert, error) {
+	query := `
+		SELECT ` + alertColumns + ` FROM stock_alerts
+		WHERE tenant_id = $1
+		  AND ($2 = '' OR ($2 = 'open') = (resolved_at IS NULL))
+		  AND ($3 = '' OR alert_type = $3)
+		ORDER BY opened_at DESC, id LIMIT $4 OFFSET $5
+	`
+
+	return r.query(ctx, query, entities.TenantIDFromContext(ctx), status, alertType, limit, offset)
+}
+
+// query runs a query selecting alertColumns
+func (r *alertRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.Alert, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []*entities.Alert
+	for rows.Next() {
+		alert := &entities.Alert{}
+		err := rows.Scan(
+			&alert.ID, &alert.ProductID, &alert.Type, &alert.Stock, &alert.Threshold,
+			&alert.OpenedAt, &alert.ResolvedAt, &alert.TenantID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, rows.Err()
+}
+
+type alertSubscriptionRepository struct {
+	db *database.DB
+}
+
+// NewAlertSubscriptionRepository creates a new alert subscription repository
+func NewAlertSubscriptionRepository(db *database.DB) repositories.AlertSubscriptionRepository {
+	return &alertSubscriptionRepository{db: db}
+}
+
+// Create stores a new subscription in the tenant of the context
+func (r *alertSubscriptionRepository) Create(ctx context.Context, subscription *entities.AlertSubscription) error {
+	query := `
+		INSERT INTO alert_subscriptions (id, user_id, category_id, channel, target, created_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		subscription.ID, subscription.UserID, subscription.CategoryID, subscription.Channel,
+		subscription.Target, subscription.CreatedAt, tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create alert subscription: %w", err)
+	}
+
+	subscription.TenantID = tenantID
+	return nil
+}
+
+// GetByUser retrieves the subscriptions of a user, oldest first
+func (r *alertSubscriptionRepository) GetByUser(ctx context.Context, userID uuid.UUID) ([]*entities.AlertSubscription, error) {
+	query := `
+		SELECT ` + alertSubscriptionColumns + ` FROM alert_subscriptions
+		WHERE user_id = $1 AND tenant_id = $2
+		ORDER BY created_at
+	`
+
+	return r.query(ctx, query, userID, entities.TenantIDFromContext(ctx))
+}
+
+// GetByCategories retrieves the subscriptions of the tenant to any of the categories or to every category
+func (r *alertSubscriptionRepository) GetByCategories(ctx context.Context, categoryIDs []uuid.UUID) ([]*entities.AlertSubscription, error) {
+	query := `
+		SELECT ` + alertSubscriptionColumns + ` FROM alert_subscriptions
+		WHERE tenant_id = $1 AND (category_id IS NULL OR category_id = ANY($2::uuid[]))
+		ORDER BY created_at
+	`
+
+	return r.query(ctx, query, entities.TenantIDFromContext(ctx), uuidArray(categoryIDs))
+}
+
+// query runs a query selecting alertSubscriptionColumns
+func (r *alertSubscriptionRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.AlertSubscription, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*entities.AlertSubscription
+	for rows.Next() {
+		subscription := &entities.AlertSubscription{}
+		err := rows.Scan(
+			&subscription.ID, &subscription.UserID, &subscription.CategoryID, &subscription.Channel,
+			&subscription.Target, &subscription.CreatedAt, &subscription.TenantID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// Delete deletes a subscription of a user in the tenant
+func (r *alertSubscriptionRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM alert_subscriptions WHERE id = $1 AND user_id = $2 AND tenant_id = $3`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, id, userID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete alert subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrAlertSubscriptionNotFound
+	}
+
+	return nil
+}
+
+// IsNotified checks if a notification of the alert event was recorded for the subscription
+func (r *alertSubscriptionRepository) IsNotified(ctx context.Context, subscriptionID, alertID uuid.UUID, eventType string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM alert_notifications
+			WHERE subscription_id = $1 AND alert_id = $2 AND event_type = $3 AND tenant_id = $4
+		)
+	`
+
+	var notified bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, subscriptionID, alertID, eventType, entities.TenantIDFromContext(ctx)).Scan(&notified)
+	if err != nil {
+		return false, fmt.Errorf("failed to check alert notification: %w", err)
+	}
+
+	return notified, nil
+}
+
+// MarkNotified records a notification of the alert event for the subscription
+func (r *alertSubscriptionRepository) MarkNotified(ctx context.Context, subscriptionID, alertID uuid.UUID, eventType string) error {
+	query := `
+		INSERT INTO alert_notifications (subscription_id, alert_id, event_type, tenant_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, subscriptionID, alertID, eventType, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to record alert notification: %w", err)
+	}
+
+	return nil
+}
+
+type alertFeedRepository struct {
+	db *database.DB
+}
+
+// NewAlertFeedRepository creates a new in-app alert feed repository
+func NewAlertFeedRepository(db *database.DB) repositories.AlertFeedRepository {
+	return &alertFeedRepository{db: db}
+}
+
+// Create stores a feed item in the tenant of the context
+func (r *alertFeedRepository) Create(ctx context.Context, item *entities.AlertFeedItem) error {
+	query := `
+		INSERT INTO alert_feed (id, user_id, alert_id, event_type, subject, message, created_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		item.ID, item.UserID, item.AlertID, item.EventType, item.Subject, item.Message, item.CreatedAt, tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create alert feed item: %w", err)
+	}
+
+	item.TenantID = tenantID
+	return nil
+}
+
+// GetByUser retrieves the feed of a user, newest first
+func (r *alertFeedRepository) GetByUser(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entities.AlertFeedItem, error) {
+	query := `
+		SELECT id, user_id, alert_id, event_type, subject, message, created_at, read_at, tenant_id
+		FROM alert_feed
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL) AND tenant_id = $5
+		ORDER BY created_at DESC, id LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, userID, unreadOnly, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert feed: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*entities.AlertFeedItem
+	for rows.Next() {
+		item := &entities.AlertFeedItem{}
+		err := rows.Scan(
+			&item.ID, &item.UserID, &item.AlertID, &item.EventType, &item.Subject, &item.Message,
+			&item.CreatedAt, &item.ReadAt, &item.TenantID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert feed item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// MarkRead marks a feed item of a user read; an item read before keeps its first read time
+func (r *alertFeedRepository) MarkRead(ctx context.Context, id, userID uuid.UUID, readAt time.Time) error {
+	query := `
+		UPDATE alert_feed SET read_at = COALESCE(read_at, $3)
+		WHERE id = $1 AND user_id = $2 AND tenant_id = $4
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, id, userID, readAt, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to mark alert feed item read: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrAlertFeedItemNotFound
+	}
+
+	return nil
+}
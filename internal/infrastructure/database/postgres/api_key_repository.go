@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+const apiKeyColumns = `id, name, prefix, secret_hash, scopes, expires_at, last_used_at, created_by, created_at, revoked_at, tenant_id`
+
+type apiKeyRepository struct {
+	db *database.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *database.DB) repositories.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// scanAPIKey scans a row selected with apiKeyColumns
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*entities.APIKey, error) {
+	key := &entities.APIKey{}
+	var scopes pq.StringArray
+
+	err := row.Scan(
+		&key.ID, &key.Name, &key.Prefix, &key.SecretHash, &scopes,
+		&key.ExpiresAt, &key.LastUsedAt, &key.CreatedBy, &key.CreatedAt, &key.RevokedAt, &key.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Scopes = make([]entities.Permission, len(scopes))
+	for i, scope := range scopes {
+		key.Scopes[i] = entities.Permission(scope)
+	}
+
+	return key, nil
+}
+
+// Create stores a new API key in the tenant of the context
+func (r *apiKeyRepository) Create(ctx context.Context, key *entities.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, name, prefix, secret_hash, scopes, expires_at, created_by, created_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10)
+	`
+
+	scopes := make(pq.StringArray, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		key.ID, key.Name, key.Prefix, key.SecretHash, scopes, key.ExpiresAt, key.CreatedBy, key.CreatedAt, tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	key.TenantID = tenantID
+	return nil
+}
+
+// GetByID retrieves an API key by ID
+func (r *apiKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1 AND tenant_id = $2`
+
+	key, err := scanAPIKey(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetByPrefix retrieves an API key by its public prefix in any tenant, since the tenant of a request
+// is only known once its key is authenticated
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE prefix = $1`
+
+	key, err := scanAPIKey(r.db.Conn(ctx).QueryRowContext(ctx, query, prefix))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetAll retrieves all API keys of the tenant, newest first
+func (r *apiKeyRepository) GetAll(ctx context.Context) ([]*entities.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE tenant_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*entities.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke marks an API key as revoked; revoking a key twice keeps the first timestamp
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, $2) WHERE id = $1 AND tenant_id = $3`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, id, revokedAt, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records a use of the key, skipping the write if the key was used within the interval.
+// Like GetByPrefix it runs while the key is authenticated, before the tenant is known.
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time, interval time.Duration) error {
+	query := `
+		UPDATE api_keys SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $3)
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, id, usedAt, usedAt.Add(-interval))
+	if err != nil {
+		return fmt.Errorf("failed to update API key last use: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+const auditColumns = `id, entity_type, entity_id, action, changes, actor_id, request_id, ip, created_at, tenant_id`
+
+type auditRepository struct {
+	db *database.DB
+}
+
+// NewAuditRepository creates a new audit log repository
+func NewAuditRepository(db *database.DB) repositories.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// scanAuditEntry scans a row selected with auditColumns
+func scanAuditEntry(row interface{ Scan(...interface{}) error }) (*entities.AuditEntry, error) {
+	entry := &entities.AuditEntry{}
+	var changes []byte
+	var requestID, ip sql.NullString
+
+	err := row.Scan(
+		&entry.ID, &entry.EntityType, &entry.EntityID, &entry.Action, &changes,
+		&entry.ActorID, &requestID, &ip, &entry.CreatedAt, &entry.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+		return nil, fmt.Errorf("failed to decode audit changes: %w", err)
+	}
+	entry.RequestID = requestID.String
+	entry.IP = ip.String
+
+	return entry, nil
+}
+
+// Create appends an entry to the audit log of the tenant of the context
+func (r *auditRepository) Create(ctx context.Context, entry *entities.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (id, entity_type, entity_id, action, changes, actor_id, request_id, ip, created_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, $10)
+	`
+
+	changes := entry.Changes
+	if changes == nil {
+		changes = []entities.FieldChange{}
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err = r.db.Conn(ctx).ExecContext(ctx, query,
+		entry.ID, entry.EntityType, entry.EntityID, entry.Action, data,
+		entry.ActorID, entry.RequestID, entry.IP, entry.CreatedAt, tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	entry.TenantID = tenantID
+	return nil
+}
+
+// GetAll retrieves audit entries newest first, optionally restricted to an entity type and an entity
+func (r *auditRepository) GetAll(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]*entities.AuditEntry, error) {
+	query := `
+		SELECT ` + auditColumns + ` FROM audit_log
+		WHERE ($1 = '' OR entity_type = $1) AND ($2 = '00000000-0000-0000-0000-000000000000'::uuid OR entity_id = $2)
+		  AND tenant_id = $5
+		ORDER BY created_at DESC, id LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entityType, entityID, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entities.AuditEntry
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
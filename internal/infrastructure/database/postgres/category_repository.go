@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+// categoryColumns is the column list shared by every category query, in scan order
+const categoryColumns = `id, name, COALESCE(description, ''), parent_id, status, created_at, updated_at, tenant_id, deleted_at`
+
+type categoryRepository struct {
+	db *database.DB
+}
+
+// NewCategoryRepository creates a new category repository
+func NewCategoryRepository(db *database.DB) repositories.CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+// scanCategory scans a row selected with categoryColumns into a category
+func scanCategory(row rowScanner) (*entities.Category, error) {
+	category := &entities.Category{}
+	err := row.Scan(
+		&category.ID, &category.Name, &category.Description, &category.ParentID,
+		&category.Status, &category.CreatedAt, &category.UpdatedAt, &category.TenantID, &category.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// scanCategories scans all rows selected with categoryColumns
+func scanCategories(rows *sql.Rows) ([]*entities.Category, error) {
+	var categories []*entities.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// Create creates a new category in the tenant of the context
+func (r *categoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	query := `
+		INSERT INTO categories (id, name, description, parent_id, status, created_at, updated_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		category.ID, category.Name, category.Description, category.ParentID,
+		category.Status, category.CreatedAt, category.UpdatedAt, tenantID,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err, "idx_categories_tenant_id_name") {
+			return entities.ErrDuplicateCategoryName
+		}
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	category.TenantID = tenantID
+	return nil
+}
+
+// GetByID retrieves a category by ID; soft-deleted categories are not found
+func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	return r.getByID(ctx, `SELECT `+categoryColumns+` FROM categories WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`, id)
+}
+
+// GetByIDIncludingDeleted retrieves a category by ID whether or not it is soft-deleted
+func (r *categoryRepository) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	return r.getByID(ctx, `SELECT `+categoryColumns+` FROM categories WHERE id = $1 AND tenant_id = $2`, id)
+}
+
+// getByID runs a query for a single category by ID
+func (r *categoryRepository) getByID(ctx context.Context, query string, id uuid.UUID) (*entities.Category, error) {
+	category, err := scanCategory(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get category by ID: %w", err)
+	}
+
+	return category, nil
+}
+
+// GetAll retrieves all categories, including soft-deleted ones when asked to
+func (r *categoryRepository) GetAll(ctx context.Context, includeDeleted bool) ([]*entities.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE tenant_id = $1 AND ($2 OR deleted_at IS NULL) ORDER BY name ASC`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx), includeDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all categories: %w", err)
+	}
+	defer rows.Close()
+
+	return scanCategories(rows)
+}
+
+// GetByParentID retrieves the direct children of a category
+func (r *categoryRepository) GetByParentID(ctx context.Context, parentID uuid.UUID) ([]*entities.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE parent_id = $1 AND tenant_id = $2 AND deleted_at IS NULL ORDER BY name ASC`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, parentID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories by parent: %w", err)
+	}
+	defer rows.Close()
+
+	return scanCategories(rows)
+}
+
+// GetRootCategories retrieves the categories without a parent
+func (r *categoryRepository) GetRootCategories(ctx context.Context) ([]*entities.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE parent_id IS NULL AND tenant_id = $1 AND deleted_at IS NULL ORDER BY name ASC`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get root categories: %w", err)
+	}
+	defer rows.Close()
+
+	return scanCategories(rows)
+}
+
+// Update updates a category
+func (r *categoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	query := `
+		UPDATE categories
+		SET name = $2, description = $3, parent_id = $4, status = $5, updated_at = $6
+		WHERE id = $1 AND tenant_id = $7 AND deleted_at IS NULL
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		category.ID, category.Name, category.Description, category.ParentID,
+		category.Status, category.UpdatedAt, entities.TenantIDFromContext(ctx),
+	)
+
+	if err != nil {
+		if isUniqueViolation(err, "idx_categories_tenant_id_name") {
+			return entities.ErrDuplicateCategoryName
+		}
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a category
+func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID, deletedAt time.Time) error {
+	query := `UPDATE categories SET deleted_at = $2 WHERE id = $1 AND tenant_id = $3 AND deleted_at IS NULL`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, id, deletedAt, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return nil
+}
+
+// Restore undeletes a soft-deleted category
+func (r *categoryRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE categories SET deleted_at = NULL WHERE id = $1 AND tenant_id = $2`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, id, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		if isUniqueViolation(err, "idx_categories_tenant_id_name") {
+			return entities.ErrDuplicateCategoryName
+		}
+		return fmt.Errorf("failed to restore category: %w", err)
+	}
+
+	return nil
+}
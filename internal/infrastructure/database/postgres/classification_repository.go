@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+type classificationRepository struct {
+	db *database.DB
+}
+
+// NewClassificationRepository creates a new classification repository
+func NewClassificationRepository(db *database.DB) repositories.ClassificationRepository {
+	return &classificationRepository{db: db}
+}
+
+// Replace stores the classification of the tenant's products in place of the previous one, in one transaction
+func (r *classificationRepository) Replace(ctx context.Context, classifications []*entities.ProductClassification, classifiedAt time.Time) error {
+	ids := make([]uuid.UUID, len(classifications))
+	abcClasses := make(pq.StringArray, len(classifications))
+	xyzClasses := make(pq.StringArray, len(classifications))
+	for i, classification := range classifications {
+		ids[i] = classification.ProductID
+		abcClasses[i] = classification.ABCClass
+		xyzClasses[i] = classification.XYZClass
+	}
+
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		tenantID := entities.TenantIDFromContext(ctx)
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM product_classifications WHERE tenant_id = $1`, tenantID); err != nil {
+			return fmt.Errorf("failed to clear product classifications: %w", err)
+		}
+
+		query := `
+			INSERT INTO product_classifications (product_id, abc_class, xyz_class, classified_at, tenant_id)
+			SELECT c.product_id, c.abc_class, c.xyz_class, $4, $5
+			FROM unnest($1::uuid[], $2::text[], $3::text[]) AS c(product_id, abc_class, xyz_class)
+			JOIN products p ON p.id = c.product_id AND p.tenant_id = $5
+		`
+
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, uuidArray(ids), abcClasses, xyzClasses, classifiedAt, tenantID); err != nil {
+			return fmt.Errorf("failed to store product classifications: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetProductIDs returns the products in the given classes; an empty class matches any
+func (r *classificationRepository) GetProductIDs(ctx context.Context, abcClass, xyzClass string) ([]uuid.UUID, error) {
+	query := `
+		SELECT product_id FROM product_classifications
+		WHERE tenant_id = $1 AND ($2::text = '' OR abc_class = $2) AND ($3::text = '' OR xyz_class = $3)
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx), abcClass, xyzClass)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classified products: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan classified product: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate classified products: %w", err)
+	}
+
+	return ids, nil
+}
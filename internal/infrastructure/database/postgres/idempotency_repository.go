@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+type idempotencyRepository struct {
+	db *database.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency repository
+func NewIdempotencyRepository(db *database.DB) repositories.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Reserve stores a new record unless an unexpired one exists for the key of the sender in the tenant.
+// It returns false when the key is already taken.
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *entities.IdempotencyRecord) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (key, request_hash, created_at, expires_at, subject, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id, subject, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = NULL, response_body = NULL,
+		    created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		record.Key, record.RequestHash, record.CreatedAt, record.ExpiresAt,
+		entities.UserIDFromContext(ctx), entities.TenantIDFromContext(ctx),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+// GetByKey retrieves the unexpired record of a key sent by the user or API key of the context
+func (r *idempotencyRepository) GetByKey(ctx context.Context, key string) (*entities.IdempotencyRecord, error) {
+	query := `
+		SELECT key, request_hash, COALESCE(status_code, 0), COALESCE(content_type, ''), response_body, created_at, expires_at
+		FROM idempotency_keys WHERE key = $1 AND subject = $2 AND tenant_id = $3 AND expires_at > NOW()
+	`
+
+	record := &entities.IdempotencyRecord{}
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, key, entities.UserIDFromContext(ctx), entities.TenantIDFromContext(ctx)).Scan(
+		&record.Key, &record.RequestHash, &record.StatusCode, &record.ContentType, &record.Body,
+		&record.CreatedAt, &record.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return record, nil
+}
+
+// Complete stores the response of the request that reserved the key
+func (r *idempotencyRepository) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	query := `
+		UPDATE idempotency_keys SET status_code = $2, content_type = $3, response_body = $4
+		WHERE key = $1 AND subject = $5 AND tenant_id = $6
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, key, statusCode, contentType, body,
+		entities.UserIDFromContext(ctx), entities.TenantIDFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+// Delete releases a key so the request can be retried
+func (r *idempotencyRepository) Delete(ctx context.Context, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE key = $1 AND subject = $2 AND tenant_id = $3`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, key, entities.UserIDFromContext(ctx), entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes the expired keys of every tenant and returns how many were removed
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return result.RowsAffected()
+}
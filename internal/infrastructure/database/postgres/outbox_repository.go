@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+// outboxLockKey is the advisory lock key that keeps dispatchers of several instances from relaying the same events
+const outboxLockKey = 7365120045
+
+const outboxColumns = `sequence, id, tenant_id, event_type, product_id, payload, occurred_at, attempts, last_error, next_attempt_at,
+	published_at`
+
+type outboxRepository struct {
+	db *database.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *database.DB) repositories.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// Create writes an event to the outbox of the tenant of the context
+func (r *outboxRepository) Create(ctx context.Context, event *entities.Event) error {
+	query := `
+		INSERT INTO outbox_events (id, tenant_id, event_type, product_id, payload, occurred_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING sequence
+	`
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query,
+		event.ID, tenantID, event.Type, event.ProductID, []byte(event.Payload), event.OccurredAt, event.NextAttemptAt,
+	).Scan(&event.Sequence)
+	if err != nil {
+		return fmt.Errorf("failed to create outbox event: %w", err)
+	}
+
+	event.TenantID = tenantID
+	return nil
+}
+
+// Lock takes a transaction-scoped advisory lock
+func (r *outboxRepository) Lock(ctx context.Context) (bool, error) {
+	var locked bool
+	if err := r.db.Conn(ctx).QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxLockKey).Scan(&locked); err != nil {
+		return false, fmt.Errorf("failed to lock outbox: %w", err)
+	}
+	return locked, nil
+}
+
+// GetPending retrieves undelivered events that are due, in sequence order, skipping every event of a
+// product from its first event still waiting for a retry onwards
+func (r *outboxRepository) GetPending(ctx context.Context, limit int) ([]*entities.Event, error) {
+	query := `
+		SELECT ` + outboxColumns + ` FROM (
+			SELECT e.*, bool_or(e.next_attempt_at > NOW()) OVER (PARTITION BY e.product_id ORDER BY e.sequence) AS blocked
+			FROM outbox_events e
+			WHERE e.published_at IS NULL
+		) pending
+		WHERE NOT blocked
+		ORDER BY sequence
+		LIMIT $1
+	`
+
+	return r.query(ctx, query, limit)
+}
+
+// GetBySequence retrieves an event of the tenant of the context by its sequence
+func (r *outboxRepository) GetBySequence(ctx context.Context, sequence int64) (*entities.Event, error) {
+	query := `SELECT ` + outboxColumns + ` FROM outbox_events WHERE sequence = $1 AND tenant_id = $2`
+
+	events, err := r.query(ctx, query, sequence, entities.TenantIDFromContext(ctx))
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+
+	return events[0], nil
+}
+
+// GetPublishedSince retrieves delivered events of the tenant of the context in the order they were published
+func (r *outboxRepository) GetPublishedSince(ctx context.Context, since time.Time, eventTypes []string, limit int) ([]*entities.Event, error) {
+	query := `
+		SELECT ` + outboxColumns + ` FROM outbox_events
+		WHERE tenant_id = $1 AND published_at >= $2 AND event_type = ANY($3)
+		ORDER BY published_at, sequence
+		LIMIT $4
+	`
+
+	return r.query(ctx, query, entities.TenantIDFromContext(ctx), since, pq.StringArray(eventTypes), limit)
+}
+
+// query runs a query selecting outboxColumns
+func (r *outboxRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.Event, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entities.Event
+	for rows.Next() {
+		event := &entities.Event{}
+		var payload []byte
+		var lastError sql.NullString
+
+		err := rows.Scan(
+			&event.Sequence, &event.ID, &event.TenantID, &event.Type, &event.ProductID, &payload,
+			&event.OccurredAt, &event.Attempts, &lastError, &event.NextAttemptAt, &event.PublishedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+
+		event.Payload = payload
+		event.LastError = lastError.String
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// MarkPublished records the delivery of an event
+func (r *outboxRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE outbox_events SET published_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1`
+
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed delivery attempt and when to try again
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	query := `UPDATE outbox_events SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`
+
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, id, lastError, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// DeletePublished deletes the delivered events of every tenant published before the given time
+func (r *outboxRepository) DeletePublished(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	query := `DELETE FROM outbox_events WHERE published_at < $1`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, publishedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox events: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -3,15 +3,23 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"inventory-app/internal/domain/entities"
 	"inventory-app/internal/domain/repositories"
 	"inventory-app/internal/infrastructure/database"
 )
 
+// productColumns is the column list shared by every product query, in scan order
+const productColumns = `id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at,
+		parent_id, variant_axes, attributes, version, tenant_id, deleted_at`
+
 type productRepository struct {
 	db *database.DB
 }
@@ -21,40 +29,123 @@ func NewProductRepository(db *database.DB) repositories.ProductRepository {
 	return &productRepository{db: db}
 }
 
-// Create creates a new product
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanProduct scans a row selected with productColumns into a product
+func scanProduct(row rowScanner) (*entities.Product, error) {
+	product := &entities.Product{}
+	var attributes []byte
+	err := row.Scan(
+		&product.ID, &product.SKU, &product.Name, &product.Description, &product.CategoryID,
+		&product.Price, &product.Cost, &product.Stock, &product.MinStock, &product.MaxStock,
+		&product.Status, &product.CreatedAt, &product.UpdatedAt,
+		&product.ParentID, pq.Array(&product.VariantAxes), &attributes, &product.Version, &product.TenantID,
+		&product.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attributes) > 0 {
+		if err := json.Unmarshal(attributes, &product.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to decode product attributes: %w", err)
+		}
+	}
+
+	return product, nil
+}
+
+// scanProducts scans all rows selected with productColumns
+func scanProducts(rows *sql.Rows) ([]*entities.Product, error) {
+	var products []*entities.Product
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate products: %w", err)
+	}
+
+	return products, nil
+}
+
+// variantAxes returns the axes to store; the column does not accept NULL
+func variantAxes(product *entities.Product) pq.StringArray {
+	if product.VariantAxes == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(product.VariantAxes)
+}
+
+// uuidArray converts IDs to a text array that can be cast to uuid[]
+func uuidArray(ids []uuid.UUID) pq.StringArray {
+	values := make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
+
+// encodeAttributes encodes variant attributes for the JSONB column
+func encodeAttributes(attributes map[string]string) ([]byte, error) {
+	if attributes == nil {
+		return nil, nil
+	}
+	return json.Marshal(attributes)
+}
+
+// Create creates a new product in the tenant of the context
 func (r *productRepository) Create(ctx context.Context, product *entities.Product) error {
 	query := `
-		INSERT INTO products (id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO products (id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at,
+		                      parent_id, variant_axes, attributes, version, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	attributes, err := encodeAttributes(product.Attributes)
+	if err != nil {
+		return fmt.Errorf("failed to encode product attributes: %w", err)
+	}
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err = r.db.Conn(ctx).ExecContext(ctx, query,
 		product.ID, product.SKU, product.Name, product.Description, product.CategoryID,
 		product.Price, product.Cost, product.Stock, product.MinStock, product.MaxStock,
 		product.Status, product.CreatedAt, product.UpdatedAt,
+		product.ParentID, variantAxes(product), attributes, product.Version, tenantID,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err, "idx_products_tenant_id_sku") {
+			return entities.ErrDuplicateSKU
+		}
 		return fmt.Errorf("failed to create product: %w", err)
 	}
 
+	product.TenantID = tenantID
 	return nil
 }
 
-// GetByID retrieves a product by ID
+// GetByID retrieves a product by ID; soft-deleted products are not found
 func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
-	query := `
-		SELECT id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at
-		FROM products WHERE id = $1
-	`
+	return r.getByID(ctx, `SELECT `+productColumns+` FROM products WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`, id)
+}
 
-	product := &entities.Product{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&product.ID, &product.SKU, &product.Name, &product.Description, &product.CategoryID,
-		&product.Price, &product.Cost, &product.Stock, &product.MinStock, &product.MaxStock,
-		&product.Status, &product.CreatedAt, &product.UpdatedAt,
-	)
+// GetByIDIncludingDeleted retrieves a product by ID whether or not it is soft-deleted
+func (r *productRepository) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*entities.Product, error) {
+	return r.getByID(ctx, `SELECT `+productColumns+` FROM products WHERE id = $1 AND tenant_id = $2`, id)
+}
 
+// getByID runs a query for a single product by ID
+func (r *productRepository) getByID(ctx context.Context, query string, id uuid.UUID) (*entities.Product, error) {
+	product, err := scanProduct(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -67,18 +158,9 @@ func (r *productRepository) GetByID(ctx context.Context, id uuid.UUID) (*entitie
 
 // GetBySKU retrieves a product by SKU
 func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*entities.Product, error) {
-	query := `
-		SELECT id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at
-		FROM products WHERE sku = $1
-	`
-
-	product := &entities.Product{}
-	err := r.db.QueryRowContext(ctx, query, sku).Scan(
-		&product.ID, &product.SKU, &product.Name, &product.Description, &product.CategoryID,
-		&product.Price, &product.Cost, &product.Stock, &product.MinStock, &product.MaxStock,
-		&product.Status, &product.CreatedAt, &product.UpdatedAt,
-	)
+	query := `SELECT ` + productColumns + ` FROM products WHERE sku = $1 AND tenant_id = $2 AND deleted_at IS NULL`
 
+	product, err := scanProduct(r.db.Conn(ctx).QueryRowContext(ctx, query, sku, entities.TenantIDFromContext(ctx)))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -89,159 +171,632 @@ func (r *productRepository) GetBySKU(ctx context.Context, sku string) (*entities
 	return product, nil
 }
 
-// GetAll retrieves all products with pagination
-func (r *productRepository) GetAll(ctx context.Context, limit, offset int) ([]*entities.Product, error) {
+// GetBySKUs retrieves the products with the given SKUs
+func (r *productRepository) GetBySKUs(ctx context.Context, skus []string) ([]*entities.Product, error) {
+	query := `SELECT ` + productColumns + ` FROM products WHERE sku = ANY($1) AND tenant_id = $2 AND deleted_at IS NULL`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, pq.StringArray(skus), entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products by SKUs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanProducts(rows)
+}
+
+// GetAll retrieves all products with pagination, including soft-deleted ones when asked to
+func (r *productRepository) GetAll(ctx context.Context, limit, offset int, includeDeleted bool) ([]*entities.Product, error) {
 	query := `
-		SELECT id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at
-		FROM products ORDER BY created_at DESC LIMIT $1 OFFSET $2
+		SELECT ` + productColumns + ` FROM products
+		WHERE tenant_id = $3 AND ($4 OR deleted_at IS NULL) ORDER BY created_at DESC LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset, entities.TenantIDFromContext(ctx), includeDeleted)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all products: %w", err)
 	}
 	defer rows.Close()
 
-	var products []*entities.Product
+	return scanProducts(rows)
+}
+
+// Count counts the products listed by GetAll
+func (r *productRepository) Count(ctx context.Context, includeDeleted bool) (int, error) {
+	query := `SELECT COUNT(*) FROM products WHERE tenant_id = $1 AND ($2 OR deleted_at IS NULL)`
+
+	var count int
+	if err := r.db.Conn(ctx).QueryRowContext(ctx, query, entities.TenantIDFromContext(ctx), includeDeleted).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count products: %w", err)
+	}
+
+	return count, nil
+}
+
+// classFilter selects the products whose stored classification is in the classes $1 and $2
+const classFilter = `
+	WHERE tenant_id = $3 AND deleted_at IS NULL AND id IN (
+		SELECT product_id FROM product_classifications
+		WHERE tenant_id = $3 AND ($1::text = '' OR abc_class = $1) AND ($2::text = '' OR xyz_class = $2)
+	)
+`
+
+// GetByClass retrieves the products in the given classes of the stored classification with pagination
+func (r *productRepository) GetByClass(ctx context.Context, abcClass, xyzClass string, limit, offset int) ([]*entities.Product, error) {
+	query := `SELECT ` + productColumns + ` FROM products ` + classFilter + ` ORDER BY created_at DESC LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, abcClass, xyzClass, entities.TenantIDFromContext(ctx), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products by class: %w", err)
+	}
+	defer rows.Close()
+
+	return scanProducts(rows)
+}
+
+// CountByClass counts the products listed by GetByClass
+func (r *productRepository) CountByClass(ctx context.Context, abcClass, xyzClass string) (int, error) {
+	query := `SELECT COUNT(*) FROM products ` + classFilter
+
+	var count int
+	if err := r.db.Conn(ctx).QueryRowContext(ctx, query, abcClass, xyzClass, entities.TenantIDFromContext(ctx)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count products by class: %w", err)
+	}
+
+	return count, nil
+}
+
+// ForEach streams products ordered by SKU to fn as they are read from the cursor.
+// A nil ids slice selects every product. Iteration stops at the first error returned by fn.
+func (r *productRepository) ForEach(ctx context.Context, ids []uuid.UUID, fn func(*entities.Product) error) error {
+	query := `SELECT ` + productColumns + ` FROM products WHERE tenant_id = $1 AND deleted_at IS NULL ORDER BY sku ASC`
+	args := []interface{}{entities.TenantIDFromContext(ctx)}
+	if ids != nil {
+		query = `SELECT ` + productColumns + ` FROM products WHERE tenant_id = $1 AND deleted_at IS NULL AND id = ANY($2::uuid[]) ORDER BY sku ASC`
+		args = append(args, uuidArray(ids))
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query products: %w", err)
+	}
+	defer rows.Close()
+
 	for rows.Next() {
-		product := &entities.Product{}
-		err := rows.Scan(
-			&product.ID, &product.SKU, &product.Name, &product.Description, &product.CategoryID,
-			&product.Price, &product.Cost, &product.Stock, &product.MinStock, &product.MaxStock,
-			&product.Status, &product.CreatedAt, &product.UpdatedAt,
-		)
+		product, err := scanProduct(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan product: %w", err)
+			return fmt.Errorf("failed to scan product: %w", err)
+		}
+		if err := fn(product); err != nil {
+			return err
 		}
-		products = append(products, product)
 	}
 
-	return products, nil
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate products: %w", err)
+	}
+
+	return nil
+}
+
+// GetForUpdate retrieves and locks the products with the given IDs until the surrounding transaction ends.
+// Rows are locked in ID order so concurrent callers always acquire their locks in the same sequence.
+func (r *productRepository) GetForUpdate(ctx context.Context, ids []uuid.UUID) ([]*entities.Product, error) {
+	query := `SELECT ` + productColumns + ` FROM products WHERE id = ANY($1::uuid[]) AND tenant_id = $2 AND deleted_at IS NULL ORDER BY id FOR UPDATE`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, uuidArray(ids), entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock products: %w", err)
+	}
+	defer rows.Close()
+
+	return scanProducts(rows)
 }
 
 // GetByCategory retrieves products by category with pagination
 func (r *productRepository) GetByCategory(ctx context.Context, categoryID uuid.UUID, limit, offset int) ([]*entities.Product, error) {
-	query := `
-		SELECT id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at
-		FROM products WHERE category_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3
-	`
+	query := `SELECT ` + productColumns + ` FROM products WHERE category_id = $1 AND tenant_id = $4 AND deleted_at IS NULL ORDER BY created_at DESC LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, categoryID, limit, offset)
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, categoryID, limit, offset, entities.TenantIDFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get products by category: %w", err)
 	}
 	defer rows.Close()
 
-	var products []*entities.Product
-	for rows.Next() {
-		product := &entities.Product{}
-		err := rows.Scan(
-			&product.ID, &product.SKU, &product.Name, &product.Description, &product.CategoryID,
-			&product.Price, &product.Cost, &product.Stock, &product.MinStock, &product.MaxStock,
-			&product.Status, &product.CreatedAt, &product.UpdatedAt,
+	return scanProducts(rows)
+}
+
+// GetVariants retrieves the variants of a parent product
+func (r *productRepository) GetVariants(ctx context.Context, parentID uuid.UUID) ([]*entities.Product, error) {
+	query := `SELECT ` + productColumns + ` FROM products WHERE parent_id = $1 AND tenant_id = $2 AND deleted_at IS NULL ORDER BY sku ASC`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, parentID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product variants: %w", err)
+	}
+	defer rows.Close()
+
+	return scanProducts(rows)
+}
+
+// CreateVariants stores the parent's variant axes and inserts its new variants in a single transaction
+func (r *productRepository) CreateVariants(ctx context.Context, parent *entities.Product, variants []*entities.Product) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			UPDATE products SET variant_axes = $2, updated_at = $3, version = version + 1
+			WHERE id = $1 AND version = $4 AND tenant_id = $5 AND deleted_at IS NULL
+		`
+		result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+			parent.ID, variantAxes(parent), parent.UpdatedAt, parent.Version, entities.TenantIDFromContext(ctx),
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan product: %w", err)
+			return fmt.Errorf("failed to update variant axes: %w", err)
 		}
-		products = append(products, product)
+		if err := checkVersionedUpdate(result); err != nil {
+			return err
+		}
+		parent.Version++
+
+		for _, variant := range variants {
+			if err := r.Create(ctx, variant); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateVariantPolicy applies a price and stock policy to every variant of a parent product
+func (r *productRepository) UpdateVariantPolicy(ctx context.Context, parentID uuid.UUID, policy entities.VariantPolicy) error {
+	query := `
+		UPDATE products
+		SET price = COALESCE($2, price), cost = COALESCE($3, cost),
+		    min_stock = COALESCE($4, min_stock), max_stock = COALESCE($5, max_stock), updated_at = NOW(),
+		    version = version + 1
+		WHERE parent_id = $1 AND tenant_id = $6 AND deleted_at IS NULL
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		parentID, policy.Price, policy.Cost, policy.MinStock, policy.MaxStock, entities.TenantIDFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update variant policy: %w", err)
 	}
 
-	return products, nil
+	return nil
 }
 
-// Update updates a product
+// Update updates the catalog fields of a product if it is still at the version it was read at.
+// Stock is not written; it only changes through UpdateStock.
 func (r *productRepository) Update(ctx context.Context, product *entities.Product) error {
 	query := `
-		UPDATE products 
-		SET sku = $2, name = $3, description = $4, category_id = $5, price = $6, cost = $7, 
-		    stock = $8, min_stock = $9, max_stock = $10, status = $11, updated_at = $12
-		WHERE id = $1
+		UPDATE products
+		SET sku = $2, name = $3, description = $4, category_id = $5, price = $6, cost = $7,
+		    min_stock = $8, max_stock = $9, status = $10, updated_at = $11, version = version + 1
+		WHERE id = $1 AND version = $12 AND tenant_id = $13 AND deleted_at IS NULL
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
 		product.ID, product.SKU, product.Name, product.Description, product.CategoryID,
-		product.Price, product.Cost, product.Stock, product.MinStock, product.MaxStock,
-		product.Status, product.UpdatedAt,
+		product.Price, product.Cost, product.MinStock, product.MaxStock,
+		product.Status, product.UpdatedAt, product.Version, entities.TenantIDFromContext(ctx),
 	)
 
 	if err != nil {
+		if isUniqueViolation(err, "idx_products_tenant_id_sku") {
+			return entities.ErrDuplicateSKU
+		}
 		return fmt.Errorf("failed to update product: %w", err)
 	}
 
+	if err := checkVersionedUpdate(result); err != nil {
+		return err
+	}
+
+	product.Version++
 	return nil
 }
 
-// Delete deletes a product
-func (r *productRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM products WHERE id = $1`
+// checkVersionedUpdate returns ErrVersionMismatch when a statement guarded by a version matched no row
+func checkVersionedUpdate(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check updated rows: %w", err)
+	}
+	if affected == 0 {
+		return entities.ErrVersionMismatch
+	}
+	return nil
+}
 
-	_, err := r.db.ExecContext(ctx, query, id)
+// UpsertBySKU inserts the products into the tenant of the context in a single statement, updating the
+// catalog fields of those whose SKU already exists in the tenant. Stock, status and variant data of
+// existing products are left untouched.
+func (r *productRepository) UpsertBySKU(ctx context.Context, products []*entities.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	const columns = 12
+	tenantID := entities.TenantIDFromContext(ctx)
+	values := make([]string, len(products))
+	args := make([]interface{}, 0, len(products)*columns)
+	for i, product := range products {
+		placeholders := make([]string, columns)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", i*columns+j+1)
+		}
+		values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+		args = append(args,
+			product.ID, product.SKU, product.Name, product.Description, product.CategoryID,
+			product.Price, product.Cost, product.MinStock, product.MaxStock, product.CreatedAt, product.UpdatedAt, tenantID,
+		)
+	}
+
+	query := `
+		INSERT INTO products (id, sku, name, description, category_id, price, cost, min_stock, max_stock, created_at, updated_at, tenant_id)
+		VALUES ` + strings.Join(values, ", ") + `
+		ON CONFLICT (tenant_id, sku) WHERE deleted_at IS NULL DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, category_id = EXCLUDED.category_id,
+		    price = EXCLUDED.price, cost = EXCLUDED.cost, min_stock = EXCLUDED.min_stock, max_stock = EXCLUDED.max_stock,
+		    updated_at = EXCLUDED.updated_at, version = products.version + 1
+	`
+
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to upsert products: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStock updates only the stock of a product
+func (r *productRepository) UpdateStock(ctx context.Context, id uuid.UUID, stock int) error {
+	query := `UPDATE products SET stock = $2, updated_at = NOW() WHERE id = $1 AND tenant_id = $3`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, id, stock, entities.TenantIDFromContext(ctx))
 	if err != nil {
-		return fmt.Errorf("failed to delete product: %w", err)
+		return fmt.Errorf("failed to update stock: %w", err)
 	}
 
 	return nil
 }
 
+// UpdateMinStock updates only the minimum stock threshold of a product. It is a catalog change, so it bumps the
+// version and an edit based on the previous version is rejected instead of reverting it.
+func (r *productRepository) UpdateMinStock(ctx context.Context, id uuid.UUID, minStock int) error {
+	query := `UPDATE products SET min_stock = $2, updated_at = NOW(), version = version + 1 WHERE id = $1 AND tenant_id = $3`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, id, minStock, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update min stock: %w", err)
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a product and its variants if the product is still at the given version
+func (r *productRepository) Delete(ctx context.Context, id uuid.UUID, version int, deletedAt time.Time) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		tenantID := entities.TenantIDFromContext(ctx)
+		query := `
+			UPDATE products SET deleted_at = $3, version = version + 1
+			WHERE id = $1 AND version = $2 AND tenant_id = $4 AND deleted_at IS NULL
+		`
+		result, err := r.db.Conn(ctx).ExecContext(ctx, query, id, version, deletedAt, tenantID)
+		if err != nil {
+			return fmt.Errorf("failed to delete product: %w", err)
+		}
+		if err := checkVersionedUpdate(result); err != nil {
+			return err
+		}
+
+		query = `
+			UPDATE products SET deleted_at = $2, version = version + 1
+			WHERE parent_id = $1 AND tenant_id = $3 AND deleted_at IS NULL
+		`
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, id, deletedAt, tenantID); err != nil {
+			return fmt.Errorf("failed to delete product variants: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Restore undeletes a soft-deleted product if it is still at the given version, together with the
+// variants deleted along with it, and returns the IDs of those variants
+func (r *productRepository) Restore(ctx context.Context, id uuid.UUID, version int) ([]uuid.UUID, error) {
+	var variantIDs []uuid.UUID
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		tenantID := entities.TenantIDFromContext(ctx)
+		var deletedAt time.Time
+		query := `SELECT deleted_at FROM products WHERE id = $1 AND version = $2 AND tenant_id = $3 AND deleted_at IS NOT NULL FOR UPDATE`
+		err := r.db.Conn(ctx).QueryRowContext(ctx, query, id, version, tenantID).Scan(&deletedAt)
+		if err == sql.ErrNoRows {
+			return entities.ErrVersionMismatch
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock deleted product: %w", err)
+		}
+
+		query = `UPDATE products SET deleted_at = NULL, version = version + 1 WHERE id = $1 AND tenant_id = $2`
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, id, tenantID); err != nil {
+			if isUniqueViolation(err, "idx_products_tenant_id_sku") {
+				return entities.ErrDuplicateSKU
+			}
+			return fmt.Errorf("failed to restore product: %w", err)
+		}
+
+		query = `
+			UPDATE products SET deleted_at = NULL, version = version + 1
+			WHERE parent_id = $1 AND tenant_id = $2 AND deleted_at = $3
+			RETURNING id
+		`
+		rows, err := r.db.Conn(ctx).QueryContext(ctx, query, id, tenantID, deletedAt)
+		if err != nil {
+			if isUniqueViolation(err, "idx_products_tenant_id_sku") {
+				return entities.ErrDuplicateSKU
+			}
+			return fmt.Errorf("failed to restore product variants: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var variantID uuid.UUID
+			if err := rows.Scan(&variantID); err != nil {
+				return fmt.Errorf("failed to scan restored variant: %w", err)
+			}
+			variantIDs = append(variantIDs, variantID)
+		}
+
+		if err := rows.Err(); err != nil {
+			if isUniqueViolation(err, "idx_products_tenant_id_sku") {
+				return entities.ErrDuplicateSKU
+			}
+			return fmt.Errorf("failed to restore product variants: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variantIDs, nil
+}
+
+// PurgeDeleted hard-deletes the products soft-deleted before the given time that have no ledger history:
+// no transactions, purchase order lines or reservations. A variant parent is purged once its variants are gone.
+func (r *productRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `
+		DELETE FROM products p
+		WHERE p.tenant_id = $2 AND p.deleted_at < $1
+		  AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.product_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM purchase_order_lines l WHERE l.product_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM reservations r WHERE r.product_id = p.id)
+		  AND NOT EXISTS (SELECT 1 FROM products v WHERE v.parent_id = p.id)
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, deletedBefore, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted products: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
 // GetLowStockProducts retrieves products with low stock
 func (r *productRepository) GetLowStockProducts(ctx context.Context) ([]*entities.Product, error) {
 	query := `
-		SELECT id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at
-		FROM products WHERE stock <= min_stock AND status = 'active' ORDER BY stock ASC
+		SELECT ` + productColumns + `
+		FROM products
+		WHERE tenant_id = $1 AND stock <= min_stock AND status = 'active' AND cardinality(variant_axes) = 0 AND deleted_at IS NULL
+		ORDER BY stock ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get low stock products: %w", err)
 	}
 	defer rows.Close()
 
-	var products []*entities.Product
-	for rows.Next() {
-		product := &entities.Product{}
-		err := rows.Scan(
-			&product.ID, &product.SKU, &product.Name, &product.Description, &product.CategoryID,
-			&product.Price, &product.Cost, &product.Stock, &product.MinStock, &product.MaxStock,
-			&product.Status, &product.CreatedAt, &product.UpdatedAt,
-		)
+	return scanProducts(rows)
+}
+
+// GetStockedProducts retrieves all active products that hold stock, i.e. everything except variant parents
+func (r *productRepository) GetStockedProducts(ctx context.Context) ([]*entities.Product, error) {
+	query := `
+		SELECT ` + productColumns + `
+		FROM products
+		WHERE tenant_id = $1 AND status = 'active' AND cardinality(variant_axes) = 0 AND deleted_at IS NULL ORDER BY sku ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocked products: %w", err)
+	}
+	defer rows.Close()
+
+	return scanProducts(rows)
+}
+
+// searchHeadlineOptions configure the highlighted name and the description snippet of search hits
+var (
+	searchNameOptions    = "HighlightAll=true, StartSel=" + entities.SearchHighlightStart + ", StopSel=" + entities.SearchHighlightStop
+	searchSnippetOptions = "MaxFragments=2, MinWords=5, MaxWords=20, FragmentDelimiter=\" ... \", StartSel=" +
+		entities.SearchHighlightStart + ", StopSel=" + entities.SearchHighlightStop
+)
+
+// searchMatches selects the products matching a search as the CTE "matches", with the parsed query as "q".
+// Its arguments are those of searchArgs; queries using it number theirs from $8.
+const searchMatches = `
+	WITH q AS (
+		SELECT websearch_to_tsquery('english', $1) AS tsq, lower($1) AS term, $2::text AS prefix
+	),
+	matches AS (
+		SELECT p.*
+		FROM products p, q
+		WHERE p.tenant_id = $3 AND p.status = ANY($7::text[]) AND ($4 OR p.deleted_at IS NULL)
+		  AND (cardinality($5::uuid[]) = 0 OR p.category_id = ANY($5::uuid[]))
+		  AND (NOT $6 OR p.stock > 0)
+		  AND ($1 = '' OR p.search_vector @@ q.tsq OR $1 <% p.name OR lower(p.sku) LIKE q.prefix)
+	)`
+
+// searchArgs returns the arguments of searchMatches
+func searchArgs(ctx context.Context, search *entities.ProductSearch) []interface{} {
+	return []interface{}{
+		search.Query, likePrefix(strings.ToLower(search.Query)), entities.TenantIDFromContext(ctx), search.IncludeDeleted,
+		uuidArray(search.CategoryIDs), search.InStockOnly, pq.StringArray(search.Statuses),
+	}
+}
+
+// Search counts the hits and their facets and reads the page of hits from one snapshot, so that the counts
+// agree with the hits
+func (r *productRepository) Search(ctx context.Context, search *entities.ProductSearch) (*entities.ProductSearchResult, error) {
+	result := &entities.ProductSearchResult{}
+	err := r.db.WithinSnapshot(ctx, func(ctx context.Context) error {
+		if err := r.countSearch(ctx, search, result); err != nil {
+			return err
+		}
+
+		hits, err := r.searchHits(ctx, search)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan product: %w", err)
+			return err
 		}
-		products = append(products, product)
+		result.Hits = hits
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return products, nil
+	return result, nil
 }
 
-// Search searches for products
-func (r *productRepository) Search(ctx context.Context, query string, limit, offset int) ([]*entities.Product, error) {
-	searchQuery := `
-		SELECT id, sku, name, description, category_id, price, cost, stock, min_stock, max_stock, status, created_at, updated_at
-		FROM products 
-		WHERE (name ILIKE $1 OR description ILIKE $1 OR sku ILIKE $1) AND status = 'active'
-		ORDER BY name ASC LIMIT $2 OFFSET $3
+// countSearch counts the hits of a search, and their facets when asked for
+func (r *productRepository) countSearch(ctx context.Context, search *entities.ProductSearch, result *entities.ProductSearchResult) error {
+	query := searchMatches + `
+		SELECT 'total', '', COUNT(*) FROM matches
+		UNION ALL
+		SELECT 'category', category_id::text, COUNT(*) FROM matches WHERE $8 GROUP BY category_id
+		UNION ALL
+		SELECT 'status', status, COUNT(*) FROM matches WHERE $8 GROUP BY status
+		UNION ALL
+		SELECT 'stock', state, COUNT(*) FROM (
+			SELECT CASE WHEN stock <= 0 THEN 'out' WHEN stock <= min_stock THEN 'low' ELSE 'in_stock' END AS state
+			FROM matches WHERE $8
+		) states GROUP BY state
+		UNION ALL
+		SELECT 'price', bucket::text, COUNT(*) FROM (
+			SELECT width_bucket(price, $9::numeric[]) AS bucket FROM matches WHERE $8 AND cardinality($9::numeric[]) > 0
+		) buckets GROUP BY bucket
 	`
 
-	searchTerm := "%" + strings.ToLower(query) + "%"
-	rows, err := r.db.QueryContext(ctx, searchQuery, searchTerm, limit, offset)
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, append(searchArgs(ctx, search), search.Facets, pq.Array(search.PriceBuckets))...)
+	if err != nil {
+		return fmt.Errorf("failed to count search hits: %w", err)
+	}
+	defer rows.Close()
+
+	var facets *entities.ProductFacets
+	if search.Facets {
+		facets = &entities.ProductFacets{
+			Categories:   make(map[uuid.UUID]int),
+			Statuses:     make(map[string]int),
+			StockStates:  make(map[string]int),
+			PriceBuckets: make([]int, len(search.PriceBuckets)+1),
+		}
+	}
+
+	for rows.Next() {
+		var facet, value string
+		var count int
+		if err := rows.Scan(&facet, &value, &count); err != nil {
+			return fmt.Errorf("failed to scan search facet: %w", err)
+		}
+
+		switch facet {
+		case "total":
+			result.Total = count
+		case "category":
+			categoryID, err := uuid.Parse(value)
+			if err != nil {
+				return fmt.Errorf("failed to parse category facet: %w", err)
+			}
+			facets.Categories[categoryID] = count
+		case "status":
+			facets.Statuses[value] = count
+		case "stock":
+			facets.StockStates[value] = count
+		case "price":
+			// Bucket 0 holds prices below the first bound, which start at 0
+			bucket, _ := strconv.Atoi(value)
+			facets.PriceBuckets[bucket] = count
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate search facets: %w", err)
+	}
+
+	result.Facets = facets
+	return nil
+}
+
+// searchHits reads a page of search hits. Hits are ranked by the weighted full-text rank, the trigram
+// similarity of the name and a boost for SKU matches. Only the page is highlighted, as headlines are costly.
+func (r *productRepository) searchHits(ctx context.Context, search *entities.ProductSearch) ([]*entities.ProductSearchHit, error) {
+	query := searchMatches + `
+		SELECT ` + productColumns + `, rank,
+			CASE WHEN $1 = '' THEN name ELSE ts_headline('english', name, q.tsq, $10) END,
+			CASE WHEN $1 = '' THEN '' ELSE ts_headline('english', description, q.tsq, $11) END
+		FROM (
+			SELECT m.*,
+				CASE WHEN $1 = '' THEN 0 ELSE
+					ts_rank_cd(m.search_vector, q.tsq) + word_similarity($1, m.name) +
+					CASE WHEN lower(m.sku) = q.term THEN 2 WHEN lower(m.sku) LIKE q.prefix THEN 1 ELSE 0 END
+				END AS rank
+			FROM matches m, q
+			ORDER BY rank DESC, m.name ASC
+			LIMIT $8 OFFSET $9
+		) hits, q
+		ORDER BY rank DESC, name ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query,
+		append(searchArgs(ctx, search), search.Limit, search.Offset, searchNameOptions, searchSnippetOptions)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search products: %w", err)
 	}
 	defer rows.Close()
 
-	var products []*entities.Product
+	var hits []*entities.ProductSearchHit
 	for rows.Next() {
-		product := &entities.Product{}
-		err := rows.Scan(
-			&product.ID, &product.SKU, &product.Name, &product.Description, &product.CategoryID,
-			&product.Price, &product.Cost, &product.Stock, &product.MinStock, &product.MaxStock,
-			&product.Status, &product.CreatedAt, &product.UpdatedAt,
-		)
+		hit := &entities.ProductSearchHit{}
+		hit.Product, err = scanProduct(extraColumns{rows, []interface{}{&hit.Rank, &hit.Name, &hit.DescriptionSnippet}})
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan product: %w", err)
+			return nil, fmt.Errorf("failed to scan search hit: %w", err)
 		}
-		products = append(products, product)
+		hits = append(hits, hit)
 	}
 
-	return products, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate search hits: %w", err)
+	}
+
+	return hits, nil
+}
+
+// extraColumns scans rows selecting further columns after those of a scan function into extra
+type extraColumns struct {
+	rows  *sql.Rows
+	extra []interface{}
+}
+
+// Scan scans the row into dest followed by extra
+func (e extraColumns) Scan(dest ...interface{}) error {
+	return e.rows.Scan(append(dest, e.extra...)...)
+}
+
+// likePrefix returns a LIKE pattern matching values starting with prefix
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
 }
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+// purchaseOrderColumns is the column list shared by every purchase order query, in scan order
+const purchaseOrderColumns = `id, number, supplier_id, status, expected_at, COALESCE(notes, ''), created_by, created_at, updated_at`
+
+type purchaseOrderRepository struct {
+	db *database.DB
+}
+
+// NewPurchaseOrderRepository creates a new purchase order repository
+func NewPurchaseOrderRepository(db *database.DB) repositories.PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: db}
+}
+
+// scanPurchaseOrder scans a row selected with purchaseOrderColumns into a purchase order
+func scanPurchaseOrder(row rowScanner) (*entities.PurchaseOrder, error) {
+	po := &entities.PurchaseOrder{}
+	err := row.Scan(
+		&po.ID, &po.Number, &po.SupplierID, &po.Status, &po.ExpectedAt,
+		&po.Notes, &po.CreatedBy, &po.CreatedAt, &po.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return po, nil
+}
+
+// Create creates a purchase order together with its lines in the tenant of the context
+func (r *purchaseOrderRepository) Create(ctx context.Context, po *entities.PurchaseOrder) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO purchase_orders (id, number, supplier_id, status, expected_at, notes, created_by, created_at, updated_at, tenant_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`
+
+		_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+			po.ID, po.Number, po.SupplierID, po.Status, po.ExpectedAt,
+			po.Notes, po.CreatedBy, po.CreatedAt, po.UpdatedAt, entities.TenantIDFromContext(ctx),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create purchase order: %w", err)
+		}
+
+		lineQuery := `
+			INSERT INTO purchase_order_lines (id, purchase_order_id, product_id, quantity, received_quantity, unit_cost)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`
+
+		for _, line := range po.Lines {
+			_, err := r.db.Conn(ctx).ExecContext(ctx, lineQuery,
+				line.ID, line.PurchaseOrderID, line.ProductID, line.Quantity, line.ReceivedQuantity, line.UnitCost,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create purchase order line: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetByID retrieves a purchase order with its lines; the lines are only read once the order is found in the tenant
+func (r *purchaseOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PurchaseOrder, error) {
+	query := `SELECT ` + purchaseOrderColumns + ` FROM purchase_orders WHERE id = $1 AND tenant_id = $2`
+
+	po, err := scanPurchaseOrder(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get purchase order by ID: %w", err)
+	}
+
+	lineQuery := `
+		SELECT id, purchase_order_id, product_id, quantity, received_quantity, unit_cost
+		FROM purchase_order_lines WHERE purchase_order_id = $1 ORDER BY id
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, lineQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line := &entities.PurchaseOrderLine{}
+		err := rows.Scan(&line.ID, &line.PurchaseOrderID, &line.ProductID, &line.Quantity, &line.ReceivedQuantity, &line.UnitCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan purchase order line: %w", err)
+		}
+		po.Lines = append(po.Lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate purchase order lines: %w", err)
+	}
+
+	return po, nil
+}
+
+// GetAll retrieves purchase orders with pagination, optionally filtered by status. Lines are not loaded.
+func (r *purchaseOrderRepository) GetAll(ctx context.Context, status string, limit, offset int) ([]*entities.PurchaseOrder, error) {
+	query := `
+		SELECT ` + purchaseOrderColumns + ` FROM purchase_orders
+		WHERE ($1 = '' OR status = $1) AND tenant_id = $4 ORDER BY created_at DESC LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, status, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase orders: %w", err)
+	}
+	defer rows.Close()
+
+	var purchaseOrders []*entities.PurchaseOrder
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan purchase order: %w", err)
+		}
+		purchaseOrders = append(purchaseOrders, po)
+	}
+
+	return purchaseOrders, rows.Err()
+}
+
+// UpdateStatus updates the status of a purchase order
+func (r *purchaseOrderRepository) UpdateStatus(ctx context.Context, po *entities.PurchaseOrder) error {
+	query := `UPDATE purchase_orders SET status = $2, updated_at = $3 WHERE id = $1 AND tenant_id = $4`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, po.ID, po.Status, po.UpdatedAt, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update purchase order: %w", err)
+	}
+
+	return nil
+}
+
+// GetOpenQuantities sums the quantity still to be received per product on draft and open purchase orders
+func (r *purchaseOrderRepository) GetOpenQuantities(ctx context.Context) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT l.product_id, SUM(GREATEST(l.quantity - l.received_quantity, 0))
+		FROM purchase_order_lines l JOIN purchase_orders po ON po.id = l.purchase_order_id
+		WHERE po.tenant_id = $1 AND po.status IN ('draft', 'open')
+		GROUP BY l.product_id
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open purchase quantities: %w", err)
+	}
+	defer rows.Close()
+
+	return scanQuantities(rows)
+}
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+// reconciliationRunColumns is the column list shared by every reconciliation run query, in scan order
+const reconciliationRunColumns = `id, repair, status, products_checked, discrepancies, repaired, COALESCE(error, ''),
+		created_by, started_at, finished_at`
+
+type reconciliationRepository struct {
+	db *database.DB
+}
+
+// NewReconciliationRepository creates a new reconciliation repository
+func NewReconciliationRepository(db *database.DB) repositories.ReconciliationRepository {
+	return &reconciliationRepository{db: db}
+}
+
+// scanReconciliationRun scans a row selected with reconciliationRunColumns into a run
+func scanReconciliationRun(row rowScanner) (*entities.ReconciliationRun, error) {
+	run := &entities.ReconciliationRun{}
+	err := row.Scan(
+		&run.ID, &run.Repair, &run.Status, &run.ProductsChecked, &run.Discrepancies, &run.Repaired, &run.Error,
+		&run.CreatedBy, &run.StartedAt, &run.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// GetLedgerBalances returns the stored stock and the ledger stock of every product of the tenant.
+// Variant parents are left out: their stock is the sum of their variants and they have no ledger.
+func (r *reconciliationRepository) GetLedgerBalances(ctx context.Context) ([]*entities.LedgerBalance, error) {
+	query := `
+		SELECT p.id, p.sku, p.name, p.stock, COALESCE(l.quantity, 0), COALESCE(l.transactions, 0), l.last_at
+		FROM products p
+		LEFT JOIN (
+			SELECT t.product_id, SUM(` + signedQuantity + `) AS quantity, COUNT(*) AS transactions, MAX(t.created_at) AS last_at
+			FROM transactions t WHERE t.tenant_id = $1 GROUP BY t.product_id
+		) l ON l.product_id = p.id
+		WHERE p.tenant_id = $1 AND cardinality(p.variant_axes) = 0
+		ORDER BY p.sku ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger balances: %w", err)
+	}
+	defer rows.Close()
+
+	var balances []*entities.LedgerBalance
+	for rows.Next() {
+		balance := &entities.LedgerBalance{}
+		err := rows.Scan(
+			&balance.ProductID, &balance.SKU, &balance.Name, &balance.Stock,
+			&balance.LedgerStock, &balance.Transactions, &balance.LastTransactionAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ledger balance: %w", err)
+		}
+		balances = append(balances, balance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate ledger balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// PostCorrection books an adjustment that brings the ledger of a product in line with its stored stock.
+// The product row is locked and the difference recomputed, so movements since the balances were read
+// are taken into account. It returns nil when the product is already balanced.
+func (r *reconciliationRepository) PostCorrection(ctx context.Context, productID uuid.UUID, reference, notes string, createdBy uuid.UUID) (*entities.Transaction, error) {
+	var adjustment *entities.Transaction
+	tenantID := entities.TenantIDFromContext(ctx)
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		var stock int
+		lockQuery := `SELECT stock FROM products WHERE id = $1 AND tenant_id = $2 FOR UPDATE`
+		if err := r.db.Conn(ctx).QueryRowContext(ctx, lockQuery, productID, tenantID).Scan(&stock); err != nil {
+			if err == sql.ErrNoRows {
+				return entities.ErrProductNotFound
+			}
+			return fmt.Errorf("failed to lock product: %w", err)
+		}
+
+		var ledger int
+		ledgerQuery := `SELECT COALESCE(SUM(` + signedQuantity + `), 0) FROM transactions t WHERE t.product_id = $1 AND t.tenant_id = $2`
+		if err := r.db.Conn(ctx).QueryRowContext(ctx, ledgerQuery, productID, tenantID).Scan(&ledger); err != nil {
+			return fmt.Errorf("failed to sum ledger: %w", err)
+		}
+
+		if stock == ledger {
+			return nil
+		}
+
+		adjustment = entities.NewTransaction(productID, entities.TransactionTypeAdjustment, stock-ledger, reference, notes, createdBy)
+		insertQuery := `
+			INSERT INTO transactions (id, product_id, type, quantity, reference, notes, created_by, created_at, tenant_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
+		_, err := r.db.Conn(ctx).ExecContext(ctx, insertQuery,
+			adjustment.ID, adjustment.ProductID, adjustment.Type, adjustment.Quantity,
+			adjustment.Reference, adjustment.Notes, adjustment.CreatedBy, adjustment.CreatedAt, tenantID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to post correcting adjustment: %w", err)
+		}
+		adjustment.TenantID = tenantID
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+// CreateRun stores a reconciliation run together with its items in the tenant of the context
+func (r *reconciliationRepository) CreateRun(ctx context.Context, run *entities.ReconciliationRun) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO reconciliation_runs (id, repair, status, products_checked, discrepancies, repaired, error,
+			                                 created_by, started_at, finished_at, tenant_id)
+			VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, $11)
+		`
+
+		_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+			run.ID, run.Repair, run.Status, run.ProductsChecked, run.Discrepancies, run.Repaired, run.Error,
+			run.CreatedBy, run.StartedAt, run.FinishedAt, entities.TenantIDFromContext(ctx),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create reconciliation run: %w", err)
+		}
+
+		itemQuery := `
+			INSERT INTO reconciliation_items (id, run_id, product_id, sku, name, stock, ledger_stock, difference,
+			                                  transactions, last_transaction_at, adjustment_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`
+
+		for _, item := range run.Items {
+			_, err := r.db.Conn(ctx).ExecContext(ctx, itemQuery,
+				item.ID, item.RunID, item.ProductID, item.SKU, item.Name, item.Stock, item.LedgerStock, item.Difference,
+				item.Transactions, item.LastTransactionAt, item.AdjustmentID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create reconciliation item: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetRunByID retrieves a reconciliation run with its items
+func (r *reconciliationRepository) GetRunByID(ctx context.Context, id uuid.UUID) (*entities.ReconciliationRun, error) {
+	query := `SELECT ` + reconciliationRunColumns + ` FROM reconciliation_runs WHERE id = $1 AND tenant_id = $2`
+
+	run, err := scanReconciliationRun(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get reconciliation run by ID: %w", err)
+	}
+
+	itemQuery := `
+		SELECT id, run_id, product_id, sku, name, stock, ledger_stock, difference, transactions, last_transaction_at, adjustment_id
+		FROM reconciliation_items WHERE run_id = $1 ORDER BY sku ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, itemQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconciliation items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := &entities.ReconciliationItem{}
+		err := rows.Scan(
+			&item.ID, &item.RunID, &item.ProductID, &item.SKU, &item.Name, &item.Stock, &item.LedgerStock,
+			&item.Difference, &item.Transactions, &item.LastTransactionAt, &item.AdjustmentID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reconciliation item: %w", err)
+		}
+		run.Items = append(run.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reconciliation items: %w", err)
+	}
+
+	return run, nil
+}
+
+// GetRuns retrieves reconciliation runs, most recent first, without their items
+func (r *reconciliationRepository) GetRuns(ctx context.Context, limit, offset int) ([]*entities.ReconciliationRun, error) {
+	query := `SELECT ` + reconciliationRunColumns + ` FROM reconciliation_runs WHERE tenant_id = $3 ORDER BY started_at DESC LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconciliation runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*entities.ReconciliationRun
+	for rows.Next() {
+		run, err := scanReconciliationRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reconciliation run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reconciliation runs: %w", err)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+// reservationColumns is the column list shared by every reservation query, in scan order
+const reservationColumns = `id, product_id, quantity, COALESCE(reference, ''), status, expires_at, created_by, created_at, updated_at`
+
+type reservationRepository struct {
+	db *database.DB
+}
+
+// NewReservationRepository creates a new reservation repository
+func NewReservationRepository(db *database.DB) repositories.ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+// scanReservation scans a row selected with reservationColumns into a reservation
+func scanReservation(row rowScanner) (*entities.Reservation, error) {
+	reservation := &entities.Reservation{}
+	err := row.Scan(
+		&reservation.ID, &reservation.ProductID, &reservation.Quantity, &reservation.Reference,
+		&reservation.Status, &reservation.ExpiresAt, &reservation.CreatedBy, &reservation.CreatedAt, &reservation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// Create creates a new reservation in the tenant of the context
+func (r *reservationRepository) Create(ctx context.Context, reservation *entities.Reservation) error {
+	query := `
+		INSERT INTO reservations (id, product_id, quantity, reference, status, expires_at, created_by, created_at, updated_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		reservation.ID, reservation.ProductID, reservation.Quantity, reservation.Reference,
+		reservation.Status, reservation.ExpiresAt, reservation.CreatedBy, reservation.CreatedAt, reservation.UpdatedAt,
+		entities.TenantIDFromContext(ctx),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a reservation by ID
+func (r *reservationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = $1 AND tenant_id = $2`
+
+	reservation, err := scanReservation(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get reservation by ID: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// GetByProductID retrieves the reservations of a product
+func (r *reservationRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*entities.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE product_id = $1 AND tenant_id = $2 ORDER BY created_at DESC`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, productID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations by product: %w", err)
+	}
+	defer rows.Close()
+
+	var reservations []*entities.Reservation
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, rows.Err()
+}
+
+// UpdateStatus updates the status of a reservation
+func (r *reservationRepository) UpdateStatus(ctx context.Context, reservation *entities.Reservation) error {
+	query := `UPDATE reservations SET status = $2, updated_at = $3 WHERE id = $1 AND tenant_id = $4`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, reservation.ID, reservation.Status, reservation.UpdatedAt, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveQuantities sums the quantity held by unexpired active reservations per product
+func (r *reservationRepository) GetActiveQuantities(ctx context.Context) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT product_id, SUM(quantity) FROM reservations
+		WHERE tenant_id = $1 AND status = 'active' AND (expires_at IS NULL OR expires_at > NOW())
+		GROUP BY product_id
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reserved quantities: %w", err)
+	}
+	defer rows.Close()
+
+	return scanQuantities(rows)
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+type roleRepository struct {
+	db *database.DB
+}
+
+// NewRoleRepository creates a new role repository
+func NewRoleRepository(db *database.DB) repositories.RoleRepository {
+	return &roleRepository{db: db}
+}
+
+// GetByUserID retrieves the role assignment of a user in the tenant
+func (r *roleRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.RoleAssignment, error) {
+	query := `SELECT user_id, role, assigned_by, assigned_at FROM user_roles WHERE user_id = $1 AND tenant_id = $2`
+
+	assignment := &entities.RoleAssignment{}
+	err := r.db.Conn(ctx).QueryRowContext(ctx, query, userID, entities.TenantIDFromContext(ctx)).Scan(
+		&assignment.UserID, &assignment.Role, &assignment.AssignedBy, &assignment.AssignedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get role assignment: %w", err)
+	}
+
+	return assignment, nil
+}
+
+// GetAll retrieves all role assignments of the tenant
+func (r *roleRepository) GetAll(ctx context.Context) ([]*entities.RoleAssignment, error) {
+	query := `SELECT user_id, role, assigned_by, assigned_at FROM user_roles WHERE tenant_id = $1 ORDER BY assigned_at DESC`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var assignments []*entities.RoleAssignment
+	for rows.Next() {
+		assignment := &entities.RoleAssignment{}
+		if err := rows.Scan(&assignment.UserID, &assignment.Role, &assignment.AssignedBy, &assignment.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan role assignment: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate role assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// Assign gives a user a role in the tenant, replacing any role the user had there
+func (r *roleRepository) Assign(ctx context.Context, assignment *entities.RoleAssignment) error {
+	query := `
+		INSERT INTO user_roles (user_id, role, assigned_by, assigned_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role, assigned_by = EXCLUDED.assigned_by, assigned_at = EXCLUDED.assigned_at
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		assignment.UserID, assignment.Role, assignment.AssignedBy, assignment.AssignedAt, entities.TenantIDFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke removes the role of a user in the tenant
+func (r *roleRepository) Revoke(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND tenant_id = $2`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query, userID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+// snapshotDateFormat is the format of the date parameters passed to snapshot queries
+const snapshotDateFormat = "2006-01-02"
+
+type stockSnapshotRepository struct {
+	db *database.DB
+}
+
+// NewStockSnapshotRepository creates a new stock snapshot repository
+func NewStockSnapshotRepository(db *database.DB) repositories.StockSnapshotRepository {
+	return &stockSnapshotRepository{db: db}
+}
+
+// CreateSnapshot stores the closing stock of every product of the tenant for the given day (UTC).
+// The closing stock is the sum of the product's signed transactions booked before the day ended, so it
+// follows the ledger even where products.stock has drifted, and past days can be snapshotted later.
+// Existing snapshots for the day are replaced.
+func (r *stockSnapshotRepository) CreateSnapshot(ctx context.Context, day time.Time) (int, error) {
+	query := `
+		INSERT INTO stock_snapshots (snapshot_date, product_id, quantity, tenant_id)
+		SELECT $1::date, p.id, COALESCE((
+			SELECT SUM(` + signedQuantity + `) FROM transactions t
+			WHERE t.product_id = p.id AND t.created_at < (($1::date + 1)::timestamp AT TIME ZONE 'UTC')
+		), 0), p.tenant_id
+		FROM products p
+		WHERE p.tenant_id = $2 AND cardinality(p.variant_axes) = 0 AND p.created_at < (($1::date + 1)::timestamp AT TIME ZONE 'UTC')
+		ON CONFLICT (snapshot_date, product_id) DO UPDATE SET quantity = EXCLUDED.quantity, created_at = NOW()
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, day.UTC().Format(snapshotDateFormat), entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create stock snapshot: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count stock snapshot rows: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// GetLatestSnapshotDate returns the most recent snapshot day of the tenant, or nil when there are none
+func (r *stockSnapshotRepository) GetLatestSnapshotDate(ctx context.Context) (*time.Time, error) {
+	query := `SELECT MAX(snapshot_date) FROM stock_snapshots WHERE tenant_id = $1`
+
+	var day sql.NullTime
+	if err := r.db.Conn(ctx).QueryRowContext(ctx, query, entities.TenantIDFromContext(ctx)).Scan(&day); err != nil {
+		return nil, fmt.Errorf("failed to get latest snapshot date: %w", err)
+	}
+
+	if !day.Valid {
+		return nil, nil
+	}
+
+	return &day.Time, nil
+}
+
+// GetStockAsOf reconstructs the stock of every product at the given time from the ledger.
+// It starts from the closest snapshot before the time and rolls the ledger forward,
+// or from the first snapshot after it and rolls the ledger back. Without snapshots
+// it sums the ledger up to the time. Variant parents are left out since their
+// stock is the sum of their variants.
+func (r *stockSnapshotRepository) GetStockAsOf(ctx context.Context, at time.Time) ([]*entities.StockPosition, error) {
+	query := `
+		WITH prev AS (
+			SELECT MAX(snapshot_date) AS day FROM stock_snapshots
+			WHERE tenant_id = $2 AND ((snapshot_date + 1)::timestamp AT TIME ZONE 'UTC') <= $1
+		), next AS (
+			SELECT MIN(snapshot_date) AS day FROM stock_snapshots
+			WHERE tenant_id = $2 AND ((snapshot_date + 1)::timestamp AT TIME ZONE 'UTC') > $1
+		)
+		SELECT p.id, p.sku, p.name, p.category_id, COALESCE(c.name, ''), p.cost,
+		       CASE
+		           WHEN ps.product_id IS NOT NULL THEN ps.quantity + COALESCE((
+		               SELECT SUM(` + signedQuantity + `) FROM transactions t
+		               WHERE t.product_id = p.id
+		                 AND t.created_at >= ((ps.snapshot_date + 1)::timestamp AT TIME ZONE 'UTC') AND t.created_at < $1
+		           ), 0)
+		           WHEN ns.product_id IS NOT NULL THEN ns.quantity - COALESCE((
+		               SELECT SUM(` + signedQuantity + `) FROM transactions t
+		               WHERE t.product_id = p.id
+		                 AND t.created_at >= $1 AND t.created_at < ((ns.snapshot_date + 1)::timestamp AT TIME ZONE 'UTC')
+		           ), 0)
+		           ELSE COALESCE((
+		               SELECT SUM(` + signedQuantity + `) FROM transactions t
+		               WHERE t.product_id = p.id AND t.created_at < $1
+		           ), 0)
+		       END
+		FROM products p
+		CROSS JOIN prev
+		CROSS JOIN next
+		LEFT JOIN stock_snapshots ps ON ps.snapshot_date = prev.day AND ps.product_id = p.id
+		LEFT JOIN stock_snapshots ns ON ns.snapshot_date = next.day AND ns.product_id = p.id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE p.tenant_id = $2 AND p.created_at <= $1 AND cardinality(p.variant_axes) = 0
+		ORDER BY c.name ASC, p.sku ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, at, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock as of %s: %w", at.Format(time.RFC3339), err)
+	}
+	defer rows.Close()
+
+	var positions []*entities.StockPosition
+	for rows.Next() {
+		position := &entities.StockPosition{}
+		err := rows.Scan(
+			&position.ProductID, &position.SKU, &position.Name, &position.CategoryID, &position.CategoryName,
+			&position.UnitCost, &position.Quantity,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock position: %w", err)
+		}
+		positions = append(positions, position)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stock positions: %w", err)
+	}
+
+	return positions, nil
+}
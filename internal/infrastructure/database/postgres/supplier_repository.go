@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+// supplierColumns is the column list shared by every supplier query, in scan order
+const supplierColumns = `id, name, COALESCE(email, ''), COALESCE(phone, ''), lead_time_days, status, created_at, updated_at`
+
+// productSupplierColumns selects purchasing terms, falling back to the supplier's lead time
+const productSupplierColumns = `ps.product_id, ps.supplier_id, COALESCE(ps.supplier_sku, ''), ps.unit_cost,
+		COALESCE(NULLIF(ps.lead_time_days, 0), s.lead_time_days), ps.min_order_qty, ps.order_multiple, ps.is_preferred,
+		ps.created_at, ps.updated_at`
+
+type supplierRepository struct {
+	db *database.DB
+}
+
+// NewSupplierRepository creates a new supplier repository
+func NewSupplierRepository(db *database.DB) repositories.SupplierRepository {
+	return &supplierRepository{db: db}
+}
+
+// scanSupplier scans a row selected with supplierColumns into a supplier
+func scanSupplier(row rowScanner) (*entities.Supplier, error) {
+	supplier := &entities.Supplier{}
+	err := row.Scan(
+		&supplier.ID, &supplier.Name, &supplier.Email, &supplier.Phone,
+		&supplier.LeadTimeDays, &supplier.Status, &supplier.CreatedAt, &supplier.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// scanProductSuppliers scans all rows selected with productSupplierColumns
+func scanProductSuppliers(rows *sql.Rows) ([]*entities.ProductSupplier, error) {
+	var productSuppliers []*entities.ProductSupplier
+	for rows.Next() {
+		ps := &entities.ProductSupplier{}
+		err := rows.Scan(
+			&ps.ProductID, &ps.SupplierID, &ps.SupplierSKU, &ps.UnitCost,
+			&ps.LeadTimeDays, &ps.MinOrderQty, &ps.OrderMultiple, &ps.IsPreferred,
+			&ps.CreatedAt, &ps.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product supplier: %w", err)
+		}
+		productSuppliers = append(productSuppliers, ps)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate product suppliers: %w", err)
+	}
+
+	return productSuppliers, nil
+}
+
+// Create creates a new supplier in the tenant of the context
+func (r *supplierRepository) Create(ctx context.Context, supplier *entities.Supplier) error {
+	query := `
+		INSERT INTO suppliers (id, name, email, phone, lead_time_days, status, created_at, updated_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		supplier.ID, supplier.Name, supplier.Email, supplier.Phone,
+		supplier.LeadTimeDays, supplier.Status, supplier.CreatedAt, supplier.UpdatedAt, entities.TenantIDFromContext(ctx),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create supplier: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a supplier by ID
+func (r *supplierRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers WHERE id = $1 AND tenant_id = $2`
+
+	supplier, err := scanSupplier(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get supplier by ID: %w", err)
+	}
+
+	return supplier, nil
+}
+
+// GetAll retrieves all suppliers with pagination
+func (r *supplierRepository) GetAll(ctx context.Context, limit, offset int) ([]*entities.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers WHERE tenant_id = $3 ORDER BY name ASC LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	var suppliers []*entities.Supplier
+	for rows.Next() {
+		supplier, err := scanSupplier(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan supplier: %w", err)
+		}
+		suppliers = append(suppliers, supplier)
+	}
+
+	return suppliers, rows.Err()
+}
+
+// Update updates a supplier
+func (r *supplierRepository) Update(ctx context.Context, supplier *entities.Supplier) error {
+	query := `
+		UPDATE suppliers
+		SET name = $2, email = $3, phone = $4, lead_time_days = $5, status = $6, updated_at = $7
+		WHERE id = $1 AND tenant_id = $8
+	`
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		supplier.ID, supplier.Name, supplier.Email, supplier.Phone,
+		supplier.LeadTimeDays, supplier.Status, supplier.UpdatedAt, entities.TenantIDFromContext(ctx),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update supplier: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertProductSupplier creates or updates the purchasing terms of a product at a supplier.
+// Marking a supplier as preferred clears the flag on the product's other suppliers.
+func (r *supplierRepository) UpsertProductSupplier(ctx context.Context, ps *entities.ProductSupplier) error {
+	tenantID := entities.TenantIDFromContext(ctx)
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if ps.IsPreferred {
+			query := `UPDATE product_suppliers SET is_preferred = FALSE WHERE product_id = $1 AND supplier_id <> $2 AND tenant_id = $3`
+			if _, err := r.db.Conn(ctx).ExecContext(ctx, query, ps.ProductID, ps.SupplierID, tenantID); err != nil {
+				return fmt.Errorf("failed to clear preferred supplier: %w", err)
+			}
+		}
+
+		query := `
+			INSERT INTO product_suppliers (product_id, supplier_id, supplier_sku, unit_cost, lead_time_days, min_order_qty,
+			                               order_multiple, is_preferred, created_at, updated_at, tenant_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (product_id, supplier_id) DO UPDATE
+			SET supplier_sku = EXCLUDED.supplier_sku, unit_cost = EXCLUDED.unit_cost, lead_time_days = EXCLUDED.lead_time_days,
+			    min_order_qty = EXCLUDED.min_order_qty, order_multiple = EXCLUDED.order_multiple,
+			    is_preferred = EXCLUDED.is_preferred, updated_at = EXCLUDED.updated_at
+			WHERE product_suppliers.tenant_id = EXCLUDED.tenant_id
+		`
+
+		_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+			ps.ProductID, ps.SupplierID, ps.SupplierSKU, ps.UnitCost, ps.LeadTimeDays, ps.MinOrderQty,
+			ps.OrderMultiple, ps.IsPreferred, ps.CreatedAt, ps.UpdatedAt, tenantID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert product supplier: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetProductSuppliers retrieves the purchasing terms of a product at all of its suppliers
+func (r *supplierRepository) GetProductSuppliers(ctx context.Context, productID uuid.UUID) ([]*entities.ProductSupplier, error) {
+	query := `
+		SELECT ` + productSupplierColumns + `
+		FROM product_suppliers ps JOIN suppliers s ON s.id = ps.supplier_id
+		WHERE ps.product_id = $1 AND ps.tenant_id = $2 ORDER BY ps.is_preferred DESC, s.name ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, productID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	return scanProductSuppliers(rows)
+}
+
+// GetPreferredProductSuppliers retrieves one set of purchasing terms per product from an active supplier,
+// favouring the preferred supplier and then the cheapest one
+func (r *supplierRepository) GetPreferredProductSuppliers(ctx context.Context) ([]*entities.ProductSupplier, error) {
+	query := `
+		SELECT DISTINCT ON (ps.product_id) ` + productSupplierColumns + `
+		FROM product_suppliers ps JOIN suppliers s ON s.id = ps.supplier_id
+		WHERE ps.tenant_id = $1 AND s.status = 'active'
+		ORDER BY ps.product_id, ps.is_preferred DESC, ps.unit_cost ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferred product suppliers: %w", err)
+	}
+	defer rows.Close()
+
+	return scanProductSuppliers(rows)
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+type tenantRepository struct {
+	db *database.DB
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(db *database.DB) repositories.TenantRepository {
+	return &tenantRepository{db: db}
+}
+
+// GetAll retrieves all tenants
+func (r *tenantRepository) GetAll(ctx context.Context) ([]*entities.Tenant, error) {
+	query := `SELECT id, name, created_at FROM tenants ORDER BY name ASC`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []*entities.Tenant
+	for rows.Next() {
+		tenant := &entities.Tenant{}
+		if err := rows.Scan(&tenant.ID, &tenant.Name, &tenant.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		tenants = append(tenants, tenant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tenants: %w", err)
+	}
+
+	return tenants, nil
+}
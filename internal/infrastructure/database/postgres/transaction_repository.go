@@ -0,0 +1,371 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+// transactionColumns is the column list shared by every transaction query, in scan order
+const transactionColumns = `id, product_id, type, quantity, COALESCE(reference, ''), COALESCE(notes, ''), created_by, created_at, tenant_id`
+
+// signedQuantity is the stock change of a ledger row aliased t: outbound rows are stored as positive quantities
+const signedQuantity = `CASE t.type WHEN 'out' THEN -t.quantity ELSE t.quantity END`
+
+type transactionRepository struct {
+	db *database.DB
+}
+
+// NewTransactionRepository creates a new transaction repository
+func NewTransactionRepository(db *database.DB) repositories.TransactionRepository {
+	return &transactionRepository{db: db}
+}
+
+// scanTransaction scans a row selected with transactionColumns into a transaction
+func scanTransaction(row rowScanner) (*entities.Transaction, error) {
+	transaction := &entities.Transaction{}
+	err := row.Scan(
+		&transaction.ID, &transaction.ProductID, &transaction.Type, &transaction.Quantity,
+		&transaction.Reference, &transaction.Notes, &transaction.CreatedBy, &transaction.CreatedAt, &transaction.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// scanTransactions scans all rows selected with transactionColumns
+func scanTransactions(rows *sql.Rows) ([]*entities.Transaction, error) {
+	var transactions []*entities.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+// Create creates a new transaction in the tenant of the context
+func (r *transactionRepository) Create(ctx context.Context, transaction *entities.Transaction) error {
+	query := `
+		INSERT INTO transactions (id, product_id, type, quantity, reference, notes, created_by, created_at, idempotency_key, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10)
+	`
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		transaction.ID, transaction.ProductID, transaction.Type, transaction.Quantity,
+		transaction.Reference, transaction.Notes, transaction.CreatedBy, transaction.CreatedAt,
+		transaction.IdempotencyKey, tenantID,
+	)
+
+	if err != nil {
+		if isUniqueViolation(err, "idx_transactions_idempotency_key") {
+			return entities.ErrDuplicateIdempotencyKey
+		}
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	transaction.TenantID = tenantID
+	return nil
+}
+
+// GetByID retrieves a transaction by ID
+func (r *transactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE id = $1 AND tenant_id = $2`
+
+	transaction, err := scanTransaction(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get transaction by ID: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// GetByProductID retrieves transactions of a product with pagination
+func (r *transactionRepository) GetByProductID(ctx context.Context, productID uuid.UUID, limit, offset int) ([]*entities.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE product_id = $1 AND tenant_id = $4 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, productID, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions by product: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
+
+// GetByType retrieves transactions of a type with pagination
+func (r *transactionRepository) GetByType(ctx context.Context, transactionType string, limit, offset int) ([]*entities.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE type = $1 AND tenant_id = $4 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, transactionType, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions by type: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
+
+// GetByDateRange retrieves transactions created in [startDate, endDate) with pagination, in a stable order
+func (r *transactionRepository) GetByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int) ([]*entities.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + ` FROM transactions
+		WHERE created_at >= $1 AND created_at < $2 AND tenant_id = $5 ORDER BY created_at ASC, id ASC LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, startDate, endDate, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions by date range: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
+
+// GetAll retrieves all transactions with pagination
+func (r *transactionRepository) GetAll(ctx context.Context, limit, offset int) ([]*entities.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE tenant_id = $3 ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, limit, offset, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
+
+// ForEach streams transactions in ledger order to fn as they are read from the cursor.
+// Nil dates leave the range open and an empty type selects every type.
+// Iteration stops at the first error returned by fn.
+func (r *transactionRepository) ForEach(ctx context.Context, startDate, endDate *time.Time, transactionType string, fn func(*entities.Transaction) error) error {
+	query := `
+		SELECT ` + transactionColumns + ` FROM transactions
+		WHERE ($1::timestamptz IS NULL OR created_at >= $1)
+		  AND ($2::timestamptz IS NULL OR created_at < $2)
+		  AND ($3 = '' OR type = $3)
+		  AND tenant_id = $4
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, startDate, endDate, transactionType, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to query transactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate transactions: %w", err)
+	}
+
+	return nil
+}
+
+// GetOutboundQuantities sums the quantity shipped per product since the given time
+func (r *transactionRepository) GetOutboundQuantities(ctx context.Context, since time.Time) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT product_id, SUM(quantity) FROM transactions
+		WHERE type = 'out' AND created_at >= $1 AND tenant_id = $2 GROUP BY product_id
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, since, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbound quantities: %w", err)
+	}
+	defer rows.Close()
+
+	return scanQuantities(rows)
+}
+
+// GetOutboundByPeriod sums the quantity shipped per product in each of the periods following start.
+// Every series has one entry per period; periods without shipments are zero.
+func (r *transactionRepository) GetOutboundByPeriod(ctx context.Context, start time.Time, periodDays, periods int) (map[uuid.UUID][]int, error) {
+	return r.outboundByPeriod(ctx, nil, start, periodDays, periods)
+}
+
+// GetProductOutboundByPeriod sums the quantity of a product shipped in each of the periods following start
+func (r *transactionRepository) GetProductOutboundByPeriod(ctx context.Context, productID uuid.UUID, start time.Time, periodDays, periods int) ([]int, error) {
+	series, err := r.outboundByPeriod(ctx, &productID, start, periodDays, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	if quantities, ok := series[productID]; ok {
+		return quantities, nil
+	}
+	return make([]int, periods), nil
+}
+
+// outboundByPeriod sums the quantity shipped per product in each period, for one product or, when productID is nil, all of them
+func (r *transactionRepository) outboundByPeriod(ctx context.Context, productID *uuid.UUID, start time.Time, periodDays, periods int) (map[uuid.UUID][]int, error) {
+	query := `
+		SELECT product_id, FLOOR(EXTRACT(EPOCH FROM created_at - $1) / ($2 * 86400))::int AS period, SUM(quantity)
+		FROM transactions
+		WHERE type = 'out' AND created_at >= $1 AND created_at < $1 + make_interval(days => $2 * $3) AND tenant_id = $4
+		  AND ($5::uuid IS NULL OR product_id = $5)
+		GROUP BY product_id, period
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, start, periodDays, periods, entities.TenantIDFromContext(ctx), productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbound quantities by period: %w", err)
+	}
+	defer rows.Close()
+
+	series := make(map[uuid.UUID][]int)
+	for rows.Next() {
+		var productID uuid.UUID
+		var period, quantity int
+		if err := rows.Scan(&productID, &period, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan outbound quantity: %w", err)
+		}
+		if period < 0 || period >= periods {
+			continue
+		}
+		if _, ok := series[productID]; !ok {
+			series[productID] = make([]int, periods)
+		}
+		series[productID][period] += quantity
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbound quantities: %w", err)
+	}
+
+	return series, nil
+}
+
+// GetMovementSummary aggregates the ledger of every product over [startDate, endDate).
+// Opening and closing stock are rolled back from the current stock, so products whose
+// opening balance predates the ledger are still reported correctly.
+func (r *transactionRepository) GetMovementSummary(ctx context.Context, startDate, endDate time.Time, categoryID *uuid.UUID) ([]*entities.ProductMovement, error) {
+	query := `
+		SELECT p.id, p.sku, p.name, p.category_id, COALESCE(c.name, ''), p.cost,
+		       p.stock - COALESCE(SUM(` + signedQuantity + `) FILTER (WHERE t.created_at >= $1), 0),
+		       COALESCE(SUM(t.quantity) FILTER (WHERE t.type = 'in' AND t.created_at >= $1 AND t.created_at < $2), 0),
+		       COALESCE(SUM(t.quantity) FILTER (WHERE t.type = 'out' AND t.created_at >= $1 AND t.created_at < $2), 0),
+		       COALESCE(SUM(t.quantity) FILTER (WHERE t.type = 'adjustment' AND t.created_at >= $1 AND t.created_at < $2), 0),
+		       p.stock - COALESCE(SUM(` + signedQuantity + `) FILTER (WHERE t.created_at >= $2), 0),
+		       MAX(t.created_at) FILTER (WHERE t.created_at < $2)
+		FROM products p
+		LEFT JOIN categories c ON c.id = p.category_id
+		LEFT JOIN transactions t ON t.product_id = p.id
+		WHERE p.tenant_id = $4 AND cardinality(p.variant_axes) = 0 AND p.created_at < $2
+		  AND ($3::uuid IS NULL OR p.category_id = $3)
+		GROUP BY p.id, c.name
+		ORDER BY c.name ASC, p.sku ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, startDate, endDate, categoryID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movement summary: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []*entities.ProductMovement
+	for rows.Next() {
+		m := &entities.ProductMovement{}
+		err := rows.Scan(
+			&m.ProductID, &m.SKU, &m.Name, &m.CategoryID, &m.CategoryName, &m.UnitCost,
+			&m.OpeningStock, &m.In, &m.Out, &m.Adjustments, &m.ClosingStock, &m.LastMovementAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product movement: %w", err)
+		}
+		movements = append(movements, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate product movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// GetDeadStock retrieves active products holding stock without any transaction since the given time
+func (r *transactionRepository) GetDeadStock(ctx context.Context, since time.Time, categoryID *uuid.UUID) ([]*entities.DeadStockItem, error) {
+	query := `
+		SELECT p.id, p.sku, p.name, p.category_id, COALESCE(c.name, ''), p.stock, p.cost, l.last_at
+		FROM products p
+		LEFT JOIN categories c ON c.id = p.category_id
+		LEFT JOIN (
+			SELECT product_id, MAX(created_at) AS last_at FROM transactions WHERE tenant_id = $3 GROUP BY product_id
+		) l ON l.product_id = p.id
+		WHERE p.tenant_id = $3 AND p.status = 'active' AND cardinality(p.variant_axes) = 0 AND p.stock > 0
+		  AND COALESCE(l.last_at, p.created_at) < $1
+		  AND ($2::uuid IS NULL OR p.category_id = $2)
+		ORDER BY p.stock * p.cost DESC, p.sku ASC
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, since, categoryID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead stock: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*entities.DeadStockItem
+	for rows.Next() {
+		item := &entities.DeadStockItem{}
+		err := rows.Scan(
+			&item.ProductID, &item.SKU, &item.Name, &item.CategoryID, &item.CategoryName,
+			&item.Stock, &item.UnitCost, &item.LastMovementAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead stock item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate dead stock: %w", err)
+	}
+
+	return items, nil
+}
+
+// scanQuantities scans (product_id, quantity) rows into a map
+func scanQuantities(rows *sql.Rows) (map[uuid.UUID]int, error) {
+	quantities := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var productID uuid.UUID
+		var quantity int
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan quantity: %w", err)
+		}
+		quantities[productID] = quantity
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate quantities: %w", err)
+	}
+
+	return quantities, nil
+}
@@ -0,0 +1,375 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/internal/infrastructure/database"
+)
+
+const webhookColumns = `id, url, event_types, secret, active, created_at, updated_at, tenant_id`
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_status_code, last_error, delivered_at, created_at, tenant_id`
+
+type webhookRepository struct {
+	db *database.DB
+}
+
+// NewWebhookRepository creates a new webhook subscription repository
+func NewWebhookRepository(db *database.DB) repositories.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// scanWebhook scans a row selected with webhookColumns
+func scanWebhook(row interface{ Scan(...interface{}) error }) (*entities.WebhookSubscription, error) {
+	subscription := &entities.WebhookSubscription{}
+	var eventTypes pq.StringArray
+
+	err := row.Scan(
+		&subscription.ID, &subscription.URL, &eventTypes, &subscription.Secret, &subscription.Active,
+		&subscription.CreatedAt, &subscription.UpdatedAt, &subscription.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.EventTypes = eventTypes
+	return subscription, nil
+}
+
+// Create stores a new subscription in the tenant of the context
+func (r *webhookRepository) Create(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (id, url, event_types, secret, active, created_at, updated_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		subscription.ID, subscription.URL, pq.StringArray(subscription.EventTypes), subscription.Secret,
+		subscription.Active, subscription.CreatedAt, subscription.UpdatedAt, tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	subscription.TenantID = tenantID
+	return nil
+}
+
+// GetByID retrieves a subscription by ID
+func (r *webhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`
+
+	subscription, err := scanWebhook(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// GetAll retrieves all subscriptions of the tenant, oldest first
+func (r *webhookRepository) GetAll(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook_subscriptions WHERE tenant_id = $1 ORDER BY created_at`
+
+	return r.query(ctx, query, entities.TenantIDFromContext(ctx))
+}
+
+// GetActiveByEventType retrieves the active subscriptions of the tenant listing the event type or "*"
+func (r *webhookRepository) GetActiveByEventType(ctx context.Context, eventType string) ([]*entities.WebhookSubscription, error) {
+	query := `
+		SELECT ` + webhookColumns + ` FROM webhook_subscriptions
+		WHERE tenant_id = $1 AND active AND ($2 = ANY(event_types) OR $3 = ANY(event_types))
+		ORDER BY created_at
+	`
+
+	return r.query(ctx, query, entities.TenantIDFromContext(ctx), eventType, entities.AllEventTypes)
+}
+
+// query runs a query selecting webhookColumns
+func (r *webhookRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.WebhookSubscription, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*entities.WebhookSubscription
+	for rows.Next() {
+		subscription, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// Update updates the URL, event types, secret and state of a subscription
+func (r *webhookRepository) Update(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscriptions SET url = $2, event_types = $3, secret = $4, active = $5, updated_at = $6
+		WHERE id = $1 AND tenant_id = $7
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		subscription.ID, subscription.URL, pq.StringArray(subscription.EventTypes), subscription.Secret,
+		subscription.Active, subscription.UpdatedAt, entities.TenantIDFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a subscription; its deliveries and their attempts are deleted with it
+func (r *webhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, id, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+type webhookDeliveryRepository struct {
+	db *database.DB
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository
+func NewWebhookDeliveryRepository(db *database.DB) repositories.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+// scanWebhookDelivery scans a row selected with webhookDeliveryColumns
+func scanWebhookDelivery(row interface{ Scan(...interface{}) error }) (*entities.WebhookDelivery, error) {
+	delivery := &entities.WebhookDelivery{}
+	var statusCode sql.NullInt64
+	var lastError sql.NullString
+
+	err := row.Scan(
+		&delivery.ID, &delivery.SubscriptionID, &delivery.EventID, &delivery.EventType, &delivery.Payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &statusCode, &lastError,
+		&delivery.DeliveredAt, &delivery.CreatedAt, &delivery.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.LastStatusCode = int(statusCode.Int64)
+	delivery.LastError = lastError.String
+	return delivery, nil
+}
+
+// Create stores a delivery in the tenant of the context unless the event was already queued for the subscription
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	tenantID := entities.TenantIDFromContext(ctx)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+		delivery.ID, delivery.SubscriptionID, delivery.EventID, delivery.EventType, delivery.Payload,
+		delivery.Status, delivery.NextAttemptAt, delivery.CreatedAt, tenantID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	delivery.TenantID = tenantID
+	return nil
+}
+
+// GetByID retrieves a delivery by ID
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1 AND tenant_id = $2`
+
+	delivery, err := scanWebhookDelivery(r.db.Conn(ctx).QueryRowContext(ctx, query, id, entities.TenantIDFromContext(ctx)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// GetBySubscription retrieves the deliveries of a subscription, newest first
+func (r *webhookDeliveryRepository) GetBySubscription(ctx context.Context, subscriptionID uuid.UUID, status string, limit, offset int) ([]*entities.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2) AND tenant_id = $5
+		ORDER BY created_at DESC, id LIMIT $3 OFFSET $4
+	`
+
+	return r.query(ctx, query, subscriptionID, status, limit, offset, entities.TenantIDFromContext(ctx))
+}
+
+// ClaimDue leases due deliveries in the order they fell due, skipping rows another worker is claiming
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhook_subscriptions s ON s.id = d.subscription_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= NOW() AND s.active
+			ORDER BY d.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	return r.query(ctx, query, limit, lease.Milliseconds())
+}
+
+// query runs a query returning webhookDeliveryColumns
+func (r *webhookDeliveryRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.WebhookDelivery, error) {
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// RecordAttempt stores an attempt and writes the delivery's new state in one transaction
+func (r *webhookDeliveryRepository) RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) error {
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO webhook_delivery_attempts (id, delivery_id, attempt, status_code, error, duration_ms, attempted_at, tenant_id)
+			VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, ''), $6, $7, $8)
+		`
+
+		_, err := r.db.Conn(ctx).ExecContext(ctx, query,
+			attempt.ID, attempt.DeliveryID, attempt.Attempt, attempt.StatusCode, attempt.Error,
+			attempt.DurationMS, attempt.AttemptedAt, delivery.TenantID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook delivery attempt: %w", err)
+		}
+
+		query = `
+			UPDATE webhook_deliveries
+			SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = NULLIF($5, 0),
+			    last_error = NULLIF($6, ''), delivered_at = $7
+			WHERE id = $1 AND tenant_id = $8
+		`
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, query,
+			delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastStatusCode,
+			delivery.LastError, delivery.DeliveredAt, delivery.TenantID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Requeue resets a delivery of the tenant to pending; its attempt log is kept
+func (r *webhookDeliveryRepository) Requeue(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE webhook_deliveries SET status = 'pending', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	result, err := r.db.Conn(ctx).ExecContext(ctx, query, id, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to requeue webhook delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// GetAttempts retrieves the attempts of a delivery in the order they were made
+func (r *webhookDeliveryRepository) GetAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*entities.WebhookAttempt, error) {
+	query := `
+		SELECT id, delivery_id, attempt, status_code, error, duration_ms, attempted_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = $1 AND tenant_id = $2
+		ORDER BY attempted_at, attempt
+	`
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, deliveryID, entities.TenantIDFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*entities.WebhookAttempt
+	for rows.Next() {
+		attempt := &entities.WebhookAttempt{}
+		var statusCode sql.NullInt64
+		var attemptError sql.NullString
+
+		err := rows.Scan(
+			&attempt.ID, &attempt.DeliveryID, &attempt.Attempt, &statusCode, &attemptError,
+			&attempt.DurationMS, &attempt.AttemptedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery attempt: %w", err)
+		}
+
+		attempt.StatusCode = int(statusCode.Int64)
+		attempt.Error = attemptError.String
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, rows.Err()
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// Querier is implemented by both *sql.DB and *sql.Tx
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txKey is the context key of the transaction started by WithinTransaction
+type txKey struct{}
+
+// WithinTransaction runs fn inside a database transaction carried by the context.
+// Repositories pick it up through Conn, so everything fn writes commits or rolls back together.
+// A call made while a transaction is already open joins that transaction.
+// The transaction is scoped to the tenant of the context, which row-level security enforces.
+func (db *DB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.within(ctx, nil, fn)
+}
+
+// WithinSnapshot runs fn inside a read-only transaction in which every query sees the same snapshot, so that
+// several queries give consistent results. A call made while a transaction is already open joins that transaction.
+func (db *DB) WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.within(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, fn)
+}
+
+// WithinSavepoint runs fn like WithinTransaction, except that inside an open transaction a failing fn rolls back
+// only its own writes, to a savepoint, and the transaction can carry on.
+func (db *DB) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return db.within(ctx, nil, fn)
+	}
+
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT nested"); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(ctx); err != nil {
+		if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT nested"); rollbackErr != nil {
+			return fmt.Errorf("failed to roll back to savepoint: %w", rollbackErr)
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT nested"); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return nil
+}
+
+// within runs fn inside a transaction with the given options, or the one already open
+func (db *DB) within(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('app.tenant_id', $1, true)`, tenantSetting(ctx)); err != nil {
+		return fmt.Errorf("failed to scope transaction to tenant: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Conn returns the transaction carried by the context, or else the connection pool. Queries on the pool are
+// scoped to the tenant of their context by the connections, unless the connections act as SystemRole.
+func (db *DB) Conn(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db.DB
+}
+
+// tenantSetting returns the value of app.tenant_id for the tenant of the context; empty, which row-level
+// security lets see nothing, when there is none
+func tenantSetting(ctx context.Context) string {
+	if tenantID := entities.TenantIDFromContext(ctx); tenantID != uuid.Nil {
+		return tenantID.String()
+	}
+	return ""
+}
@@ -0,0 +1,16 @@
+package events
+
+import "time"
+
+// Backoff returns the wait before the next attempt after the given number of failed attempts:
+// min after the first, doubling with every further one, and never more than max
+func Backoff(attempts int, min, max time.Duration) time.Duration {
+	wait := min
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
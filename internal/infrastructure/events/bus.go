@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"inventory-app/internal/domain/entities"
+)
+
+// AllEvents subscribes a handler to every event type
+const AllEvents = entities.AllEventTypes
+
+// Handler handles an event delivered by the in-process bus
+type Handler func(ctx context.Context, event *entities.Event) error
+
+// Bus is an in-process publisher that hands events to the handlers subscribed to their type.
+// A failing handler makes the dispatcher redeliver the event to every handler, so handlers must be idempotent.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus creates a new in-process event bus
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers a handler for an event type, or for every type with AllEvents
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish runs the handlers of the event's type in the order they subscribed; the context carries the event's tenant
+func (b *Bus) Publish(ctx context.Context, event *entities.Event) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[event.Type]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/pkg/logger"
+)
+
+// Dispatcher relays outbox events to publishers. Every event goes to every publisher, and is retried
+// with exponential backoff until all of them accept it, so consumers may see an event more than once.
+// Events of one product are relayed in order: a failed event holds back the later events of its product.
+type Dispatcher struct {
+	outboxRepo repositories.OutboxRepository
+	txManager  repositories.TxManager
+	publishers []Publisher
+	batchSize  int
+	maxBackoff time.Duration
+	logger     *logger.Logger
+}
+
+// NewDispatcher creates a new outbox dispatcher
+func NewDispatcher(outboxRepo repositories.OutboxRepository, txManager repositories.TxManager, publishers []Publisher, batchSize int, maxBackoff time.Duration, logger *logger.Logger) *Dispatcher {
+	return &Dispatcher{
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+		publishers: publishers,
+		batchSize:  batchSize,
+		maxBackoff: maxBackoff,
+		logger:     logger,
+	}
+}
+
+// Dispatch relays pending events of every tenant in batches until no full batch is left.
+// It does nothing while the dispatcher of another instance is running.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	for ctx.Err() == nil {
+		relayed, err := d.dispatchBatch(ctx)
+		if err != nil || relayed < d.batchSize {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// dispatchBatch relays one batch under the dispatcher lock and returns how many events it handled
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	var handled int
+	err := d.txManager.WithinTransaction(ctx, func(txCtx context.Context) error {
+		locked, err := d.outboxRepo.Lock(txCtx)
+		if err != nil || !locked {
+			return err
+		}
+
+		events, err := d.outboxRepo.GetPending(txCtx, d.batchSize)
+		if err != nil {
+			return err
+		}
+		handled = len(events)
+
+		// Products with an event that failed in this batch
+		held := make(map[uuid.UUID]bool)
+		for _, event := range events {
+			if held[event.ProductID] {
+				continue
+			}
+
+			// Publishers get the tenant of the event, but not the dispatcher's transaction
+			if err := d.publish(entities.WithTenantID(ctx, event.TenantID), event); err != nil {
+				held[event.ProductID] = true
+				d.logger.Warn("Failed to publish event", d.logger.WithFields(map[string]interface{}{
+					"event_id":   event.ID,
+					"event_type": event.Type,
+					"product_id": event.ProductID,
+					"attempts":   event.Attempts + 1,
+					"error":      err,
+				})...)
+				if err := d.outboxRepo.MarkFailed(txCtx, event.ID, err.Error(), time.Now().Add(Backoff(event.Attempts+1, time.Second, d.maxBackoff))); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := d.outboxRepo.MarkPublished(txCtx, event.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return handled, err
+}
+
+// publish hands an event to every publisher
+func (d *Dispatcher) publish(ctx context.Context, event *entities.Event) error {
+	var errs []error
+	for _, publisher := range d.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to publish event %s: %w", event.ID, errors.Join(errs...))
+	}
+	return nil
+}
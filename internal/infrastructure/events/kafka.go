@@ -0,0 +1,39 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"inventory-app/internal/domain/entities"
+)
+
+// KafkaWriter is the part of a Kafka client the publisher needs: writing one message and waiting for the
+// broker to acknowledge it. Client libraries need a few lines of adapter to satisfy it.
+type KafkaWriter interface {
+	WriteMessage(ctx context.Context, topic string, key, value []byte) error
+}
+
+// KafkaPublisher writes every event to one topic, keyed by product ID so the events of a product
+// land on one partition and keep their order
+type KafkaPublisher struct {
+	writer KafkaWriter
+	topic  string
+}
+
+// NewKafkaPublisher creates a publisher on top of a Kafka writer
+func NewKafkaPublisher(writer KafkaWriter, topic string) *KafkaPublisher {
+	return &KafkaPublisher{writer: writer, topic: topic}
+}
+
+// Publish writes an event
+func (p *KafkaPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	data, err := Encode(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.writer.WriteMessage(ctx, p.topic, []byte(event.ProductID.String()), data); err != nil {
+		return fmt.Errorf("failed to write to Kafka: %w", err)
+	}
+	return nil
+}
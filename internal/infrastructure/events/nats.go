@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"inventory-app/internal/domain/entities"
+)
+
+// NATSConn is the part of a NATS client the publisher needs; *nats.Conn of github.com/nats-io/nats.go satisfies it.
+// For JetStream acknowledgements, wrap a JetStream context's Publish.
+type NATSConn interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATSPublisher publishes every event on the subject <prefix>.<event type>, e.g. inventory.stock.received
+type NATSPublisher struct {
+	conn   NATSConn
+	prefix string
+}
+
+// NewNATSPublisher creates a publisher on top of a NATS connection
+func NewNATSPublisher(conn NATSConn, prefix string) *NATSPublisher {
+	return &NATSPublisher{conn: conn, prefix: prefix}
+}
+
+// Publish publishes an event
+func (p *NATSPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	data, err := Encode(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.conn.Publish(p.prefix+"."+event.Type, data); err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/infrastructure/database"
+	"inventory-app/pkg/logger"
+)
+
+// StockNotifyChannel is the PostgreSQL notification channel that stock events are broadcast on
+const StockNotifyChannel = "inventory_stock_events"
+
+// NotifyPublisher broadcasts stock events with PostgreSQL NOTIFY, so that every instance receives the
+// events relayed by the dispatcher of whichever instance runs it. Other event types are ignored.
+type NotifyPublisher struct {
+	db *database.DB
+}
+
+// NewNotifyPublisher creates a new NOTIFY publisher
+func NewNotifyPublisher(db *database.DB) *NotifyPublisher {
+	return &NotifyPublisher{db: db}
+}
+
+// Publish sends a stock event as JSON on StockNotifyChannel
+func (p *NotifyPublisher) Publish(ctx context.Context, event *entities.Event) error {
+	if !entities.IsStockEventType(event.Type) {
+		return nil
+	}
+
+	body, err := Encode(event)
+	if err != nil {
+		return err
+	}
+
+	// Outside the dispatcher's transaction, so the notification is sent right away
+	_, err = p.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, StockNotifyChannel, string(body))
+	return err
+}
+
+// NotifyListener listens on StockNotifyChannel and hands the events to a handler
+type NotifyListener struct {
+	listener *pq.Listener
+	logger   *logger.Logger
+}
+
+// NewNotifyListener creates a listener on its own connection to the database at dsn. onReconnect runs after
+// the connection was lost and restored, as notifications sent meanwhile were missed.
+func NewNotifyListener(dsn string, onReconnect func(), logger *logger.Logger) *NotifyListener {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			logger.Warn("Lost the stock event listener connection", logger.WithField("error", err))
+		case pq.ListenerEventReconnected:
+			onReconnect()
+		}
+	})
+	return &NotifyListener{listener: listener, logger: logger}
+}
+
+// Listen hands every notified event to handler until ctx is done
+func (l *NotifyListener) Listen(ctx context.Context, handler Handler) error {
+	if err := l.listener.Listen(StockNotifyChannel); err != nil {
+		return err
+	}
+	defer l.listener.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notification := <-l.listener.Notify:
+			if notification == nil {
+				// Sent after a reconnect
+				continue
+			}
+
+			var event entities.Event
+			if err := json.Unmarshal([]byte(notification.Extra), &event); err != nil {
+				l.logger.Warn("Invalid stock event notification", l.logger.WithField("error", err))
+				continue
+			}
+			if err := handler(entities.WithTenantID(ctx, event.TenantID), &event); err != nil {
+				l.logger.Warn("Failed to handle stock event notification", l.logger.WithField("error", err))
+			}
+		case <-time.After(90 * time.Second):
+			// Detect a dead connection that the driver has not noticed
+			go l.listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"inventory-app/internal/domain/entities"
+)
+
+// Publisher relays outbox events to consumers. Publish must return nil only once the event has been
+// handed over; an error makes the dispatcher retry the event, and hold back later events of its product.
+type Publisher interface {
+	Publish(ctx context.Context, event *entities.Event) error
+}
+
+// Encode returns the JSON form in which publishers send an event
+func Encode(event *entities.Event) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return data, nil
+}
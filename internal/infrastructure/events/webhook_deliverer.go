@@ -0,0 +1,202 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/pkg/logger"
+)
+
+// Headers of webhook deliveries
+const (
+	HeaderWebhookID        = "X-Webhook-ID"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+	HeaderEventID          = "X-Event-ID"
+	HeaderEventType        = "X-Event-Type"
+)
+
+// webhookErrorBodyLimit is how much of an error response body is kept in the delivery log
+const webhookErrorBodyLimit = 256
+
+// WebhookSettings holds the delivery settings of webhook subscriptions
+type WebhookSettings struct {
+	BatchSize   int           // deliveries sent concurrently per round
+	Timeout     time.Duration // how long to wait for a receiver to respond
+	MaxAttempts int           // attempts before a delivery is dead
+	MinBackoff  time.Duration // wait before the first retry; doubles with every further one
+	MaxBackoff  time.Duration // longest wait between retries
+}
+
+// WebhookDeliverer queues events for the webhook subscriptions that want them and sends the deliveries,
+// signed with the subscription secret and retried with exponential backoff until they run out of attempts
+type WebhookDeliverer struct {
+	webhookRepo  repositories.WebhookRepository
+	deliveryRepo repositories.WebhookDeliveryRepository
+	client       *http.Client
+	settings     WebhookSettings
+	logger       *logger.Logger
+}
+
+// NewWebhookDeliverer creates a new webhook deliverer
+func NewWebhookDeliverer(webhookRepo repositories.WebhookRepository, deliveryRepo repositories.WebhookDeliveryRepository, settings WebhookSettings, logger *logger.Logger) *WebhookDeliverer {
+	return &WebhookDeliverer{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		client:       &http.Client{Timeout: settings.Timeout},
+		settings:     settings,
+		logger:       logger,
+	}
+}
+
+// Sign returns the signature of a webhook body: the hex HMAC-SHA256, keyed with the secret,
+// of the timestamp, a dot and the body, prefixed with "sha256="
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Enqueue queues a delivery of the event for every active subscription of its tenant that wants it.
+// It is a bus handler; a redelivered event is not queued twice.
+func (d *WebhookDeliverer) Enqueue(ctx context.Context, event *entities.Event) error {
+	subscriptions, err := d.webhookRepo.GetActiveByEventType(ctx, event.Type)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	body, err := Encode(event)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		if err := d.deliveryRepo.Create(ctx, entities.NewWebhookDelivery(subscription.ID, event, body)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliver sends due deliveries of every tenant until no full batch is left
+func (d *WebhookDeliverer) Deliver(ctx context.Context) error {
+	// A claimed delivery is left alone by other workers until its request has surely finished
+	lease := d.settings.Timeout + time.Minute
+
+	for ctx.Err() == nil {
+		deliveries, err := d.deliveryRepo.ClaimDue(ctx, d.settings.BatchSize, lease)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			wg.Add(1)
+			go func(delivery *entities.WebhookDelivery) {
+				defer wg.Done()
+				d.deliver(entities.WithTenantID(ctx, delivery.TenantID), delivery)
+			}(delivery)
+		}
+		wg.Wait()
+
+		if len(deliveries) < d.settings.BatchSize {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+// deliver makes one attempt at a delivery and records its outcome
+func (d *WebhookDeliverer) deliver(ctx context.Context, delivery *entities.WebhookDelivery) {
+	subscription, err := d.webhookRepo.GetByID(ctx, delivery.SubscriptionID)
+	if err != nil || subscription == nil {
+		// A deleted subscription takes its deliveries with it; anything else is retried once the lease expires
+		return
+	}
+
+	started := time.Now()
+	statusCode, err := d.send(ctx, subscription, delivery)
+	attempt := &entities.WebhookAttempt{
+		ID:          uuid.New(),
+		DeliveryID:  delivery.ID,
+		Attempt:     delivery.Attempts + 1,
+		StatusCode:  statusCode,
+		DurationMS:  time.Since(started).Milliseconds(),
+		AttemptedAt: started,
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+	switch {
+	case err == nil:
+		delivery.Status = entities.DeliveryStatusDelivered
+		delivery.DeliveredAt = &started
+	case delivery.Attempts >= d.settings.MaxAttempts:
+		delivery.Status = entities.DeliveryStatusDead
+	default:
+		delivery.NextAttemptAt = time.Now().Add(Backoff(delivery.Attempts, d.settings.MinBackoff, d.settings.MaxBackoff))
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+		delivery.LastError = err.Error()
+		d.logger.Warn("Webhook delivery failed", d.logger.WithFields(map[string]interface{}{
+			"tenant_id":       delivery.TenantID,
+			"subscription_id": delivery.SubscriptionID,
+			"delivery_id":     delivery.ID,
+			"attempts":        delivery.Attempts,
+			"status":          delivery.Status,
+			"error":           err,
+		})...)
+	}
+
+	if err := d.deliveryRepo.RecordAttempt(ctx, delivery, attempt); err != nil {
+		d.logger.Error("Failed to record webhook delivery attempt", d.logger.WithFields(map[string]interface{}{
+			"delivery_id": delivery.ID,
+			"error":       err,
+		})...)
+	}
+}
+
+// send posts a delivery to its subscription and returns the response status, 0 when there was none
+func (d *WebhookDeliverer) send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, delivery.ID.String())
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderWebhookSignature, Sign(subscription.Secret, timestamp, delivery.Payload))
+	req.Header.Set(HeaderEventID, delivery.EventID.String())
+	req.Header.Set(HeaderEventType, delivery.EventType)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,423 @@
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/pkg/logger"
+)
+
+// memoryWebhookRepository keeps subscriptions in memory
+type memoryWebhookRepository struct {
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]*entities.WebhookSubscription
+}
+
+func newMemoryWebhookRepository(subscriptions ...*entities.WebhookSubscription) *memoryWebhookRepository {
+	r := &memoryWebhookRepository{subscriptions: make(map[uuid.UUID]*entities.WebhookSubscription)}
+	for _, subscription := range subscriptions {
+		r.subscriptions[subscription.ID] = subscription
+	}
+	return r
+}
+
+func (r *memoryWebhookRepository) Create(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.subscriptions[id], nil
+}
+
+func (r *memoryWebhookRepository) GetAll(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions []*entities.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+func (r *memoryWebhookRepository) GetActiveByEventType(ctx context.Context, eventType string) ([]*entities.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions []*entities.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.Active && subscription.Matches(eventType) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *memoryWebhookRepository) Update(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	return r.Create(ctx, subscription)
+}
+
+func (r *memoryWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions, id)
+	return nil
+}
+
+// memoryDeliveryRepository keeps deliveries in memory with the same contract as the Postgres repository
+type memoryDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries []*entities.WebhookDelivery
+	attempts   map[uuid.UUID][]*entities.WebhookAttempt
+}
+
+func newMemoryDeliveryRepository() *memoryDeliveryRepository {
+	return &memoryDeliveryRepository{attempts: make(map[uuid.UUID][]*entities.WebhookAttempt)}
+}
+
+func (r *memoryDeliveryRepository) Create(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.deliveries {
+		if existing.SubscriptionID == delivery.SubscriptionID && existing.EventID == delivery.EventID {
+			return nil
+		}
+	}
+	stored := *delivery
+	r.deliveries = append(r.deliveries, &stored)
+	return nil
+}
+
+func (r *memoryDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, delivery := range r.deliveries {
+		if delivery.ID == id {
+			copied := *delivery
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryDeliveryRepository) GetBySubscription(ctx context.Context, subscriptionID uuid.UUID, status string, limit, offset int) ([]*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []*entities.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.SubscriptionID == subscriptionID && (status == "" || delivery.Status == status) {
+			copied := *delivery
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *memoryDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	var deliveries []*entities.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		if delivery.Status == entities.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			delivery.NextAttemptAt = now.Add(lease)
+			copied := *delivery
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *memoryDeliveryRepository) RecordAttempt(ctx context.Context, delivery *entities.WebhookDelivery, attempt *entities.WebhookAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.deliveries {
+		if existing.ID == delivery.ID {
+			stored := *delivery
+			r.deliveries[i] = &stored
+		}
+	}
+	r.attempts[delivery.ID] = append(r.attempts[delivery.ID], attempt)
+	return nil
+}
+
+func (r *memoryDeliveryRepository) Requeue(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, delivery := range r.deliveries {
+		if delivery.ID == id {
+			delivery.Status = entities.DeliveryStatusPending
+			delivery.Attempts = 0
+			delivery.NextAttemptAt = time.Now()
+			delivery.DeliveredAt = nil
+			return nil
+		}
+	}
+	return entities.ErrWebhookDeliveryNotFound
+}
+
+func (r *memoryDeliveryRepository) GetAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*entities.WebhookAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.attempts[deliveryID], nil
+}
+
+// only returns the single delivery in the repository
+func (r *memoryDeliveryRepository) only(t *testing.T) *entities.WebhookDelivery {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(r.deliveries))
+	}
+	copied := *r.deliveries[0]
+	return &copied
+}
+
+// receiver is a webhook endpoint answering with the queued status codes, then 200, and recording every request
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+type receivedRequest struct {
+	at     time.Time
+	header http.Header
+	body   []byte
+}
+
+func (rv *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	rv.requests = append(rv.requests, receivedRequest{at: time.Now(), header: r.Header.Clone(), body: body})
+	status := http.StatusOK
+	if len(rv.statuses) > 0 {
+		status, rv.statuses = rv.statuses[0], rv.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rv *receiver) received() []receivedRequest {
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	return append([]receivedRequest(nil), rv.requests...)
+}
+
+var testWebhookSettings = WebhookSettings{
+	BatchSize:   10,
+	Timeout:     5 * time.Second,
+	MaxAttempts: 3,
+	MinBackoff:  20 * time.Millisecond,
+	MaxBackoff:  time.Second,
+}
+
+// setupDeliverer starts a receiver and a deliverer with one subscription to it, and enqueues an event for it
+func setupDeliverer(t *testing.T, statuses ...int) (*WebhookDeliverer, *memoryDeliveryRepository, *receiver) {
+	t.Helper()
+
+	rv := &receiver{statuses: statuses}
+	server := httptest.NewServer(rv)
+	t.Cleanup(server.Close)
+
+	subscription := entities.NewWebhookSubscription(server.URL, []string{entities.AllEventTypes}, "s3cret")
+	deliveryRepo := newMemoryDeliveryRepository()
+	deliverer := NewWebhookDeliverer(newMemoryWebhookRepository(subscription), deliveryRepo, testWebhookSettings,
+		&logger.Logger{Logger: zap.NewNop()})
+
+	event := &entities.Event{ID: uuid.New(), Type: "product.updated", OccurredAt: time.Now()}
+	if err := deliverer.Enqueue(context.Background(), event); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	return deliverer, deliveryRepo, rv
+}
+
+// deliverUntil runs delivery rounds until the delivery is no longer pending or has made attempts attempts
+func deliverUntil(t *testing.T, deliverer *WebhookDeliverer, repo *memoryDeliveryRepository, attempts int) *entities.WebhookDelivery {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if err := deliverer.Deliver(context.Background()); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+		delivery := repo.only(t)
+		if delivery.Status != entities.DeliveryStatusPending || delivery.Attempts >= attempts {
+			return delivery
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery still pending after %d attempts", delivery.Attempts)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSignCanBeVerified(t *testing.T) {
+	deliverer, repo, rv := setupDeliverer(t)
+	deliverUntil(t, deliverer, repo, 1)
+
+	requests := rv.received()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	request := requests[0]
+
+	timestamp, err := strconv.ParseInt(request.header.Get(HeaderWebhookTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid %s header: %v", HeaderWebhookTimestamp, err)
+	}
+
+	// Verify the way a receiver would, without Sign
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(request.body)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	signature := request.header.Get(HeaderWebhookSignature)
+	if !hmac.Equal([]byte(signature), []byte(want)) {
+		t.Errorf("signature = %q, want %q", signature, want)
+	}
+	if Sign("other", timestamp, request.body) == signature {
+		t.Error("signature verifies with another secret")
+	}
+	if Sign("s3cret", timestamp+1, request.body) == signature {
+		t.Error("signature verifies with another timestamp")
+	}
+
+	delivery := repo.only(t)
+	if got := request.header.Get(HeaderWebhookID); got != delivery.ID.String() {
+		t.Errorf("%s = %q, want %q", HeaderWebhookID, got, delivery.ID)
+	}
+	if got := request.header.Get(HeaderEventType); got != "product.updated" {
+		t.Errorf("%s = %q, want product.updated", HeaderEventType, got)
+	}
+}
+
+func TestDeliverRetriesWithBackoff(t *testing.T) {
+	deliverer, repo, rv := setupDeliverer(t, http.StatusInternalServerError, http.StatusBadGateway)
+
+	delivery := deliverUntil(t, deliverer, repo, 3)
+	if delivery.Status != entities.DeliveryStatusDelivered {
+		t.Fatalf("status = %q, want %q", delivery.Status, entities.DeliveryStatusDelivered)
+	}
+	if delivery.Attempts != 3 || delivery.LastStatusCode != http.StatusOK {
+		t.Errorf("attempts = %d, last status = %d, want 3 and 200", delivery.Attempts, delivery.LastStatusCode)
+	}
+
+	requests := rv.received()
+	if len(requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(requests))
+	}
+	for i := 1; i < len(requests); i++ {
+		wait := Backoff(i, testWebhookSettings.MinBackoff, testWebhookSettings.MaxBackoff)
+		if gap := requests[i].at.Sub(requests[i-1].at); gap < wait {
+			t.Errorf("attempt %d came %v after the previous one, want at least %v", i+1, gap, wait)
+		}
+	}
+
+	attempts, _ := repo.GetAttempts(context.Background(), delivery.ID)
+	wantStatuses := []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK}
+	if len(attempts) != len(wantStatuses) {
+		t.Fatalf("got %d attempts, want %d", len(attempts), len(wantStatuses))
+	}
+	for i, attempt := range attempts {
+		if attempt.Attempt != i+1 || attempt.StatusCode != wantStatuses[i] {
+			t.Errorf("attempt %d: number %d, status %d, want %d", i+1, attempt.Attempt, attempt.StatusCode, wantStatuses[i])
+		}
+		if (attempt.Error == "") != (wantStatuses[i] == http.StatusOK) {
+			t.Errorf("attempt %d: error %q", i+1, attempt.Error)
+		}
+	}
+}
+
+func TestDeliverGivesUpAfterMaxAttempts(t *testing.T) {
+	deliverer, repo, rv := setupDeliverer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable,
+		http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	delivery := deliverUntil(t, deliverer, repo, testWebhookSettings.MaxAttempts+1)
+	if delivery.Status != entities.DeliveryStatusDead {
+		t.Fatalf("status = %q, want %q", delivery.Status, entities.DeliveryStatusDead)
+	}
+	if delivery.Attempts != testWebhookSettings.MaxAttempts || delivery.LastStatusCode != http.StatusServiceUnavailable {
+		t.Errorf("attempts = %d, last status = %d", delivery.Attempts, delivery.LastStatusCode)
+	}
+
+	// A dead delivery is not attempted again
+	if err := deliverer.Deliver(context.Background()); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	if got := len(rv.received()); got != testWebhookSettings.MaxAttempts {
+		t.Errorf("got %d requests, want %d", got, testWebhookSettings.MaxAttempts)
+	}
+}
+
+func TestEnqueueIgnoresDuplicates(t *testing.T) {
+	deliverer, repo, rv := setupDeliverer(t)
+
+	delivery := repo.only(t)
+	event := &entities.Event{ID: delivery.EventID, Type: delivery.EventType, OccurredAt: time.Now()}
+	if err := deliverer.Enqueue(context.Background(), event); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	if got := repo.only(t); got.ID != delivery.ID {
+		t.Errorf("delivery %s replaced by %s", delivery.ID, got.ID)
+	}
+	deliverUntil(t, deliverer, repo, 1)
+	if got := len(rv.received()); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestRedeliverDeadDelivery(t *testing.T) {
+	deliverer, repo, rv := setupDeliverer(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable,
+		http.StatusServiceUnavailable)
+
+	delivery := deliverUntil(t, deliverer, repo, testWebhookSettings.MaxAttempts)
+	if delivery.Status != entities.DeliveryStatusDead {
+		t.Fatalf("status = %q, want %q", delivery.Status, entities.DeliveryStatusDead)
+	}
+
+	if err := repo.Requeue(context.Background(), delivery.ID); err != nil {
+		t.Fatalf("Requeue: %v", err)
+	}
+	delivery = deliverUntil(t, deliverer, repo, 1)
+	if delivery.Status != entities.DeliveryStatusDelivered || delivery.Attempts != 1 || delivery.DeliveredAt == nil {
+		t.Errorf("status = %q, attempts = %d after redelivery", delivery.Status, delivery.Attempts)
+	}
+
+	requests := rv.received()
+	if len(requests) != testWebhookSettings.MaxAttempts+1 {
+		t.Fatalf("got %d requests, want %d", len(requests), testWebhookSettings.MaxAttempts+1)
+	}
+	first, last := requests[0], requests[len(requests)-1]
+	if string(first.body) != string(last.body) || first.header.Get(HeaderWebhookID) != last.header.Get(HeaderWebhookID) {
+		t.Error("redelivery differs from the original delivery")
+	}
+
+	attempts, _ := repo.GetAttempts(context.Background(), delivery.ID)
+	if len(attempts) != testWebhookSettings.MaxAttempts+1 {
+		t.Errorf("got %d logged attempts, want the earlier ones kept", len(attempts))
+	}
+}
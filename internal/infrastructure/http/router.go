@@ -4,24 +4,68 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"inventory-app/internal/domain/entities"
 	"inventory-app/internal/interfaces/handlers"
 	"inventory-app/internal/interfaces/middleware"
 )
 
+// productImportPath is the one route whose request body is streamed to its handler
+const productImportPath = "/api/v1/products/import"
+
 // Router holds the HTTP router and handlers
 type Router struct {
 	app                *fiber.App
 	productHandler     *handlers.ProductHandler
 	categoryHandler    *handlers.CategoryHandler
 	transactionHandler *handlers.TransactionHandler
+	inventoryHandler   *handlers.InventoryHandler
+	supplierHandler    *handlers.SupplierHandler
+	replenishHandler   *handlers.ReplenishmentHandler
+	forecastHandler    *handlers.ForecastHandler
+	analyticsHandler   *handlers.AnalyticsHandler
+	reconcileHandler   *handlers.ReconciliationHandler
+	adminHandler       *handlers.AdminHandler
+	apiKeyHandler      *handlers.APIKeyHandler
+	auditHandler       *handlers.AuditHandler
+	webhookHandler     *handlers.WebhookHandler
+	alertHandler       *handlers.AlertHandler
+	streamHandler      *handlers.StreamHandler
+	idempotency        fiber.Handler
+	apiKeyAuth         fiber.Handler
+	auth               fiber.Handler
+	can                func(...entities.Permission) fiber.Handler
 }
 
 // NewRouter creates a new HTTP router
 func NewRouter(
 	productHandler *handlers.ProductHandler,
 	categoryHandler *handlers.CategoryHandler,
-	transactionHandler *handlers.TransactionHandler) *Router {
+	transactionHandler *handlers.TransactionHandler,
+	inventoryHandler *handlers.InventoryHandler,
+	supplierHandler *handlers.SupplierHandler,
+	replenishHandler *handlers.ReplenishmentHandler,
+	forecastHandler *handlers.ForecastHandler,
+	analyticsHandler *handlers.AnalyticsHandler,
+	reconcileHandler *handlers.ReconciliationHandler,
+	adminHandler *handlers.AdminHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	auditHandler *handlers.AuditHandler,
+	webhookHandler *handlers.WebhookHandler,
+	alertHandler *handlers.AlertHandler,
+	streamHandler *handlers.StreamHandler,
+	idempotency fiber.Handler,
+	apiKeyAuth fiber.Handler,
+	auth fiber.Handler,
+	can func(...entities.Permission) fiber.Handler,
+	corsOrigins string,
+	bodyLimit int) *Router {
 	app := fiber.New(fiber.Config{
+		// Stream request bodies so that product imports are read as they arrive; BodyLimit below holds every
+		// other route to bodyLimit, as the server itself no longer rejects larger bodies once streaming
+		StreamRequestBody: true,
+		BodyLimit:         bodyLimit,
+		// Multipart forms are parsed from the limited body instead of being spooled to disk up front
+		DisablePreParseMultipartForm: true,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			if e, ok := err.(*fiber.Error); ok {
@@ -36,13 +80,31 @@ func NewRouter(
 	// Add middleware
 	app.Use(logger.New())
 	app.Use(recover.New())
-	app.Use(middleware.CORS())
+	app.Use(middleware.RequestID())
+	app.Use(middleware.BodyLimit(bodyLimit, productImportPath))
+	app.Use(middleware.CORS(corsOrigins))
 
 	return &Router{
 		app:                app,
 		productHandler:     productHandler,
 		categoryHandler:    categoryHandler,
 		transactionHandler: transactionHandler,
+		inventoryHandler:   inventoryHandler,
+		supplierHandler:    supplierHandler,
+		replenishHandler:   replenishHandler,
+		forecastHandler:    forecastHandler,
+		analyticsHandler:   analyticsHandler,
+		reconcileHandler:   reconcileHandler,
+		adminHandler:       adminHandler,
+		apiKeyHandler:      apiKeyHandler,
+		auditHandler:       auditHandler,
+		webhookHandler:     webhookHandler,
+		alertHandler:       alertHandler,
+		streamHandler:      streamHandler,
+		idempotency:        idempotency,
+		apiKeyAuth:         apiKeyAuth,
+		auth:               auth,
+		can:                can,
 	}
 }
 
@@ -51,23 +113,156 @@ func (r *Router) SetupRoutes() {
 	// Health check endpoint
 	r.app.Get("/health", r.healthCheck)
 
-	// API v1 routes
-	v1 := r.app.Group("/api/v1")
+	// API v1 routes, all authenticated with an API key or a bearer token; r.can names the
+	// permissions a route accepts, any one of them suffices
+	v1 := r.app.Group("/api/v1", r.apiKeyAuth, r.auth)
 	{
 		// Product routes
-		products := v1.Group("/products")
+		// Imports are registered ahead of the group so that its Idempotency-Key middleware, which hashes the
+		// whole body, does not buffer the streamed upload
+		r.app.Post(productImportPath, r.can(entities.PermissionProductImport), r.productHandler.ImportProducts)
+		// POST product and stock endpoints accept an Idempotency-Key header
+		products := v1.Group("/products", r.idempotency)
+		{
+			products.Post("/", r.can(entities.PermissionProductCreate), r.productHandler.CreateProduct)
+			products.Get("/", r.can(entities.PermissionProductView), r.productHandler.ListProducts)
+			products.Get("/search", r.can(entities.PermissionProductView), r.productHandler.SearchProducts)
+			products.Get("/low-stock", r.can(entities.PermissionProductView), r.productHandler.GetLowStockProducts)
+			products.Get("/export", r.can(entities.PermissionProductView), r.productHandler.ExportProducts)
+			products.Get("/:id", r.can(entities.PermissionProductView), r.productHandler.GetProduct)
+			products.Put("/:id", r.can(entities.PermissionProductUpdate), r.productHandler.UpdateProduct)
+			products.Patch("/:id", r.can(entities.PermissionProductUpdate), r.productHandler.PatchProduct)
+			products.Delete("/:id", r.can(entities.PermissionProductDelete), r.productHandler.DeleteProduct)
+			products.Post("/:id/restore", r.can(entities.PermissionProductDelete), r.productHandler.RestoreProduct)
+			products.Post("/:id/activate", r.can(entities.PermissionProductUpdate), r.productHandler.ActivateProduct)
+			products.Post("/:id/deactivate", r.can(entities.PermissionProductUpdate), r.productHandler.DeactivateProduct)
+			products.Get("/:id/history", r.can(entities.PermissionAuditView), r.auditHandler.GetProductHistory)
+			products.Post("/:id/variants", r.can(entities.PermissionProductCreate), r.productHandler.GenerateVariants)
+			products.Put("/:id/variants/policy", r.can(entities.PermissionProductUpdate), r.productHandler.ApplyVariantPolicy)
+			products.Get("/:id/suppliers", r.can(entities.PermissionSupplierView), r.supplierHandler.GetProductSuppliers)
+			products.Get("/:id/reservations", r.can(entities.PermissionStockView), r.inventoryHandler.GetReservations)
+			products.Get("/:id/forecast", r.can(entities.PermissionReportView), r.forecastHandler.GetProductForecast)
+		}
+
+		// Category routes
+		categories := v1.Group("/categories")
+		{
+			categories.Post("/", r.can(entities.PermissionCategoryUpdate), r.categoryHandler.CreateCategory)
+			categories.Get("/", r.can(entities.PermissionCategoryView), r.categoryHandler.ListCategories)
+			categories.Get("/:id", r.can(entities.PermissionCategoryView), r.categoryHandler.GetCategory)
+			categories.Patch("/:id", r.can(entities.PermissionCategoryUpdate), r.categoryHandler.PatchCategory)
+			categories.Delete("/:id", r.can(entities.PermissionCategoryDelete), r.categoryHandler.DeleteCategory)
+			categories.Post("/:id/restore", r.can(entities.PermissionCategoryDelete), r.categoryHandler.RestoreCategory)
+			categories.Post("/:id/activate", r.can(entities.PermissionCategoryUpdate), r.categoryHandler.ActivateCategory)
+			categories.Post("/:id/deactivate", r.can(entities.PermissionCategoryUpdate), r.categoryHandler.DeactivateCategory)
+		}
+
+		// Inventory routes
+		inventory := v1.Group("/inventory", r.idempotency)
+		{
+			inventory.Post("/stock-in", r.can(entities.PermissionStockIn), r.inventoryHandler.StockIn)
+			inventory.Post("/stock-out", r.can(entities.PermissionStockOut), r.inventoryHandler.StockOut)
+			inventory.Post("/adjustments", r.can(entities.PermissionStockAdjust), r.inventoryHandler.AdjustStock)
+			inventory.Post("/movements/batch", r.can(entities.PermissionStockIn, entities.PermissionStockOut, entities.PermissionStockAdjust), r.inventoryHandler.ApplyMovements)
+			inventory.Post("/reservations", r.can(entities.PermissionStockReserve), r.inventoryHandler.ReserveStock)
+			inventory.Post("/reservations/:id/release", r.can(entities.PermissionStockReserve), r.inventoryHandler.ReleaseReservation)
+		}
+
+		// Transaction routes
+		transactions := v1.Group("/transactions")
+		{
+			transactions.Get("/export", r.can(entities.PermissionStockView), r.inventoryHandler.ExportTransactions)
+		}
+
+		// Supplier routes
+		suppliers := v1.Group("/suppliers")
+		{
+			suppliers.Post("/", r.can(entities.PermissionSupplierManage), r.supplierHandler.CreateSupplier)
+			suppliers.Get("/", r.can(entities.PermissionSupplierView), r.supplierHandler.ListSuppliers)
+			suppliers.Get("/:id", r.can(entities.PermissionSupplierView), r.supplierHandler.GetSupplier)
+			suppliers.Put("/:id", r.can(entities.PermissionSupplierManage), r.supplierHandler.UpdateSupplier)
+			suppliers.Put("/:id/products/:productId", r.can(entities.PermissionSupplierManage), r.supplierHandler.SetProductSupplier)
+		}
+
+		// Replenishment routes
+		replenishment := v1.Group("/replenishment")
+		{
+			replenishment.Get("/proposals", r.can(entities.PermissionPurchaseView), r.replenishHandler.GetProposals)
+			replenishment.Post("/purchase-orders", r.can(entities.PermissionPurchaseManage), r.replenishHandler.CreatePurchaseOrders)
+		}
+
+		// Purchase order routes
+		purchaseOrders := v1.Group("/purchase-orders")
+		{
+			purchaseOrders.Get("/", r.can(entities.PermissionPurchaseView), r.replenishHandler.ListPurchaseOrders)
+			purchaseOrders.Get("/:id", r.can(entities.PermissionPurchaseView), r.replenishHandler.GetPurchaseOrder)
+			purchaseOrders.Post("/:id/submit", r.can(entities.PermissionPurchaseManage), r.replenishHandler.SubmitPurchaseOrder)
+			purchaseOrders.Post("/:id/cancel", r.can(entities.PermissionPurchaseManage), r.replenishHandler.CancelPurchaseOrder)
+		}
+
+		// Report routes
+		reports := v1.Group("/reports")
+		{
+			reports.Get("/abc-xyz", r.can(entities.PermissionReportView), r.analyticsHandler.GetClassification)
+			reports.Get("/stock-as-of", r.can(entities.PermissionReportView), r.analyticsHandler.GetStockAsOf)
+			reports.Get("/movements", r.can(entities.PermissionReportView), r.analyticsHandler.GetMovementReport)
+			reports.Get("/dead-stock", r.can(entities.PermissionReportView), r.analyticsHandler.GetDeadStock)
+		}
+
+		// Reconciliation routes
+		reconciliation := v1.Group("/reconciliation")
+		{
+			reconciliation.Post("/runs", r.can(entities.PermissionReconciliationRun), r.reconcileHandler.Reconcile)
+			reconciliation.Get("/runs", r.can(entities.PermissionReportView), r.reconcileHandler.ListRuns)
+			reconciliation.Get("/runs/:id", r.can(entities.PermissionReportView), r.reconcileHandler.GetRun)
+		}
+
+		// Audit log routes
+		v1.Get("/audit", r.can(entities.PermissionAuditView), r.auditHandler.ListEntries)
+
+		// Role administration routes
+		admin := v1.Group("/admin", r.can(entities.PermissionRoleManage))
+		{
+			admin.Get("/roles", r.adminHandler.ListRoles)
+			admin.Get("/users", r.adminHandler.ListAssignments)
+			admin.Put("/users/:id/role", r.adminHandler.AssignRole)
+			admin.Delete("/users/:id/role", r.adminHandler.RevokeRole)
+		}
+
+		// API key routes
+		apiKeys := v1.Group("/api-keys", r.can(entities.PermissionAPIKeyManage))
+		{
+			apiKeys.Post("/", r.apiKeyHandler.CreateAPIKey)
+			apiKeys.Get("/", r.apiKeyHandler.ListAPIKeys)
+			apiKeys.Delete("/:id", r.apiKeyHandler.RevokeAPIKey)
+		}
+
+		// Webhook routes
+		webhooks := v1.Group("/webhooks", r.can(entities.PermissionWebhookManage))
+		{
+			webhooks.Post("/", r.webhookHandler.CreateWebhook)
+			webhooks.Get("/", r.webhookHandler.ListWebhooks)
+			webhooks.Get("/:id", r.webhookHandler.GetWebhook)
+			webhooks.Put("/:id", r.webhookHandler.UpdateWebhook)
+			webhooks.Delete("/:id", r.webhookHandler.DeleteWebhook)
+			webhooks.Get("/:id/deliveries", r.webhookHandler.ListDeliveries)
+			webhooks.Get("/:id/deliveries/:deliveryId", r.webhookHandler.GetDelivery)
+			webhooks.Post("/:id/deliveries/:deliveryId/redeliver", r.webhookHandler.RedeliverDelivery)
+		}
+
+		// Stock alert routes; subscriptions and the feed belong to the caller
+		alerts := v1.Group("/alerts", r.can(entities.PermissionStockView))
 		{
-			products.Post("/", r.productHandler.CreateProduct)
-			products.Get("/", r.productHandler.ListProducts)
-			products.Get("/search", r.productHandler.SearchProducts)
-			products.Get("/low-stock", r.productHandler.GetLowStockProducts)
-			products.Get("/:id", r.productHandler.GetProduct)
-			products.Put("/:id", r.productHandler.UpdateProduct)
-			products.Delete("/:id", r.productHandler.DeleteProduct)
+			alerts.Get("/", r.alertHandler.ListAlerts)
+			alerts.Post("/subscriptions", r.alertHandler.Subscribe)
+			alerts.Get("/subscriptions", r.alertHandler.ListSubscriptions)
+			alerts.Delete("/subscriptions/:id", r.alertHandler.Unsubscribe)
+			alerts.Get("/feed", r.alertHandler.GetFeed)
+			alerts.Post("/feed/:id/read", r.alertHandler.MarkFeedItemRead)
 		}
 
-		// TODO: Add inventory routes
-		// TODO: Add category routes
+		// Live stream routes (server-sent events)
+		v1.Get("/stream/stock", r.can(entities.PermissionStockView), r.streamHandler.StreamStock)
 	}
 }
 
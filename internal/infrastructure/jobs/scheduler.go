@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"inventory-app/pkg/logger"
+)
+
+// Job is a background task run at a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs background jobs until its context is cancelled
+type Scheduler struct {
+	logger *logger.Logger
+	jobs   []Job
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new job scheduler
+func NewScheduler(logger *logger.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Register adds a job; jobs with a non-positive interval are ignored
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 {
+		return
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every job once immediately and then at its interval, each in its own goroutine
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Wait blocks until every job goroutine has returned
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// loop runs a job until the context is cancelled
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run runs a job once and logs the outcome
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	started := time.Now()
+	if err := job.Run(ctx); err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("Background job failed", s.logger.WithFields(map[string]interface{}{
+				"job":   job.Name,
+				"error": err,
+			})...)
+		}
+		return
+	}
+
+	s.logger.Debug("Background job finished", s.logger.WithFields(map[string]interface{}{
+		"job":      job.Name,
+		"duration": time.Since(started).String(),
+	})...)
+}
@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// PerTenant returns a job run that calls run once for every tenant, with the context scoped to the tenant.
+// A failing tenant does not stop the others; their errors are returned together.
+func PerTenant(tenantRepo repositories.TenantRepository, run func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		tenants, err := tenantRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		var errs []error
+		for _, tenant := range tenants {
+			if err := run(entities.WithTenantID(ctx, tenant.ID)); err != nil {
+				errs = append(errs, fmt.Errorf("tenant %s: %w", tenant.Name, err))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
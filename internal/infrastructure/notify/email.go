@@ -0,0 +1,109 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"inventory-app/internal/domain/entities"
+)
+
+// EmailSettings holds the SMTP server that alert emails are sent through
+type EmailSettings struct {
+	Host     string
+	Port     int
+	Username string // no authentication when empty
+	Password string
+	From     string
+	Timeout  time.Duration // how long a whole SMTP conversation may take
+}
+
+// EmailChannel sends alert notifications as plain text emails to the address of the subscription
+type EmailChannel struct {
+	settings EmailSettings
+}
+
+// NewEmailChannel creates a new email channel
+func NewEmailChannel(settings EmailSettings) *EmailChannel {
+	return &EmailChannel{settings: settings}
+}
+
+// Send sends one email; STARTTLS is used when the server offers it
+func (c *EmailChannel) Send(ctx context.Context, subscription *entities.AlertSubscription, notification *entities.AlertNotification) error {
+	if err := c.send(ctx, subscription.Target, c.message(subscription, notification)); err != nil {
+		return fmt.Errorf("failed to send alert email: %w", err)
+	}
+	return nil
+}
+
+// send runs the SMTP conversation delivering msg to one recipient
+func (c *EmailChannel) send(ctx context.Context, to string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, c.settings.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(c.settings.Host, strconv.Itoa(c.settings.Port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.settings.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: c.settings.Host}); err != nil {
+			return err
+		}
+	}
+	if c.settings.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.settings.Username, c.settings.Password, c.settings.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(c.settings.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message builds the email of a notification
+func (c *EmailChannel) message(subscription *entities.AlertSubscription, notification *entities.AlertNotification) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", c.settings.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", subscription.Target)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject()))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(notification.Message())
+	msg.WriteString("\r\n")
+	return msg.Bytes()
+}
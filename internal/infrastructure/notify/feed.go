@@ -0,0 +1,23 @@
+package notify
+
+import (
+	"context"
+
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+)
+
+// FeedChannel adds alert notifications to the in-app feed of the subscriber
+type FeedChannel struct {
+	feedRepo repositories.AlertFeedRepository
+}
+
+// NewFeedChannel creates a new in-app feed channel
+func NewFeedChannel(feedRepo repositories.AlertFeedRepository) *FeedChannel {
+	return &FeedChannel{feedRepo: feedRepo}
+}
+
+// Send stores the notification as an unread feed item of the subscription's user
+func (c *FeedChannel) Send(ctx context.Context, subscription *entities.AlertSubscription, notification *entities.AlertNotification) error {
+	return c.feedRepo.Create(ctx, entities.NewAlertFeedItem(subscription.UserID, notification))
+}
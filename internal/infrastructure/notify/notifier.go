@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
+	"inventory-app/pkg/logger"
+)
+
+// Channel sends alert notifications to the target of a subscription
+type Channel interface {
+	Send(ctx context.Context, subscription *entities.AlertSubscription, notification *entities.AlertNotification) error
+}
+
+// Notifier sends alert events to the subscribers of the product's category or of any category above it,
+// through the channel of each subscription
+type Notifier struct {
+	subscriptionRepo repositories.AlertSubscriptionRepository
+	categoryRepo     repositories.CategoryRepository
+	channels         map[string]Channel
+	logger           *logger.Logger
+}
+
+// NewNotifier creates a new notifier sending through the channels keyed by their name
+func NewNotifier(subscriptionRepo repositories.AlertSubscriptionRepository, categoryRepo repositories.CategoryRepository, channels map[string]Channel, logger *logger.Logger) *Notifier {
+	return &Notifier{
+		subscriptionRepo: subscriptionRepo,
+		categoryRepo:     categoryRepo,
+		channels:         channels,
+		logger:           logger,
+	}
+}
+
+// Channels returns the names of the configured channels
+func (n *Notifier) Channels() []string {
+	var names []string
+	for name := range n.channels {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Notify sends an alert.opened or alert.resolved event to its subscribers. It is a bus handler: subscriptions
+// already notified of the event are skipped, and a failed send fails the event so that it is redelivered.
+func (n *Notifier) Notify(ctx context.Context, event *entities.Event) error {
+	notification := &entities.AlertNotification{EventType: event.Type}
+	if err := json.Unmarshal(event.Payload, &notification.AlertEventPayload); err != nil {
+		return fmt.Errorf("failed to decode alert event: %w", err)
+	}
+
+	categoryIDs, err := n.categoryPath(ctx, notification.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := n.subscriptionRepo.GetByCategories(ctx, categoryIDs)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if err := n.send(ctx, subscription, notification); err != nil {
+			n.logger.Warn("Alert notification failed", n.logger.WithFields(map[string]interface{}{
+				"tenant_id":       event.TenantID,
+				"subscription_id": subscription.ID,
+				"channel":         subscription.Channel,
+				"alert_id":        notification.AlertID,
+				"error":           err,
+			})...)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// send sends a notification to one subscription unless it was sent before
+func (n *Notifier) send(ctx context.Context, subscription *entities.AlertSubscription, notification *entities.AlertNotification) error {
+	channel, ok := n.channels[subscription.Channel]
+	if !ok {
+		// The channel was configured when the subscription was made but is not anymore
+		return nil
+	}
+
+	notified, err := n.subscriptionRepo.IsNotified(ctx, subscription.ID, notification.AlertID, notification.EventType)
+	if err != nil || notified {
+		return err
+	}
+
+	if err := channel.Send(ctx, subscription, notification); err != nil {
+		return err
+	}
+
+	return n.subscriptionRepo.MarkNotified(ctx, subscription.ID, notification.AlertID, notification.EventType)
+}
+
+// categoryPath returns a category and every category above it
+func (n *Notifier) categoryPath(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error) {
+	var path []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for id := &categoryID; id != nil && !seen[*id]; {
+		seen[*id] = true
+		path = append(path, *id)
+
+		category, err := n.categoryRepo.GetByIDIncludingDeleted(ctx, *id)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			break
+		}
+		id = category.ParentID
+	}
+	return path, nil
+}
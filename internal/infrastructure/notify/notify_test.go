@@ -0,0 +1,455 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/pkg/logger"
+)
+
+// smtpServer is an in-process SMTP server accepting every message except to rejected recipients
+type smtpServer struct {
+	listener net.Listener
+	rejected string
+
+	mu       sync.Mutex
+	messages []smtpMessage
+}
+
+type smtpMessage struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// newSMTPServer starts a server rejecting the recipients containing rejected, none when it is empty
+func newSMTPServer(t *testing.T, rejected string) *smtpServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &smtpServer{listener: listener, rejected: rejected}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// settings returns email settings pointing at the server
+func (s *smtpServer) settings() EmailSettings {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return EmailSettings{Host: addr.IP.String(), Port: addr.Port, From: "alerts@example.com", Timeout: 5 * time.Second}
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+
+	var msg smtpMessage
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			text.PrintfLine("250-localhost")
+			text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			msg.auth = arg
+			text.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			msg.from = arg
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			if s.rejected != "" && strings.Contains(arg, s.rejected) {
+				text.PrintfLine("550 5.1.1 No such user")
+				continue
+			}
+			msg.to = append(msg.to, arg)
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			msg = smtpMessage{}
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *smtpServer) received() []smtpMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smtpMessage(nil), s.messages...)
+}
+
+func lowStockNotification(eventType string) *entities.AlertNotification {
+	return &entities.AlertNotification{
+		EventType: eventType,
+		AlertEventPayload: entities.AlertEventPayload{
+			AlertID:    uuid.New(),
+			AlertType:  entities.AlertTypeLowStock,
+			ProductID:  uuid.New(),
+			SKU:        "SKU-1",
+			Name:       "Widget",
+			CategoryID: uuid.New(),
+			Stock:      2,
+			Threshold:  5,
+		},
+	}
+}
+
+func TestEmailChannelSend(t *testing.T) {
+	server := newSMTPServer(t, "")
+	settings := server.settings()
+	settings.Username, settings.Password = "user", "pass"
+	channel := NewEmailChannel(settings)
+
+	subscription := entities.NewAlertSubscription(uuid.New(), nil, entities.AlertChannelEmail, "buyer@example.com")
+	notification := lowStockNotification(entities.EventAlertOpened)
+	if err := channel.Send(context.Background(), subscription, notification); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	messages := server.received()
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	msg := messages[0]
+	if msg.from != "FROM:<alerts@example.com>" {
+		t.Errorf("MAIL %q, want FROM:<alerts@example.com>", msg.from)
+	}
+	if len(msg.to) != 1 || msg.to[0] != "TO:<buyer@example.com>" {
+		t.Errorf("RCPT %q, want TO:<buyer@example.com>", msg.to)
+	}
+	if !strings.HasPrefix(msg.auth, "PLAIN ") {
+		t.Errorf("AUTH %q, want PLAIN", msg.auth)
+	}
+
+	header, body, _ := strings.Cut(msg.data, "\n\n")
+	for _, want := range []string{
+		"From: alerts@example.com",
+		"To: buyer@example.com",
+		"Subject: " + notification.Subject(),
+		"Content-Type: text/plain; charset=utf-8",
+	} {
+		if !strings.Contains(header, want) {
+			t.Errorf("header lacks %q:\n%s", want, header)
+		}
+	}
+	if strings.TrimSpace(body) != notification.Message() {
+		t.Errorf("body = %q, want %q", strings.TrimSpace(body), notification.Message())
+	}
+}
+
+func TestEmailChannelRejectedRecipient(t *testing.T) {
+	server := newSMTPServer(t, "nobody@example.com")
+	channel := NewEmailChannel(server.settings())
+
+	subscription := entities.NewAlertSubscription(uuid.New(), nil, entities.AlertChannelEmail, "nobody@example.com")
+	if err := channel.Send(context.Background(), subscription, lowStockNotification(entities.EventAlertOpened)); err == nil {
+		t.Fatal("Send succeeded for a rejected recipient")
+	}
+	if got := len(server.received()); got != 0 {
+		t.Errorf("got %d messages, want none", got)
+	}
+}
+
+// memorySubscriptionRepository keeps alert subscriptions and their notifications in memory
+type memorySubscriptionRepository struct {
+	mu            sync.Mutex
+	subscriptions []*entities.AlertSubscription
+	notified      map[string]bool
+}
+
+func newMemorySubscriptionRepository(subscriptions ...*entities.AlertSubscription) *memorySubscriptionRepository {
+	return &memorySubscriptionRepository{subscriptions: subscriptions, notified: make(map[string]bool)}
+}
+
+func (r *memorySubscriptionRepository) Create(ctx context.Context, subscription *entities.AlertSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions = append(r.subscriptions, subscription)
+	return nil
+}
+
+func (r *memorySubscriptionRepository) GetByUser(ctx context.Context, userID uuid.UUID) ([]*entities.AlertSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions []*entities.AlertSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.UserID == userID {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *memorySubscriptionRepository) GetByCategories(ctx context.Context, categoryIDs []uuid.UUID) ([]*entities.AlertSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions []*entities.AlertSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.CategoryID == nil {
+			subscriptions = append(subscriptions, subscription)
+			continue
+		}
+		for _, id := range categoryIDs {
+			if *subscription.CategoryID == id {
+				subscriptions = append(subscriptions, subscription)
+				break
+			}
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *memorySubscriptionRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+func (r *memorySubscriptionRepository) IsNotified(ctx context.Context, subscriptionID, alertID uuid.UUID, eventType string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.notified[subscriptionID.String()+alertID.String()+eventType], nil
+}
+
+func (r *memorySubscriptionRepository) MarkNotified(ctx context.Context, subscriptionID, alertID uuid.UUID, eventType string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notified[subscriptionID.String()+alertID.String()+eventType] = true
+	return nil
+}
+
+// memoryCategoryRepository keeps categories, soft-deleted ones included, in memory
+type memoryCategoryRepository struct {
+	categories map[uuid.UUID]*entities.Category
+}
+
+func newMemoryCategoryRepository(categories ...*entities.Category) *memoryCategoryRepository {
+	r := &memoryCategoryRepository{categories: make(map[uuid.UUID]*entities.Category)}
+	for _, category := range categories {
+		r.categories[category.ID] = category
+	}
+	return r
+}
+
+func (r *memoryCategoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	r.categories[category.ID] = category
+	return nil
+}
+
+func (r *memoryCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	if category := r.categories[id]; category != nil && category.DeletedAt == nil {
+		return category, nil
+	}
+	return nil, nil
+}
+
+func (r *memoryCategoryRepository) GetByIDIncludingDeleted(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	return r.categories[id], nil
+}
+
+func (r *memoryCategoryRepository) GetAll(ctx context.Context, includeDeleted bool) ([]*entities.Category, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryCategoryRepository) GetByParentID(ctx context.Context, parentID uuid.UUID) ([]*entities.Category, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryCategoryRepository) GetRootCategories(ctx context.Context) ([]*entities.Category, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryCategoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	return errors.New("not implemented")
+}
+
+func (r *memoryCategoryRepository) Delete(ctx context.Context, id uuid.UUID, deletedAt time.Time) error {
+	return errors.New("not implemented")
+}
+
+func (r *memoryCategoryRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return errors.New("not implemented")
+}
+
+// recordingChannel records the subscriptions it sends to, failing for the targets in fail
+type recordingChannel struct {
+	mu   sync.Mutex
+	sent []uuid.UUID
+	fail map[string]bool
+}
+
+func (c *recordingChannel) Send(ctx context.Context, subscription *entities.AlertSubscription, notification *entities.AlertNotification) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fail[subscription.Target] {
+		return errors.New("unreachable")
+	}
+	c.sent = append(c.sent, subscription.ID)
+	return nil
+}
+
+func (c *recordingChannel) sentTo() map[uuid.UUID]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := make(map[uuid.UUID]int)
+	for _, id := range c.sent {
+		counts[id]++
+	}
+	return counts
+}
+
+// category creates a category below parent, nil for a root category
+func category(parent *entities.Category) *entities.Category {
+	c := &entities.Category{ID: uuid.New(), Status: "active"}
+	if parent != nil {
+		c.ParentID = &parent.ID
+	}
+	return c
+}
+
+func alertEvent(t *testing.T, eventType string, alertID, categoryID uuid.UUID) *entities.Event {
+	t.Helper()
+	payload, err := json.Marshal(entities.AlertEventPayload{
+		AlertID: alertID, AlertType: entities.AlertTypeLowStock, SKU: "SKU-1", CategoryID: categoryID, Stock: 1, Threshold: 5,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	return &entities.Event{ID: uuid.New(), Type: eventType, Payload: payload, OccurredAt: time.Now()}
+}
+
+func newTestNotifier(subscriptionRepo *memorySubscriptionRepository, categoryRepo *memoryCategoryRepository, channel Channel) *Notifier {
+	return NewNotifier(subscriptionRepo, categoryRepo, map[string]Channel{entities.AlertChannelEmail: channel},
+		&logger.Logger{Logger: zap.NewNop()})
+}
+
+func TestNotifierCategoryPath(t *testing.T) {
+	root := category(nil)
+	deletedParent := category(root)
+	now := time.Now()
+	deletedParent.DeletedAt = &now
+	leaf := category(deletedParent)
+	sibling := category(root)
+
+	subscribe := func(c *entities.Category) *entities.AlertSubscription {
+		var categoryID *uuid.UUID
+		if c != nil {
+			categoryID = &c.ID
+		}
+		return entities.NewAlertSubscription(uuid.New(), categoryID, entities.AlertChannelEmail, "buyer@example.com")
+	}
+	toLeaf, toDeletedParent, toRoot, toSibling, toAll := subscribe(leaf), subscribe(deletedParent), subscribe(root), subscribe(sibling), subscribe(nil)
+
+	channel := &recordingChannel{}
+	notifier := newTestNotifier(
+		newMemorySubscriptionRepository(toLeaf, toDeletedParent, toRoot, toSibling, toAll),
+		newMemoryCategoryRepository(root, deletedParent, leaf, sibling),
+		channel,
+	)
+
+	if err := notifier.Notify(context.Background(), alertEvent(t, entities.EventAlertOpened, uuid.New(), leaf.ID)); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	sent := channel.sentTo()
+	for name, subscription := range map[string]*entities.AlertSubscription{
+		"leaf": toLeaf, "soft-deleted parent": toDeletedParent, "root": toRoot, "every category": toAll,
+	} {
+		if sent[subscription.ID] != 1 {
+			t.Errorf("subscription to %s notified %d times, want once", name, sent[subscription.ID])
+		}
+	}
+	if sent[toSibling.ID] != 0 {
+		t.Error("subscription to a sibling category notified")
+	}
+}
+
+func TestNotifierCategoryCycle(t *testing.T) {
+	a, b := category(nil), category(nil)
+	a.ParentID, b.ParentID = &b.ID, &a.ID
+	toA := entities.NewAlertSubscription(uuid.New(), &a.ID, entities.AlertChannelEmail, "buyer@example.com")
+
+	channel := &recordingChannel{}
+	notifier := newTestNotifier(newMemorySubscriptionRepository(toA), newMemoryCategoryRepository(a, b), channel)
+
+	if err := notifier.Notify(context.Background(), alertEvent(t, entities.EventAlertOpened, uuid.New(), b.ID)); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if channel.sentTo()[toA.ID] != 1 {
+		t.Error("subscription above a category cycle not notified")
+	}
+}
+
+func TestNotifierSkipsNotified(t *testing.T) {
+	cat := category(nil)
+	reachable := entities.NewAlertSubscription(uuid.New(), &cat.ID, entities.AlertChannelEmail, "buyer@example.com")
+	unreachable := entities.NewAlertSubscription(uuid.New(), &cat.ID, entities.AlertChannelEmail, "down@example.com")
+
+	channel := &recordingChannel{fail: map[string]bool{"down@example.com": true}}
+	notifier := newTestNotifier(newMemorySubscriptionRepository(reachable, unreachable), newMemoryCategoryRepository(cat), channel)
+
+	alertID := uuid.New()
+	opened := alertEvent(t, entities.EventAlertOpened, alertID, cat.ID)
+	if err := notifier.Notify(context.Background(), opened); err == nil {
+		t.Fatal("Notify succeeded although a send failed")
+	}
+
+	// The redelivered event only goes to the subscription that was not notified yet
+	channel.fail = nil
+	if err := notifier.Notify(context.Background(), opened); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if err := notifier.Notify(context.Background(), opened); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	sent := channel.sentTo()
+	if sent[reachable.ID] != 1 || sent[unreachable.ID] != 1 {
+		t.Errorf("notified %d and %d times, want once each", sent[reachable.ID], sent[unreachable.ID])
+	}
+
+	// Resolving the alert is a separate notification
+	if err := notifier.Notify(context.Background(), alertEvent(t, entities.EventAlertResolved, alertID, cat.ID)); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if sent := channel.sentTo(); sent[reachable.ID] != 2 || sent[unreachable.ID] != 2 {
+		t.Errorf("notified %d and %d times after resolving, want twice each", sent[reachable.ID], sent[unreachable.ID])
+	}
+}
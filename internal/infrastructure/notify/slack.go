@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"inventory-app/internal/domain/entities"
+)
+
+// SlackChannel posts alert notifications to the Slack-compatible incoming webhook URL of the subscription
+type SlackChannel struct {
+	client *http.Client
+}
+
+// NewSlackChannel creates a new Slack channel, giving up on a request after timeout
+func NewSlackChannel(timeout time.Duration) *SlackChannel {
+	return &SlackChannel{client: &http.Client{Timeout: timeout}}
+}
+
+// Send posts the notification as a {"text": ...} message; any 2xx response acknowledges it
+func (c *SlackChannel) Send(ctx context.Context, subscription *entities.AlertSubscription, notification *entities.AlertNotification) error {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", notification.Subject(), notification.Message()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post slack message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
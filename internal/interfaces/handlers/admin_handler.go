@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// AdminHandler handles role administration HTTP requests
+type AdminHandler struct {
+	accessUseCase usecases.AccessUseCase
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(accessUseCase usecases.AccessUseCase) *AdminHandler {
+	return &AdminHandler{accessUseCase: accessUseCase}
+}
+
+// ListRoles handles GET /admin/roles
+func (h *AdminHandler) ListRoles(c *fiber.Ctx) error {
+	return c.JSON(h.accessUseCase.ListRoles(c.Context()))
+}
+
+// ListAssignments handles GET /admin/users
+func (h *AdminHandler) ListAssignments(c *fiber.Ctx) error {
+	assignments, err := h.accessUseCase.ListAssignments(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(assignments)
+}
+
+// AssignRole handles PUT /admin/users/:id/role
+func (h *AdminHandler) AssignRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user ID"})
+	}
+
+	var req dto.RoleAssignmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	assignment, err := h.accessUseCase.AssignRole(c.Context(), userID, &req, currentUserID(c))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(assignment)
+}
+
+// RevokeRole handles DELETE /admin/users/:id/role
+func (h *AdminHandler) RevokeRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid user ID"})
+	}
+
+	if err := h.accessUseCase.RevokeRole(c.Context(), userID); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
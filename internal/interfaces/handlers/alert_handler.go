@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// AlertHandler handles stock alert, alert subscription and alert feed HTTP requests
+type AlertHandler struct {
+	alertUseCase usecases.AlertUseCase
+}
+
+// NewAlertHandler creates a new alert handler
+func NewAlertHandler(alertUseCase usecases.AlertUseCase) *AlertHandler {
+	return &AlertHandler{alertUseCase: alertUseCase}
+}
+
+// ListAlerts handles GET /alerts?status=&type=
+func (h *AlertHandler) ListAlerts(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	alerts, err := h.alertUseCase.ListAlerts(c.Context(), c.Query("status"), c.Query("type"), page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(alerts)
+}
+
+// Subscribe handles POST /alerts/subscriptions
+func (h *AlertHandler) Subscribe(c *fiber.Ctx) error {
+	var req dto.AlertSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	subscription, err := h.alertUseCase.Subscribe(c.Context(), &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(subscription)
+}
+
+// ListSubscriptions handles GET /alerts/subscriptions
+func (h *AlertHandler) ListSubscriptions(c *fiber.Ctx) error {
+	subscriptions, err := h.alertUseCase.ListSubscriptions(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(subscriptions)
+}
+
+// Unsubscribe handles DELETE /alerts/subscriptions/:id
+func (h *AlertHandler) Unsubscribe(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid subscription ID"})
+	}
+
+	if err := h.alertUseCase.Unsubscribe(c.Context(), id); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetFeed handles GET /alerts/feed?unread=
+func (h *AlertHandler) GetFeed(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	feed, err := h.alertUseCase.GetFeed(c.Context(), c.QueryBool("unread"), page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(feed)
+}
+
+// MarkFeedItemRead handles POST /alerts/feed/:id/read
+func (h *AlertHandler) MarkFeedItemRead(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid feed item ID"})
+	}
+
+	if err := h.alertUseCase.MarkFeedItemRead(c.Context(), id); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// AnalyticsHandler handles inventory analytics report HTTP requests
+type AnalyticsHandler struct {
+	analyticsUseCase usecases.AnalyticsUseCase
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(analyticsUseCase usecases.AnalyticsUseCase) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUseCase: analyticsUseCase,
+	}
+}
+
+// GetClassification handles GET /reports/abc-xyz
+func (h *AnalyticsHandler) GetClassification(c *fiber.Ctx) error {
+	var req dto.ClassificationRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	report, err := h.analyticsUseCase.ClassifyProducts(c.Context(), &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	if wantsCSV(c) {
+		return sendCSV(c, "abc-xyz.csv", classificationRecords(report))
+	}
+
+	return c.JSON(report)
+}
+
+// GetStockAsOf handles GET /reports/stock-as-of?date=
+func (h *AnalyticsHandler) GetStockAsOf(c *fiber.Ctx) error {
+	at, err := parseAsOf(c.Query("date"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid date, expected YYYY-MM-DD or RFC 3339"})
+	}
+
+	report, err := h.analyticsUseCase.GetStockAsOf(c.Context(), at)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	if wantsCSV(c) {
+		return sendCSV(c, "stock-as-of.csv", stockAsOfRecords(report))
+	}
+
+	return c.JSON(report)
+}
+
+// GetMovementReport handles GET /reports/movements?from=&to=&group_by=product|category&category_id=
+func (h *AnalyticsHandler) GetMovementReport(c *fiber.Ctx) error {
+	tomorrow := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	req := dto.MovementReportRequest{
+		From:    tomorrow.AddDate(0, 0, -30),
+		To:      tomorrow,
+		GroupBy: c.Query("group_by"),
+	}
+
+	if from := c.Query("from"); from != "" {
+		day, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid from date, expected YYYY-MM-DD"})
+		}
+		req.From = day
+	}
+
+	// The to date is inclusive
+	if to := c.Query("to"); to != "" {
+		day, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid to date, expected YYYY-MM-DD"})
+		}
+		req.To = day.AddDate(0, 0, 1)
+	}
+
+	categoryID, err := optionalUUID(c.Query("category_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid category ID"})
+	}
+	req.CategoryID = categoryID
+
+	report, err := h.analyticsUseCase.GetMovementReport(c.Context(), &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	if wantsCSV(c) {
+		return sendCSV(c, "movements.csv", movementRecords(report))
+	}
+
+	return c.JSON(report)
+}
+
+// GetDeadStock handles GET /reports/dead-stock?days=
+func (h *AnalyticsHandler) GetDeadStock(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", "90"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid days"})
+	}
+
+	categoryID, err := optionalUUID(c.Query("category_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid category ID"})
+	}
+
+	report, err := h.analyticsUseCase.GetDeadStock(c.Context(), &dto.DeadStockRequest{Days: days, CategoryID: categoryID})
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	if wantsCSV(c) {
+		return sendCSV(c, "dead-stock.csv", deadStockRecords(report))
+	}
+
+	return c.JSON(report)
+}
+
+// optionalUUID parses an optional ID query parameter
+func optionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+// parseAsOf parses the report time. A plain date means the end of that day (UTC),
+// i.e. the closing stock; an empty value means now.
+func parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		return day.AddDate(0, 0, 1), nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// classificationRecords converts a classification report to CSV records, one row per product
+func classificationRecords(report *dto.ClassificationResponse) [][]string {
+	records := [][]string{{
+		"product_id", "sku", "name", "consumption_quantity", "consumption_value",
+		"value_share", "cumulative_share", "demand_cv", "abc_class", "xyz_class", "class",
+	}}
+
+	for _, p := range report.Products {
+		records = append(records, []string{
+			p.ProductID.String(), p.SKU, p.Name, strconv.Itoa(p.ConsumptionQuantity), formatFloat(p.ConsumptionValue),
+			formatFloat(p.ValueShare), formatFloat(p.CumulativeShare), optionalFloat(p.DemandCV), p.ABCClass, p.XYZClass, p.Class,
+		})
+	}
+
+	return records
+}
+
+// stockAsOfRecords converts a stock-as-of report to CSV records, one row per product
+func stockAsOfRecords(report *dto.StockAsOfResponse) [][]string {
+	records := [][]string{{"category_id", "category_name", "product_id", "sku", "name", "quantity", "unit_cost", "value"}}
+
+	for _, category := range report.Categories {
+		for _, p := range category.Products {
+			records = append(records, []string{
+				category.CategoryID.String(), category.CategoryName, p.ProductID.String(), p.SKU, p.Name,
+				strconv.Itoa(p.Quantity), formatFloat(p.UnitCost), formatFloat(p.Value),
+			})
+		}
+	}
+
+	return records
+}
+
+// movementRecords converts a movement report to CSV records, one row per product or category
+func movementRecords(report *dto.MovementReportResponse) [][]string {
+	records := [][]string{{
+		"category_id", "category_name", "product_id", "sku", "name", "opening_stock", "in", "out", "adjustments",
+		"closing_stock", "closing_value", "turnover", "days_on_hand", "last_movement_at",
+	}}
+
+	for _, row := range report.Rows {
+		records = append(records, []string{
+			optionalID(row.CategoryID), row.CategoryName, optionalID(row.ProductID), row.SKU, row.Name,
+			strconv.Itoa(row.OpeningStock), strconv.Itoa(row.In), strconv.Itoa(row.Out), strconv.Itoa(row.Adjustments),
+			strconv.Itoa(row.ClosingStock), formatFloat(row.ClosingValue), optionalFloat(row.Turnover),
+			optionalFloat(row.DaysOnHand), optionalTime(row.LastMovementAt),
+		})
+	}
+
+	return records
+}
+
+// deadStockRecords converts a dead stock report to CSV records, one row per product
+func deadStockRecords(report *dto.DeadStockResponse) [][]string {
+	records := [][]string{{
+		"category_id", "category_name", "product_id", "sku", "name", "stock", "unit_cost", "value", "last_movement_at",
+	}}
+
+	for _, item := range report.Items {
+		records = append(records, []string{
+			item.CategoryID.String(), item.CategoryName, item.ProductID.String(), item.SKU, item.Name,
+			strconv.Itoa(item.Stock), formatFloat(item.UnitCost), formatFloat(item.Value), optionalTime(item.LastMovementAt),
+		})
+	}
+
+	return records
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// APIKeyHandler handles API key administration HTTP requests
+type APIKeyHandler struct {
+	apiKeyUseCase usecases.APIKeyUseCase
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyUseCase usecases.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyUseCase: apiKeyUseCase}
+}
+
+// CreateAPIKey handles POST /api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req dto.APIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	key, err := h.apiKeyUseCase.CreateAPIKey(c.Context(), &req, currentUserID(c))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// ListAPIKeys handles GET /api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	keys, err := h.apiKeyUseCase.ListAPIKeys(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(keys)
+}
+
+// RevokeAPIKey handles DELETE /api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid API key ID"})
+	}
+
+	if err := h.apiKeyUseCase.RevokeAPIKey(c.Context(), id); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
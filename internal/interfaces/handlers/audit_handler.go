@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/usecases"
+)
+
+// AuditHandler handles audit log HTTP requests
+type AuditHandler struct {
+	auditUseCase usecases.AuditUseCase
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditUseCase usecases.AuditUseCase) *AuditHandler {
+	return &AuditHandler{auditUseCase: auditUseCase}
+}
+
+// ListEntries handles GET /audit?entity=product&id=
+func (h *AuditHandler) ListEntries(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	var entityID uuid.UUID
+	if id := c.Query("id"); id != "" {
+		var err error
+		if entityID, err = uuid.Parse(id); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid entity ID"})
+		}
+	}
+
+	entries, err := h.auditUseCase.ListEntries(c.Context(), c.Query("entity"), entityID, page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(entries)
+}
+
+// GetProductHistory handles GET /products/:id/history
+func (h *AuditHandler) GetProductHistory(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	history, err := h.auditUseCase.GetProductHistory(c.Context(), id, page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(history)
+}
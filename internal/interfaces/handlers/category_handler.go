@@ -1,10 +1,124 @@
 package handlers
 
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
 // CategoryHandler handles category-related HTTP requests
 type CategoryHandler struct {
+	categoryUseCase usecases.CategoryUseCase
+}
+
+// NewCategoryHandler creates a new category handler
+func NewCategoryHandler(categoryUseCase usecases.CategoryUseCase) *CategoryHandler {
+	return &CategoryHandler{categoryUseCase: categoryUseCase}
+}
+
+// CreateCategory handles POST /categories
+func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
+	var req dto.CategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	category, err := h.categoryUseCase.CreateCategory(c.Context(), &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(category)
+}
+
+// ListCategories handles GET /categories
+func (h *CategoryHandler) ListCategories(c *fiber.Ctx) error {
+	categories, err := h.categoryUseCase.ListCategories(c.Context(), c.QueryBool("include_deleted"))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(categories)
+}
+
+// GetCategory handles GET /categories/:id
+func (h *CategoryHandler) GetCategory(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid category ID"})
+	}
+
+	category, err := h.categoryUseCase.GetCategory(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(category)
+}
+
+// PatchCategory handles PATCH /categories/:id with a merge patch or JSON patch body
+func (h *CategoryHandler) PatchCategory(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid category ID"})
+	}
+
+	req, err := patchRequest(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.categoryUseCase.PatchCategory(c.Context(), id, req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(result)
+}
+
+// DeleteCategory handles DELETE /categories/:id
+func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid category ID"})
+	}
+
+	if err := h.categoryUseCase.DeleteCategory(c.Context(), id); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
 }
 
-// NewCategoryHandler creates a new product handler
-func NewCategoryHandler() *CategoryHandler {
-	return &CategoryHandler{}
+// RestoreCategory handles POST /categories/:id/restore
+func (h *CategoryHandler) RestoreCategory(c *fiber.Ctx) error {
+	return h.setStatus(c, h.categoryUseCase.RestoreCategory)
+}
+
+// ActivateCategory handles POST /categories/:id/activate
+func (h *CategoryHandler) ActivateCategory(c *fiber.Ctx) error {
+	return h.setStatus(c, h.categoryUseCase.ActivateCategory)
+}
+
+// DeactivateCategory handles POST /categories/:id/deactivate
+func (h *CategoryHandler) DeactivateCategory(c *fiber.Ctx) error {
+	return h.setStatus(c, h.categoryUseCase.DeactivateCategory)
+}
+
+// setStatus runs a category status change or restore
+func (h *CategoryHandler) setStatus(c *fiber.Ctx, change func(context.Context, uuid.UUID) (*dto.CategoryResponse, error)) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid category ID"})
+	}
+
+	category, err := change(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(category)
 }
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// currentUserID returns the acting user stored in the request locals, or uuid.Nil when the request is anonymous
+func currentUserID(c *fiber.Ctx) uuid.UUID {
+	if userID, ok := c.Locals(entities.UserIDContextKey).(uuid.UUID); ok {
+		return userID
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// wantsCSV checks if the client asked for CSV with ?format=csv
+func wantsCSV(c *fiber.Ctx) bool {
+	return c.Query("format") == "csv"
+}
+
+// sendCSV writes the records as a CSV attachment
+func sendCSV(c *fiber.Ctx, filename string, records [][]string) error {
+	c.Attachment(filename)
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+
+	w := csv.NewWriter(c.Response().BodyWriter())
+	if err := w.WriteAll(records); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return nil
+}
+
+// formatFloat formats a number for CSV output
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// optionalFloat formats a number for CSV output; nil becomes an empty field
+func optionalFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return formatFloat(*f)
+}
+
+// optionalTime formats a time for CSV output; nil becomes an empty field
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// optionalID formats an ID for CSV output; nil becomes an empty field
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"inventory-app/internal/domain/entities"
+)
+
+// errorStatus maps domain errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, entities.ErrProductNotFound),
+		errors.Is(err, entities.ErrCategoryNotFound),
+		errors.Is(err, entities.ErrSupplierNotFound),
+		errors.Is(err, entities.ErrPurchaseOrderNotFound),
+		errors.Is(err, entities.ErrReservationNotFound),
+		errors.Is(err, entities.ErrReconciliationRunNotFound),
+		errors.Is(err, entities.ErrAPIKeyNotFound),
+		errors.Is(err, entities.ErrWebhookNotFound),
+		errors.Is(err, entities.ErrWebhookDeliveryNotFound),
+		errors.Is(err, entities.ErrAlertSubscriptionNotFound),
+		errors.Is(err, entities.ErrAlertFeedItemNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, entities.ErrDuplicateSKU),
+		errors.Is(err, entities.ErrInvalidPurchaseOrderStatus),
+		errors.Is(err, entities.ErrReservationNotActive),
+		errors.Is(err, entities.ErrIdempotencyKeyConflict),
+		errors.Is(err, entities.ErrDuplicateIdempotencyKey),
+		errors.Is(err, entities.ErrPatchTestFailed),
+		errors.Is(err, entities.ErrCategoryInUse),
+		errors.Is(err, entities.ErrDuplicateCategoryName),
+		errors.Is(err, entities.ErrParentDeleted):
+		return fiber.StatusConflict
+	case errors.Is(err, entities.ErrForecastRangeTooLarge):
+		return fiber.StatusBadRequest
+	case errors.Is(err, entities.ErrImportTooLarge):
+		return fiber.StatusRequestEntityTooLarge
+	case errors.Is(err, entities.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, entities.ErrVersionMismatch):
+		return fiber.StatusPreconditionFailed
+	case errors.Is(err, entities.ErrInvalidSKU),
+		errors.Is(err, entities.ErrInvalidQuantity),
+		errors.Is(err, entities.ErrInsufficientStock),
+		errors.Is(err, entities.ErrInvalidVariantMatrix),
+		errors.Is(err, entities.ErrVariantAxesMismatch),
+		errors.Is(err, entities.ErrNestedVariant),
+		errors.Is(err, entities.ErrNotVariantParent),
+		errors.Is(err, entities.ErrVariantParentStock),
+		errors.Is(err, entities.ErrVariantParentHasStock),
+		errors.Is(err, entities.ErrInvalidReplenishmentPolicy),
+		errors.Is(err, entities.ErrNoReplenishmentProposals),
+		errors.Is(err, entities.ErrInvalidForecastModel),
+		errors.Is(err, entities.ErrInvalidServiceLevel),
+		errors.Is(err, entities.ErrInvalidClassificationParams),
+		errors.Is(err, entities.ErrInvalidClass),
+		errors.Is(err, entities.ErrInvalidDateRange),
+		errors.Is(err, entities.ErrInvalidReportGrouping),
+		errors.Is(err, entities.ErrInvalidImportMode),
+		errors.Is(err, entities.ErrInvalidImportFile),
+		errors.Is(err, entities.ErrInvalidTransactionType),
+		errors.Is(err, entities.ErrInvalidMovementBatch),
+		errors.Is(err, entities.ErrMovementBatchRejected),
+		errors.Is(err, entities.ErrInvalidPatch),
+		errors.Is(err, entities.ErrInvalidField),
+		errors.Is(err, entities.ErrInvalidRole),
+		errors.Is(err, entities.ErrInvalidScope),
+		errors.Is(err, entities.ErrInvalidAuditEntity),
+		errors.Is(err, entities.ErrInvalidWebhookURL),
+		errors.Is(err, entities.ErrInvalidEventType),
+		errors.Is(err, entities.ErrInvalidDeliveryStatus),
+		errors.Is(err, entities.ErrInvalidAlertChannel),
+		errors.Is(err, entities.ErrAlertChannelDisabled),
+		errors.Is(err, entities.ErrInvalidAlertTarget),
+		errors.Is(err, entities.ErrInvalidAlertStatus),
+		errors.Is(err, entities.ErrInvalidProductStatus),
+		errors.Is(err, entities.ErrInvalidPriceBuckets):
+		return fiber.StatusUnprocessableEntity
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// errorResponse writes an error response with the status mapped from the error
+func errorResponse(c *fiber.Ctx, err error) error {
+	return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+}
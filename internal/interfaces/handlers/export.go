@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+)
+
+// Export formats
+const (
+	exportFormatCSV    = "csv"
+	exportFormatXLSX   = "xlsx"
+	exportFormatNDJSON = "ndjson"
+)
+
+// exportContentTypes maps each export format to its content type
+var exportContentTypes = map[string]string{
+	exportFormatCSV:    "text/csv; charset=utf-8",
+	exportFormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	exportFormatNDJSON: "application/x-ndjson",
+}
+
+// exportWriter writes rows of an export in one format.
+// Values are strings, ints, float64s, times or nil; nil becomes an empty cell or a JSON null.
+type exportWriter interface {
+	WriteHeader(columns []string) error
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// newExportWriter creates the writer for a format
+func newExportWriter(format string, w io.Writer) exportWriter {
+	switch format {
+	case exportFormatXLSX:
+		return &xlsxExportWriter{zip: zip.NewWriter(w)}
+	case exportFormatNDJSON:
+		return &ndjsonExportWriter{w: w}
+	default:
+		return &csvExportWriter{w: csv.NewWriter(w)}
+	}
+}
+
+// exportFormat reads ?format=, defaulting to CSV
+func exportFormat(c *fiber.Ctx) (string, bool) {
+	format := c.Query("format", exportFormatCSV)
+	_, ok := exportContentTypes[format]
+	return format, ok
+}
+
+// streamContextKeys are the request values carried over to the context of a streamed response body
+var streamContextKeys = []interface{}{
+	entities.UserIDContextKey,
+	entities.TenantIDContextKey,
+	entities.RequestIDContextKey,
+	entities.ClientIPContextKey,
+	entities.APIKeyContextKey,
+}
+
+// streamContext returns the context for work done while streaming a response body.
+// The request context must not be used once the handler has returned, so the caller's tenant, identity and
+// request ID are copied onto a detached context.
+func streamContext(c *fiber.Ctx) context.Context {
+	ctx := context.Background()
+	for _, key := range streamContextKeys {
+		if value := c.Context().Value(key); value != nil {
+			ctx = context.WithValue(ctx, key, value)
+		}
+	}
+	return ctx
+}
+
+// streamExport sends the rows produced by export as an attachment, streaming them as they are produced.
+// Headers are already sent when export runs, so a failure truncates the file; an XLSX file is then left
+// without its central directory and will not open.
+func streamExport(c *fiber.Ctx, format, filename string, columns []string, export func(write func(values []interface{}) error) error) error {
+	c.Attachment(filename + "." + format)
+	c.Set(fiber.HeaderContentType, exportContentTypes[format])
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ew := newExportWriter(format, w)
+		if err := ew.WriteHeader(columns); err != nil {
+			return
+		}
+		if err := export(ew.WriteRow); err != nil {
+			w.Flush()
+			return
+		}
+		if err := ew.Close(); err != nil {
+			return
+		}
+		w.Flush()
+	})
+
+	return nil
+}
+
+// csvExportWriter writes comma separated values
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (e *csvExportWriter) WriteHeader(columns []string) error {
+	return e.w.Write(columns)
+}
+
+func (e *csvExportWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = exportString(value)
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvExportWriter) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// ndjsonExportWriter writes one JSON object per line with keys in column order
+type ndjsonExportWriter struct {
+	w       io.Writer
+	columns [][]byte
+}
+
+func (e *ndjsonExportWriter) WriteHeader(columns []string) error {
+	e.columns = make([][]byte, len(columns))
+	for i, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		e.columns[i] = key
+	}
+	return nil
+}
+
+func (e *ndjsonExportWriter) WriteRow(values []interface{}) error {
+	line := []byte{'{'}
+	for i, value := range values {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		encoded, err := json.Marshal(exportJSON(value))
+		if err != nil {
+			return err
+		}
+		line = append(line, e.columns[i]...)
+		line = append(line, ':')
+		line = append(line, encoded...)
+	}
+	line = append(line, '}', '\n')
+
+	_, err := e.w.Write(line)
+	return err
+}
+
+func (e *ndjsonExportWriter) Close() error {
+	return nil
+}
+
+// xlsxExportWriter writes a single-sheet workbook. The sheet is written row by row into the zip
+// stream with inline strings, so no shared string table has to be held in memory.
+type xlsxExportWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	row   int
+}
+
+// xlsxStaticParts are the workbook parts written before the sheet
+var xlsxStaticParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Export" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+func (e *xlsxExportWriter) WriteHeader(columns []string) error {
+	for _, part := range xlsxStaticParts {
+		w, err := e.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := e.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	e.sheet = sheet
+
+	_, err = io.WriteString(e.sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return e.WriteRow(values)
+}
+
+func (e *xlsxExportWriter) WriteRow(values []interface{}) error {
+	e.row++
+	row := []byte(`<row r="` + strconv.Itoa(e.row) + `">`)
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			row = append(row, `<c/>`...)
+		case int:
+			row = append(row, `<c><v>`+strconv.Itoa(v)+`</v></c>`...)
+		case float64:
+			row = append(row, `<c><v>`+strconv.FormatFloat(v, 'f', -1, 64)+`</v></c>`...)
+		default:
+			row = append(row, `<c t="inlineStr"><is><t xml:space="preserve">`...)
+			row = append(row, xmlEscape(exportString(value))...)
+			row = append(row, `</t></is></c>`...)
+		}
+	}
+	row = append(row, `</row>`...)
+
+	_, err := e.sheet.Write(row)
+	return err
+}
+
+func (e *xlsxExportWriter) Close() error {
+	if _, err := io.WriteString(e.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return e.zip.Close()
+}
+
+// xmlEscape escapes text for an XML element; characters not allowed in XML are replaced
+func xmlEscape(s string) []byte {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.Bytes()
+}
+
+// exportString formats a value for a text cell
+func exportString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return formatFloat(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case uuid.UUID:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// exportJSON converts a value for NDJSON output; times use the same format as the other exports
+func exportJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case uuid.UUID:
+		return v.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// ForecastHandler handles demand forecast HTTP requests
+type ForecastHandler struct {
+	forecastUseCase usecases.ForecastUseCase
+}
+
+// NewForecastHandler creates a new forecast handler
+func NewForecastHandler(forecastUseCase usecases.ForecastUseCase) *ForecastHandler {
+	return &ForecastHandler{
+		forecastUseCase: forecastUseCase,
+	}
+}
+
+// GetProductForecast handles GET /products/:id/forecast
+func (h *ForecastHandler) GetProductForecast(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	var req dto.ForecastRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	forecast, err := h.forecastUseCase.GetProductForecast(c.Context(), id, &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(forecast)
+}
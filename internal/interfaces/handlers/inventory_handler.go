@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// InventoryHandler handles inventory-related HTTP requests
+type InventoryHandler struct {
+	inventoryUseCase usecases.InventoryUseCase
+}
+
+// NewInventoryHandler creates a new inventory handler
+func NewInventoryHandler(inventoryUseCase usecases.InventoryUseCase) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryUseCase: inventoryUseCase,
+	}
+}
+
+// ReserveStock handles POST /inventory/reservations
+func (h *InventoryHandler) ReserveStock(c *fiber.Ctx) error {
+	var req dto.ReservationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	reservation, err := h.inventoryUseCase.ReserveStock(c.Context(), &req, currentUserID(c))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(reservation)
+}
+
+// StockIn handles POST /inventory/stock-in
+func (h *InventoryHandler) StockIn(c *fiber.Ctx) error {
+	var req dto.StockMovementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.inventoryUseCase.StockIn(c.Context(), &req, currentUserID(c)); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// StockOut handles POST /inventory/stock-out
+func (h *InventoryHandler) StockOut(c *fiber.Ctx) error {
+	var req dto.StockMovementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.inventoryUseCase.StockOut(c.Context(), &req, currentUserID(c)); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AdjustStock handles POST /inventory/adjustments
+func (h *InventoryHandler) AdjustStock(c *fiber.Ctx) error {
+	var req dto.StockAdjustmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.inventoryUseCase.AdjustStock(c.Context(), &req, currentUserID(c)); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ApplyMovements handles POST /inventory/movements/batch.
+// A rejected batch responds 422 with the per-line results.
+func (h *InventoryHandler) ApplyMovements(c *fiber.Ctx) error {
+	var req dto.MovementBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.inventoryUseCase.ApplyMovements(c.Context(), &req, currentUserID(c))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	if !result.Applied {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(result)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
+
+// ReleaseReservation handles POST /inventory/reservations/:id/release
+func (h *InventoryHandler) ReleaseReservation(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid reservation ID"})
+	}
+
+	reservation, err := h.inventoryUseCase.ReleaseReservation(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(reservation)
+}
+
+// GetReservations handles GET /products/:id/reservations
+func (h *InventoryHandler) GetReservations(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	reservations, err := h.inventoryUseCase.GetReservations(c.Context(), productID)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(reservations)
+}
+
+// transactionExportColumns is the column order of ledger exports
+var transactionExportColumns = []string{"id", "product_id", "type", "quantity", "reference", "notes", "created_by", "created_at"}
+
+// ExportTransactions handles GET /transactions/export?format=csv|xlsx|ndjson&from=&to=&type=.
+// from and to are YYYY-MM-DD dates, to being inclusive; both are optional.
+func (h *InventoryHandler) ExportTransactions(c *fiber.Ctx) error {
+	format, ok := exportFormat(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid export format"})
+	}
+
+	req := dto.TransactionExportRequest{Type: c.Query("type")}
+
+	if from := c.Query("from"); from != "" {
+		day, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid from date, expected YYYY-MM-DD"})
+		}
+		req.From = &day
+	}
+
+	if to := c.Query("to"); to != "" {
+		day, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid to date, expected YYYY-MM-DD"})
+		}
+		end := day.AddDate(0, 0, 1)
+		req.To = &end
+	}
+
+	export, err := h.inventoryUseCase.ExportTransactions(streamContext(c), &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return streamExport(c, format, "transactions", transactionExportColumns, func(write func([]interface{}) error) error {
+		return export(func(t *dto.TransactionResponse) error {
+			return write([]interface{}{
+				t.ID, t.ProductID, t.Type, t.Quantity, t.Reference, t.Notes, t.CreatedBy, t.CreatedAt,
+			})
+		})
+	})
+}
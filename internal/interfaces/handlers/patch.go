@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"inventory-app/internal/application/dto"
+)
+
+// patchRequest reads a PATCH body, taking the patch format from the content type
+func patchRequest(c *fiber.Ctx) (*dto.PatchRequest, error) {
+	format := strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0])
+	if format != dto.PatchFormatMerge && format != dto.PatchFormatJSON {
+		return nil, fiber.NewError(fiber.StatusUnsupportedMediaType,
+			"Content-Type must be "+dto.PatchFormatMerge+" or "+dto.PatchFormatJSON)
+	}
+
+	return &dto.PatchRequest{Format: format, Document: c.Body()}, nil
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// weakETagPrefix marks an entity tag as weak
+const weakETagPrefix = "W/"
+
+// etag formats a resource version as a weak entity tag. The representation also holds values the version does not
+// cover, such as stock, so the tag only identifies the version, not the exact bytes.
+func etag(version int) string {
+	return weakETagPrefix + `"` + strconv.Itoa(version) + `"`
+}
+
+// setETag sets the ETag header of a response to the resource version
+func setETag(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, etag(version))
+}
+
+// ifMatchVersion reads the version a write is conditional on from the If-Match header.
+// The header is required; "*" matches any version and is returned as 0. The version is compared
+// weakly, so the tag may be sent with or without the W/ prefix. A tag that is not a version can
+// never match and fails with 412.
+func ifMatchVersion(c *fiber.Ctx) (int, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return 0, fiber.NewError(fiber.StatusPreconditionRequired, "If-Match header is required")
+	}
+
+	if header == "*" {
+		return 0, nil
+	}
+
+	// A list of tags matches if any of them matches; only a single version can be checked
+	if strings.Contains(header, ",") {
+		return 0, fiber.NewError(fiber.StatusPreconditionFailed, "If-Match must contain a single ETag")
+	}
+
+	header = strings.TrimPrefix(header, weakETagPrefix)
+	version, err := strconv.Atoi(strings.Trim(header, `"`))
+	if err != nil || version <= 0 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return 0, fiber.NewError(fiber.StatusPreconditionFailed, "If-Match does not match the current ETag")
+	}
+
+	return version, nil
+}
@@ -1,23 +1,34 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"inventory-app/internal/application/dto"
 	"inventory-app/internal/application/usecases"
+	"inventory-app/internal/domain/entities"
 )
 
 // ProductHandler handles product-related HTTP requests
 type ProductHandler struct {
 	productUseCase usecases.ProductUseCase
+	importUseCase  usecases.ProductImportUseCase
+	importLimit    int64 // largest import body in bytes
 }
 
-// NewProductHandler creates a new product handler
-func NewProductHandler(productUseCase usecases.ProductUseCase) *ProductHandler {
+// NewProductHandler creates a new product handler accepting imports of up to importLimit bytes
+func NewProductHandler(productUseCase usecases.ProductUseCase, importUseCase usecases.ProductImportUseCase, importLimit int64) *ProductHandler {
 	return &ProductHandler{
 		productUseCase: productUseCase,
+		importUseCase:  importUseCase,
+		importLimit:    importLimit,
 	}
 }
 
@@ -49,10 +60,11 @@ func (h *ProductHandler) GetProduct(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	setETag(c, product.Version)
 	return c.JSON(product)
 }
 
-// UpdateProduct handles PUT /products/:id
+// UpdateProduct handles PUT /products/:id; If-Match must carry the product's current ETag
 func (h *ProductHandler) UpdateProduct(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := uuid.Parse(idParam)
@@ -60,20 +72,53 @@ func (h *ProductHandler) UpdateProduct(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
 	}
 
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
 	var req dto.ProductRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	product, err := h.productUseCase.UpdateProduct(c.Context(), id, &req)
+	product, err := h.productUseCase.UpdateProduct(c.Context(), id, &req, version)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, err)
 	}
 
+	setETag(c, product.Version)
 	return c.JSON(product)
 }
 
-// DeleteProduct handles DELETE /products/:id
+// PatchProduct handles PATCH /products/:id with a merge patch or JSON patch body;
+// If-Match must carry the product's current ETag
+func (h *ProductHandler) PatchProduct(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	req, err := patchRequest(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.productUseCase.PatchProduct(c.Context(), id, req, version)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setETag(c, result.Product.Version)
+	return c.JSON(result)
+}
+
+// DeleteProduct handles DELETE /products/:id; If-Match must carry the product's current ETag
 func (h *ProductHandler) DeleteProduct(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := uuid.Parse(idParam)
@@ -81,36 +126,96 @@ func (h *ProductHandler) DeleteProduct(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
 	}
 
-	err = h.productUseCase.DeleteProduct(c.Context(), id)
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return err
+	}
+
+	err = h.productUseCase.DeleteProduct(c.Context(), id, version)
+	if err != nil {
+		return errorResponse(c, err)
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// RestoreProduct handles POST /products/:id/restore; If-Match must carry the product's current ETag
+func (h *ProductHandler) RestoreProduct(c *fiber.Ctx) error {
+	return h.setStatus(c, h.productUseCase.RestoreProduct)
+}
+
+// ActivateProduct handles POST /products/:id/activate; If-Match must carry the product's current ETag
+func (h *ProductHandler) ActivateProduct(c *fiber.Ctx) error {
+	return h.setStatus(c, h.productUseCase.ActivateProduct)
+}
+
+// DeactivateProduct handles POST /products/:id/deactivate; If-Match must carry the product's current ETag
+func (h *ProductHandler) DeactivateProduct(c *fiber.Ctx) error {
+	return h.setStatus(c, h.productUseCase.DeactivateProduct)
+}
+
+// setStatus runs a product status change or restore conditional on the If-Match version
+func (h *ProductHandler) setStatus(c *fiber.Ctx, change func(context.Context, uuid.UUID, int) (*dto.ProductResponse, error)) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	product, err := change(c.Context(), id, version)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	setETag(c, product.Version)
+	return c.JSON(product)
+}
+
 // ListProducts handles GET /products
 func (h *ProductHandler) ListProducts(c *fiber.Ctx) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 
-	products, err := h.productUseCase.ListProducts(c.Context(), page, limit)
+	var filter dto.ProductFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	products, err := h.productUseCase.ListProducts(c.Context(), &filter, page, limit)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, err)
 	}
 
 	return c.JSON(products)
 }
 
-// SearchProducts handles GET /products/search
+// SearchProducts handles GET /products/search?q=&category_id=&status=&in_stock=&include_deleted=&facets=&price_buckets=
 func (h *ProductHandler) SearchProducts(c *fiber.Ctx) error {
-	query := c.Query("q")
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	req := dto.ProductSearchRequest{
+		Query:          c.Query("q"),
+		Statuses:       queryList(c, "status"),
+		InStock:        c.QueryBool("in_stock"),
+		IncludeDeleted: c.QueryBool("include_deleted"),
+		Page:           page,
+		Limit:          limit,
+		Facets:         c.QueryBool("facets"),
+		PriceBuckets:   c.Query("price_buckets"),
+	}
+
+	var err error
+	if req.CategoryIDs, err = queryUUIDs(c, "category_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid category ID"})
+	}
 
-	products, err := h.productUseCase.SearchProducts(c.Context(), query, page, limit)
+	products, err := h.productUseCase.SearchProducts(c.Context(), &req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return errorResponse(c, err)
 	}
 
 	return c.JSON(products)
@@ -125,3 +230,167 @@ func (h *ProductHandler) GetLowStockProducts(c *fiber.Ctx) error {
 
 	return c.JSON(products)
 }
+
+// GenerateVariants handles POST /products/:id/variants
+func (h *ProductHandler) GenerateVariants(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	var req dto.GenerateVariantsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	product, err := h.productUseCase.GenerateVariants(c.Context(), id, &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(product)
+}
+
+// ApplyVariantPolicy handles PUT /products/:id/variants/policy
+func (h *ProductHandler) ApplyVariantPolicy(c *fiber.Ctx) error {
+	idParam := c.Params("id")
+	id, err := uuid.Parse(idParam)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	var req dto.VariantPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	product, err := h.productUseCase.ApplyVariantPolicy(c.Context(), id, &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(product)
+}
+
+// ImportProducts handles POST /products/import.
+// The CSV is sent either as the raw request body or as the "file" field of a multipart form, and is read
+// as it arrives. A body over the import limit responds 413; a rejected all-or-nothing import responds 422
+// with the row report.
+func (h *ProductHandler) ImportProducts(c *fiber.Ctx) error {
+	var req dto.ImportRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if int64(c.Request().Header.ContentLength()) > h.importLimit {
+		return errorResponse(c, entities.ErrImportTooLarge)
+	}
+
+	var body io.Reader = bytes.NewReader(c.Body())
+	if stream := c.Context().RequestBodyStream(); stream != nil {
+		body = stream
+	}
+	body = &limitedImport{r: body, remaining: h.importLimit}
+
+	if boundary := string(c.Request().Header.MultipartFormBoundary()); boundary != "" {
+		file, err := multipartFile(multipart.NewReader(body, boundary), "file")
+		if err != nil {
+			return errorResponse(c, err)
+		}
+		body = file
+	}
+
+	result, err := h.importUseCase.ImportProducts(c.Context(), body, &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	if !result.DryRun && !result.Committed {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(result)
+	}
+
+	return c.JSON(result)
+}
+
+// limitedImport reads an import body, failing with ErrImportTooLarge once it goes past the limit
+type limitedImport struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read reads up to the remaining bytes of the limit
+func (l *limitedImport) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// A body of exactly the limit is fine; only a byte beyond it is too much
+		var probe [1]byte
+		if n, err := l.r.Read(probe[:]); n == 0 {
+			return 0, err
+		}
+		return 0, entities.ErrImportTooLarge
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// multipartFile returns the reader of the named file field of a multipart form, skipping the parts before it
+func multipartFile(form *multipart.Reader, name string) (io.Reader, error) {
+	for {
+		part, err := form.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the form has no %q field", entities.ErrInvalidImportFile, name)
+		}
+		if err != nil {
+			if errors.Is(err, entities.ErrImportTooLarge) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", entities.ErrInvalidImportFile, err)
+		}
+		if part.FormName() == name {
+			return part, nil
+		}
+	}
+}
+
+// productExportColumns is the column order of product exports. The importable columns come first,
+// so an export can be edited and sent back to POST /products/import.
+var productExportColumns = []string{
+	"sku", "name", "description", "category_id", "price", "cost", "min_stock", "max_stock",
+	"id", "stock", "status", "parent_id", "created_at", "updated_at",
+}
+
+// ExportProducts handles GET /products/export?format=csv|xlsx|ndjson with the same filters as the product list
+func (h *ProductHandler) ExportProducts(c *fiber.Ctx) error {
+	format, ok := exportFormat(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid export format"})
+	}
+
+	var filter dto.ProductFilter
+	if err := c.QueryParser(&filter); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	export, err := h.productUseCase.ExportProducts(streamContext(c), &filter)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return streamExport(c, format, "products", productExportColumns, func(write func([]interface{}) error) error {
+		return export(func(p *dto.ProductResponse) error {
+			var parentID interface{}
+			if p.ParentID != nil {
+				parentID = *p.ParentID
+			}
+			return write([]interface{}{
+				p.SKU, p.Name, p.Description, p.CategoryID, p.Price, p.Cost, p.MinStock, p.MaxStock,
+				p.ID, p.Stock, p.Status, parentID, p.CreatedAt, p.UpdatedAt,
+			})
+		})
+	})
+}
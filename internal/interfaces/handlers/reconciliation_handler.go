@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// ReconciliationHandler handles ledger reconciliation HTTP requests
+type ReconciliationHandler struct {
+	reconciliationUseCase usecases.ReconciliationUseCase
+}
+
+// NewReconciliationHandler creates a new reconciliation handler
+func NewReconciliationHandler(reconciliationUseCase usecases.ReconciliationUseCase) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationUseCase: reconciliationUseCase,
+	}
+}
+
+// Reconcile handles POST /reconciliation/runs
+func (h *ReconciliationHandler) Reconcile(c *fiber.Ctx) error {
+	var req dto.ReconciliationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+	}
+
+	run, err := h.reconciliationUseCase.Reconcile(c.Context(), &req, currentUserID(c))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(run)
+}
+
+// GetRun handles GET /reconciliation/runs/:id
+func (h *ReconciliationHandler) GetRun(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid reconciliation run ID"})
+	}
+
+	run, err := h.reconciliationUseCase.GetRun(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(run)
+}
+
+// ListRuns handles GET /reconciliation/runs
+func (h *ReconciliationHandler) ListRuns(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	runs, err := h.reconciliationUseCase.ListRuns(c.Context(), page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(runs)
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+	"inventory-app/internal/domain/entities"
+)
+
+// ReplenishmentHandler handles replenishment and purchase order HTTP requests
+type ReplenishmentHandler struct {
+	replenishmentUseCase usecases.ReplenishmentUseCase
+}
+
+// NewReplenishmentHandler creates a new replenishment handler
+func NewReplenishmentHandler(replenishmentUseCase usecases.ReplenishmentUseCase) *ReplenishmentHandler {
+	return &ReplenishmentHandler{
+		replenishmentUseCase: replenishmentUseCase,
+	}
+}
+
+// GetProposals handles GET /replenishment/proposals?policy=max|eoq
+func (h *ReplenishmentHandler) GetProposals(c *fiber.Ctx) error {
+	policy := c.Query("policy", entities.ReplenishmentPolicyMax)
+
+	proposals, err := h.replenishmentUseCase.GetProposals(c.Context(), policy)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(proposals)
+}
+
+// CreatePurchaseOrders handles POST /replenishment/purchase-orders
+func (h *ReplenishmentHandler) CreatePurchaseOrders(c *fiber.Ctx) error {
+	var req dto.CreatePurchaseOrdersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if req.Policy == "" {
+		req.Policy = entities.ReplenishmentPolicyMax
+	}
+
+	purchaseOrders, err := h.replenishmentUseCase.CreatePurchaseOrders(c.Context(), &req, currentUserID(c))
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(purchaseOrders)
+}
+
+// ListPurchaseOrders handles GET /purchase-orders
+func (h *ReplenishmentHandler) ListPurchaseOrders(c *fiber.Ctx) error {
+	status := c.Query("status")
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	purchaseOrders, err := h.replenishmentUseCase.ListPurchaseOrders(c.Context(), status, page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(purchaseOrders)
+}
+
+// GetPurchaseOrder handles GET /purchase-orders/:id
+func (h *ReplenishmentHandler) GetPurchaseOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid purchase order ID"})
+	}
+
+	purchaseOrder, err := h.replenishmentUseCase.GetPurchaseOrder(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(purchaseOrder)
+}
+
+// SubmitPurchaseOrder handles POST /purchase-orders/:id/submit
+func (h *ReplenishmentHandler) SubmitPurchaseOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid purchase order ID"})
+	}
+
+	purchaseOrder, err := h.replenishmentUseCase.SubmitPurchaseOrder(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(purchaseOrder)
+}
+
+// CancelPurchaseOrder handles POST /purchase-orders/:id/cancel
+func (h *ReplenishmentHandler) CancelPurchaseOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid purchase order ID"})
+	}
+
+	purchaseOrder, err := h.replenishmentUseCase.CancelPurchaseOrder(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(purchaseOrder)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+	"inventory-app/internal/domain/entities"
+)
+
+// streamRetryMS is the reconnect delay suggested to stream clients
+const streamRetryMS = 3000
+
+// StreamHandler handles server-sent event streams
+type StreamHandler struct {
+	streamUseCase usecases.StreamUseCase
+	heartbeat     time.Duration
+}
+
+// NewStreamHandler creates a new stream handler sending a heartbeat comment every heartbeat interval
+func NewStreamHandler(streamUseCase usecases.StreamUseCase, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{streamUseCase: streamUseCase, heartbeat: heartbeat}
+}
+
+// StreamStock handles GET /stream/stock?product_id=&category_id=
+func (h *StreamHandler) StreamStock(c *fiber.Ctx) error {
+	var req dto.StockStreamRequest
+	var err error
+	if req.ProductIDs, err = queryUUIDs(c, "product_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+	if req.CategoryIDs, err = queryUUIDs(c, "category_id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid category ID"})
+	}
+
+	// EventSource sends the header on reconnect; the query parameter serves clients that cannot set headers
+	lastEventID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+	if lastEventID != "" {
+		if req.LastEventID, err = strconv.ParseInt(lastEventID, 10, 64); err != nil || req.LastEventID < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid Last-Event-ID"})
+		}
+	}
+
+	subscription, err := h.streamUseCase.OpenStockStream(c.Context(), &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.streamUseCase.CloseStockStream(subscription)
+
+		fmt.Fprintf(w, "retry: %d\n\n", streamRetryMS)
+		if subscription.Reset {
+			// The client missed more than can be replayed and should reload the current stock
+			fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+		}
+
+		replayed := make(map[uuid.UUID]bool, len(subscription.Replay))
+		for _, event := range subscription.Replay {
+			replayed[event.ID] = true
+			h.writeEvent(w, event)
+		}
+		if w.Flush() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(h.heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case event, ok := <-subscription.Events:
+				if !ok {
+					// Dropped for falling behind or on shutdown; the client reconnects and resumes
+					return
+				}
+				if replayed[event.ID] {
+					continue
+				}
+				h.writeEvent(w, event)
+			case <-ticker.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+
+			// A failed flush means the client went away
+			if w.Flush() != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeEvent writes a stock event as a server-sent event; events that cannot be converted are skipped
+func (h *StreamHandler) writeEvent(w *bufio.Writer, event *entities.Event) {
+	streamEvent, err := h.streamUseCase.StockStreamEvent(event)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(streamEvent.Data)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", streamEvent.ID, streamEvent.Type, data)
+}
+
+// queryList returns the comma-separated values of a query parameter
+func queryList(c *fiber.Ctx, key string) []string {
+	var values []string
+	for _, value := range strings.Split(c.Query(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// queryUUIDs parses the comma-separated IDs of a query parameter
+func queryUUIDs(c *fiber.Ctx, key string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, value := range queryList(c, key) {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// SupplierHandler handles supplier-related HTTP requests
+type SupplierHandler struct {
+	supplierUseCase usecases.SupplierUseCase
+}
+
+// NewSupplierHandler creates a new supplier handler
+func NewSupplierHandler(supplierUseCase usecases.SupplierUseCase) *SupplierHandler {
+	return &SupplierHandler{
+		supplierUseCase: supplierUseCase,
+	}
+}
+
+// CreateSupplier handles POST /suppliers
+func (h *SupplierHandler) CreateSupplier(c *fiber.Ctx) error {
+	var req dto.SupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	supplier, err := h.supplierUseCase.CreateSupplier(c.Context(), &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(supplier)
+}
+
+// GetSupplier handles GET /suppliers/:id
+func (h *SupplierHandler) GetSupplier(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid supplier ID"})
+	}
+
+	supplier, err := h.supplierUseCase.GetSupplier(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(supplier)
+}
+
+// UpdateSupplier handles PUT /suppliers/:id
+func (h *SupplierHandler) UpdateSupplier(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid supplier ID"})
+	}
+
+	var req dto.SupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	supplier, err := h.supplierUseCase.UpdateSupplier(c.Context(), id, &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(supplier)
+}
+
+// ListSuppliers handles GET /suppliers
+func (h *SupplierHandler) ListSuppliers(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	suppliers, err := h.supplierUseCase.ListSuppliers(c.Context(), page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(suppliers)
+}
+
+// SetProductSupplier handles PUT /suppliers/:id/products/:productId
+func (h *SupplierHandler) SetProductSupplier(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid supplier ID"})
+	}
+
+	productID, err := uuid.Parse(c.Params("productId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	var req dto.ProductSupplierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	terms, err := h.supplierUseCase.SetProductSupplier(c.Context(), id, productID, &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(terms)
+}
+
+// GetProductSuppliers handles GET /products/:id/suppliers
+func (h *SupplierHandler) GetProductSuppliers(c *fiber.Ctx) error {
+	productID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid product ID"})
+	}
+
+	terms, err := h.supplierUseCase.GetProductSuppliers(c.Context(), productID)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(terms)
+}
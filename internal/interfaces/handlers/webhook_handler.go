@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"inventory-app/internal/application/dto"
+	"inventory-app/internal/application/usecases"
+)
+
+// WebhookHandler handles webhook subscription HTTP requests
+type WebhookHandler struct {
+	webhookUseCase usecases.WebhookUseCase
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookUseCase usecases.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{webhookUseCase: webhookUseCase}
+}
+
+// CreateWebhook handles POST /webhooks
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var req dto.WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	webhook, err := h.webhookUseCase.CreateWebhook(c.Context(), &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(webhook)
+}
+
+// ListWebhooks handles GET /webhooks
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	webhooks, err := h.webhookUseCase.ListWebhooks(c.Context())
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(webhooks)
+}
+
+// GetWebhook handles GET /webhooks/:id
+func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid webhook ID"})
+	}
+
+	webhook, err := h.webhookUseCase.GetWebhook(c.Context(), id)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(webhook)
+}
+
+// UpdateWebhook handles PUT /webhooks/:id
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid webhook ID"})
+	}
+
+	var req dto.WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	webhook, err := h.webhookUseCase.UpdateWebhook(c.Context(), id, &req)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(webhook)
+}
+
+// DeleteWebhook handles DELETE /webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid webhook ID"})
+	}
+
+	if err := h.webhookUseCase.DeleteWebhook(c.Context(), id); err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListDeliveries handles GET /webhooks/:id/deliveries?status=
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid webhook ID"})
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+
+	deliveries, err := h.webhookUseCase.ListDeliveries(c.Context(), id, c.Query("status"), page, limit)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(deliveries)
+}
+
+// GetDelivery handles GET /webhooks/:id/deliveries/:deliveryId
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	return h.delivery(c, h.webhookUseCase.GetDelivery)
+}
+
+// RedeliverDelivery handles POST /webhooks/:id/deliveries/:deliveryId/redeliver
+func (h *WebhookHandler) RedeliverDelivery(c *fiber.Ctx) error {
+	return h.delivery(c, h.webhookUseCase.RedeliverDelivery)
+}
+
+// delivery runs a use case on the delivery named by the path
+func (h *WebhookHandler) delivery(c *fiber.Ctx, fn func(context.Context, uuid.UUID, uuid.UUID) (*dto.WebhookDeliveryResponse, error)) error {
+	webhookID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid webhook ID"})
+	}
+
+	deliveryID, err := uuid.Parse(c.Params("deliveryId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid delivery ID"})
+	}
+
+	delivery, err := fn(c.Context(), webhookID, deliveryID)
+	if err != nil {
+		return errorResponse(c, err)
+	}
+
+	return c.JSON(delivery)
+}
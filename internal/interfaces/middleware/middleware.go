@@ -1,18 +1,29 @@
 package middleware
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/google/uuid"
+	"inventory-app/internal/domain/entities"
+	"inventory-app/internal/domain/repositories"
 )
 
-// CORS returns CORS middleware for Fiber
-func CORS() fiber.Handler {
+// CORS returns CORS middleware for Fiber.
+// allowOrigins is a comma-separated list of origins or "*"; credentials are only allowed for
+// an explicit list, since browsers reject them for a wildcard origin.
+func CORS(allowOrigins string) fiber.Handler {
 	return cors.New(cors.Config{
-		AllowOrigins:     "*",
-		AllowCredentials: true,
-		AllowHeaders:     "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With",
-		AllowMethods:     "POST, OPTIONS, GET, PUT, DELETE",
+		AllowOrigins:     allowOrigins,
+		AllowCredentials: allowOrigins != "*",
+		AllowHeaders:     "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Idempotency-Key, If-Match",
+		ExposeHeaders:    "ETag, Idempotent-Replayed",
+		AllowMethods:     "POST, OPTIONS, GET, PUT, PATCH, DELETE",
 	})
 }
 
@@ -31,7 +42,7 @@ func JSONContentType() fiber.Handler {
 	}
 }
 
-// RequestID adds a request ID to each request
+// RequestID adds a request ID to each request and records the client IP for the audit log
 func RequestID() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		requestID := c.Get("X-Request-ID")
@@ -39,8 +50,107 @@ func RequestID() fiber.Handler {
 			requestID = uuid.New().String()
 		}
 
-		c.Locals("RequestID", requestID)
+		c.Locals(entities.RequestIDContextKey, requestID)
+		c.Locals(entities.ClientIPContextKey, c.IP())
 		c.Set("X-Request-ID", requestID)
 		return c.Next()
 	}
 }
+
+// BodyLimit rejects request bodies larger than limit bytes with 413. The server streams bodies instead of
+// buffering them so that imports can be read as they arrive; on every other path the body is read into
+// memory here, up to the limit, before any handler sees it. The streamedPaths are left to their handlers,
+// which must limit what they read.
+func BodyLimit(limit int, streamedPaths ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, path := range streamedPaths {
+			if strings.TrimSuffix(c.Path(), "/") == path {
+				return c.Next()
+			}
+		}
+
+		req := c.Request()
+		if req.Header.ContentLength() > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+
+		if stream := req.BodyStream(); stream != nil {
+			// Chunked bodies have no length up front, so the limit is checked while reading
+			body, err := io.ReadAll(io.LimitReader(stream, int64(limit)+1))
+			if err != nil {
+				return fiber.ErrBadRequest
+			}
+			if len(body) > limit {
+				return fiber.ErrRequestEntityTooLarge
+			}
+			req.SetBody(body)
+		}
+
+		return c.Next()
+	}
+}
+
+// maxIdempotencyKeyLength is the longest Idempotency-Key accepted
+const maxIdempotencyKeyLength = 255
+
+// Idempotency makes POST requests sent with an Idempotency-Key header safe to retry.
+// Keys belong to the user or API key that sent them, which authentication has set by now.
+// The first response for a key is stored and replayed for retries with the same method, URL and body;
+// a different request with the same key, or a retry while the first is still running, gets 409.
+// Server errors and permission denials are not stored, so the request can be retried with the same key.
+func Idempotency(repo repositories.IdempotencyRepository, ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get("Idempotency-Key")
+		if key == "" || c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Idempotency-Key must be at most 255 characters",
+			})
+		}
+
+		hash := sha256.New()
+		hash.Write([]byte(c.Method() + " " + c.OriginalURL() + "\n"))
+		hash.Write(c.Body())
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		reserved, err := repo.Reserve(c.Context(), entities.NewIdempotencyRecord(key, requestHash, ttl))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		if !reserved {
+			record, err := repo.GetByKey(c.Context(), key)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			}
+			if record == nil || record.RequestHash != requestHash || !record.IsComplete() {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": entities.ErrIdempotencyKeyConflict.Error()})
+			}
+
+			c.Set("Idempotent-Replayed", "true")
+			if record.ContentType != "" {
+				c.Set(fiber.HeaderContentType, record.ContentType)
+			}
+			return c.Status(record.StatusCode).Send(record.Body)
+		}
+
+		// Transactions record the key under its sender too, so other senders may use the same key
+		c.Locals(entities.IdempotencyKeyContextKey, entities.UserIDFromContext(c.Context()).String()+"/"+key)
+		if err := c.Next(); err != nil {
+			repo.Delete(c.Context(), key)
+			return err
+		}
+
+		// Denials are not stored either, so a retry succeeds once the user has been given the permission
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError || status == fiber.StatusForbidden {
+			return repo.Delete(c.Context(), key)
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+		return repo.Complete(c.Context(), key, status, string(c.Response().Header.ContentType()), body)
+	}
+}
@@ -0,0 +1,322 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidPatch is returned for malformed patches and operations on paths that do not exist
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrTestFailed is returned when a test operation does not match the document
+	ErrTestFailed = errors.New("test operation failed")
+)
+
+// Operation is a single JSON Patch (RFC 6902) operation
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"` // nil when absent, "null" when null
+}
+
+// Decode parses a JSON Patch document
+func Decode(data []byte) ([]Operation, error) {
+	var ops []Operation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return ops, nil
+}
+
+// Apply applies JSON Patch operations to a decoded JSON document and returns the result.
+// The document passed in is not modified. Operations apply in order and stop at the first failure.
+func Apply(doc interface{}, ops []Operation) (interface{}, error) {
+	doc = deepCopy(doc)
+	for i, op := range ops {
+		var err error
+		doc, err = applyOperation(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+// MergePatch applies a JSON Merge Patch (RFC 7386) to a decoded JSON document and returns the result.
+// The target passed in is not modified.
+func MergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return deepCopy(patch)
+	}
+
+	result := make(map[string]interface{})
+	if targetObject, ok := target.(map[string]interface{}); ok {
+		for key, value := range targetObject {
+			result[key] = deepCopy(value)
+		}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+		} else {
+			result[key] = MergePatch(result[key], value)
+		}
+	}
+
+	return result
+}
+
+// applyOperation applies a single operation
+func applyOperation(doc interface{}, op Operation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+		}
+		var value interface{}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+
+		switch op.Op {
+		case "add":
+			return put(doc, path, value, true)
+		case "replace":
+			return put(doc, path, value, false)
+		default:
+			current, err := get(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		var value interface{}
+		if op.Op == "move" {
+			if len(from) < len(path) && isPrefix(from, path) {
+				return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalidPatch)
+			}
+			if doc, value, err = remove(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			if value, err = get(doc, from); err != nil {
+				return nil, err
+			}
+			value = deepCopy(value)
+		}
+		return put(doc, path, value, true)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, op.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path %q must start with /", ErrInvalidPatch, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// isPrefix checks if the tokens of prefix start path
+func isPrefix(prefix, path []string) bool {
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex parses an array index token; "-" refers to the position after the last element
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+
+	// RFC 6901 allows only digits without leading zeros, which Atoi alone would not enforce
+	index, err := strconv.Atoi(token)
+	if err != nil || strings.TrimLeft(token, "0123456789") != "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+
+	max := length - 1
+	if allowEnd {
+		max = length
+	}
+	if index > max {
+		return 0, fmt.Errorf("%w: array index %d out of range", ErrInvalidPatch, index)
+	}
+
+	return index, nil
+}
+
+// get returns the value at path
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+			}
+			doc = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[index]
+		default:
+			return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+	}
+	return doc, nil
+}
+
+// put sets the value at path and returns the updated document.
+// With insert, a new object member may be created and array values are inserted rather than replaced.
+func put(doc interface{}, path []string, value interface{}, insert bool) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token, last := path[0], len(path) == 1
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok && !(last && insert) {
+			return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+		if last {
+			node[token] = value
+			return node, nil
+		}
+		updated, err := put(child, path[1:], value, insert)
+		if err != nil {
+			return nil, err
+		}
+		node[token] = updated
+		return node, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node), last && insert)
+		if err != nil {
+			return nil, err
+		}
+		if last && insert {
+			result := make([]interface{}, 0, len(node)+1)
+			result = append(result, node[:index]...)
+			result = append(result, value)
+			return append(result, node[index:]...), nil
+		}
+		if last {
+			node[index] = value
+			return node, nil
+		}
+		updated, err := put(node[index], path[1:], value, insert)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = updated
+		return node, nil
+	default:
+		return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+	}
+}
+
+// remove deletes the value at path and returns the updated document and the removed value
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+
+	token, last := path[0], len(path) == 1
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+		if last {
+			delete(node, token)
+			return node, child, nil
+		}
+		updated, removed, err := remove(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[token] = updated
+		return node, removed, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if last {
+			removed := node[index]
+			result := make([]interface{}, 0, len(node)-1)
+			result = append(result, node[:index]...)
+			return append(result, node[index+1:]...), removed, nil
+		}
+		updated, removed, err := remove(node[index], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		node[index] = updated
+		return node, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+	}
+}
+
+// deepCopy copies the objects and arrays of a decoded JSON value
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			result[key] = deepCopy(child)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, child := range v {
+			result[i] = deepCopy(child)
+		}
+		return result
+	default:
+		return v
+	}
+}
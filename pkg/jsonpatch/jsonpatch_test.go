@@ -0,0 +1,360 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, data string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	return value
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string // expected document when err is nil
+		wantErr error
+	}{
+		// RFC 6902 Appendix A
+		{
+			name:  "A.1 adding an object member",
+			doc:   `{"foo": "bar"}`,
+			patch: `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			want:  `{"baz": "qux", "foo": "bar"}`,
+		},
+		{
+			name:  "A.2 adding an array element",
+			doc:   `{"foo": ["bar", "baz"]}`,
+			patch: `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			want:  `{"foo": ["bar", "qux", "baz"]}`,
+		},
+		{
+			name:  "A.3 removing an object member",
+			doc:   `{"baz": "qux", "foo": "bar"}`,
+			patch: `[{"op": "remove", "path": "/baz"}]`,
+			want:  `{"foo": "bar"}`,
+		},
+		{
+			name:  "A.4 removing an array element",
+			doc:   `{"foo": ["bar", "qux", "baz"]}`,
+			patch: `[{"op": "remove", "path": "/foo/1"}]`,
+			want:  `{"foo": ["bar", "baz"]}`,
+		},
+		{
+			name:  "A.5 replacing a value",
+			doc:   `{"baz": "qux", "foo": "bar"}`,
+			patch: `[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			want:  `{"baz": "boo", "foo": "bar"}`,
+		},
+		{
+			name:  "A.6 moving a value",
+			doc:   `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			patch: `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			want:  `{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+		},
+		{
+			name:  "A.7 moving an array element",
+			doc:   `{"foo": ["all", "grass", "cows", "eat"]}`,
+			patch: `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			want:  `{"foo": ["all", "cows", "eat", "grass"]}`,
+		},
+		{
+			name: "A.8 testing a value: success",
+			doc:  `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			patch: `[{"op": "test", "path": "/baz", "value": "qux"},
+				{"op": "test", "path": "/foo/1", "value": 2}]`,
+			want: `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+		},
+		{
+			name:    "A.9 testing a value: error",
+			doc:     `{"baz": "qux"}`,
+			patch:   `[{"op": "test", "path": "/baz", "value": "bar"}]`,
+			wantErr: ErrTestFailed,
+		},
+		{
+			name:  "A.10 adding a nested member object",
+			doc:   `{"foo": "bar"}`,
+			patch: `[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`,
+			want:  `{"foo": "bar", "child": {"grandchild": {}}}`,
+		},
+		{
+			name:  "A.11 ignoring unrecognized elements",
+			doc:   `{"foo": "bar"}`,
+			patch: `[{"op": "add", "path": "/baz", "value": "qux", "xyz": 123}]`,
+			want:  `{"foo": "bar", "baz": "qux"}`,
+		},
+		{
+			name:    "A.12 adding to a nonexistent target",
+			doc:     `{"foo": "bar"}`,
+			patch:   `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:  "A.14 ~ escape ordering",
+			doc:   `{"/": 9, "~1": 10}`,
+			patch: `[{"op": "test", "path": "/~01", "value": 10}]`,
+			want:  `{"/": 9, "~1": 10}`,
+		},
+		{
+			name:    "A.15 comparing strings and numbers",
+			doc:     `{"/": 9, "~1": 10}`,
+			patch:   `[{"op": "test", "path": "/~01", "value": "10"}]`,
+			wantErr: ErrTestFailed,
+		},
+		{
+			name:  "A.16 adding an array value",
+			doc:   `{"foo": ["bar"]}`,
+			patch: `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			want:  `{"foo": ["bar", ["abc", "def"]]}`,
+		},
+
+		// Copy
+		{
+			name:  "copy a value",
+			doc:   `{"foo": {"bar": [1]}}`,
+			patch: `[{"op": "copy", "from": "/foo", "path": "/baz"}, {"op": "add", "path": "/baz/bar/-", "value": 2}]`,
+			want:  `{"foo": {"bar": [1]}, "baz": {"bar": [1, 2]}}`,
+		},
+		{
+			name:    "copy from a missing path",
+			doc:     `{"foo": 1}`,
+			patch:   `[{"op": "copy", "from": "/bar", "path": "/baz"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+
+		// Escaping
+		{
+			name:  "~1 refers to a slash",
+			doc:   `{"a/b": 1}`,
+			patch: `[{"op": "replace", "path": "/a~1b", "value": 2}]`,
+			want:  `{"a/b": 2}`,
+		},
+		{
+			name:  "~0 refers to a tilde",
+			doc:   `{"m~n": 1}`,
+			patch: `[{"op": "remove", "path": "/m~0n"}]`,
+			want:  `{}`,
+		},
+		{
+			name:  "escaped member is added as is",
+			doc:   `{}`,
+			patch: `[{"op": "add", "path": "/~0~1", "value": true}]`,
+			want:  `{"~/": true}`,
+		},
+
+		// Array indices
+		{
+			name:  "add with - appends",
+			doc:   `{"foo": [1, 2]}`,
+			patch: `[{"op": "add", "path": "/foo/-", "value": 3}]`,
+			want:  `{"foo": [1, 2, 3]}`,
+		},
+		{
+			name:  "add at the length appends",
+			doc:   `{"foo": [1, 2]}`,
+			patch: `[{"op": "add", "path": "/foo/2", "value": 3}]`,
+			want:  `{"foo": [1, 2, 3]}`,
+		},
+		{
+			name:    "add past the length",
+			doc:     `{"foo": [1, 2]}`,
+			patch:   `[{"op": "add", "path": "/foo/3", "value": 3}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "replace at the length",
+			doc:     `{"foo": [1, 2]}`,
+			patch:   `[{"op": "replace", "path": "/foo/2", "value": 3}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "remove with -",
+			doc:     `{"foo": [1, 2]}`,
+			patch:   `[{"op": "remove", "path": "/foo/-"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "test with -",
+			doc:     `{"foo": [1, 2]}`,
+			patch:   `[{"op": "test", "path": "/foo/-", "value": 2}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "negative index",
+			doc:     `{"foo": [1, 2]}`,
+			patch:   `[{"op": "remove", "path": "/foo/-1"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "index with a leading zero",
+			doc:     `{"foo": [1, 2]}`,
+			patch:   `[{"op": "remove", "path": "/foo/01"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "index with a sign",
+			doc:     `{"foo": [1, 2]}`,
+			patch:   `[{"op": "remove", "path": "/foo/+1"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+
+		// Move
+		{
+			name:    "move into a child",
+			doc:     `{"foo": {"bar": 1}}`,
+			patch:   `[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:  "move onto itself",
+			doc:   `{"foo": {"bar": 1}}`,
+			patch: `[{"op": "move", "from": "/foo", "path": "/foo"}]`,
+			want:  `{"foo": {"bar": 1}}`,
+		},
+		{
+			name:  "move to a sibling with a common prefix",
+			doc:   `{"foo": 1}`,
+			patch: `[{"op": "move", "from": "/foo", "path": "/foobar"}]`,
+			want:  `{"foobar": 1}`,
+		},
+
+		// Whole document and malformed operations
+		{
+			name:  "replace the whole document",
+			doc:   `{"foo": 1}`,
+			patch: `[{"op": "replace", "path": "", "value": [1]}]`,
+			want:  `[1]`,
+		},
+		{
+			name:    "remove the whole document",
+			doc:     `{"foo": 1}`,
+			patch:   `[{"op": "remove", "path": ""}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "path without a leading slash",
+			doc:     `{"foo": 1}`,
+			patch:   `[{"op": "remove", "path": "foo"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "add without a value",
+			doc:     `{"foo": 1}`,
+			patch:   `[{"op": "add", "path": "/bar"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:  "add a null value",
+			doc:   `{"foo": 1}`,
+			patch: `[{"op": "add", "path": "/bar", "value": null}]`,
+			want:  `{"foo": 1, "bar": null}`,
+		},
+		{
+			name:    "unknown operation",
+			doc:     `{"foo": 1}`,
+			patch:   `[{"op": "increment", "path": "/foo"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "replace a missing member",
+			doc:     `{"foo": 1}`,
+			patch:   `[{"op": "replace", "path": "/bar", "value": 2}]`,
+			wantErr: ErrInvalidPatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := Decode([]byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			doc := decodeJSON(t, tt.doc)
+
+			got, err := Apply(doc, ops)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(doc, decodeJSON(t, tt.doc)) {
+				t.Error("Apply modified the document passed in")
+			}
+		})
+	}
+}
+
+func TestApplyStopsAtFirstFailure(t *testing.T) {
+	doc := decodeJSON(t, `{"foo": 1}`)
+	ops, err := Decode([]byte(`[{"op": "replace", "path": "/foo", "value": 2}, {"op": "test", "path": "/foo", "value": 3}]`))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	if got, err := Apply(doc, ops); !errors.Is(err, ErrTestFailed) || got != nil {
+		t.Errorf("got %v, %v; want nil and %v", got, err, ErrTestFailed)
+	}
+	if !reflect.DeepEqual(doc, decodeJSON(t, `{"foo": 1}`)) {
+		t.Error("a failed patch modified the document")
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	if _, err := Decode([]byte(`{"op": "add"}`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("error = %v, want %v", err, ErrInvalidPatch)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// RFC 7386 Appendix A
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target+" "+tt.patch, func(t *testing.T) {
+			target := decodeJSON(t, tt.target)
+			got := MergePatch(target, decodeJSON(t, tt.patch))
+			if want := decodeJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(target, decodeJSON(t, tt.target)) {
+				t.Error("MergePatch modified the target passed in")
+			}
+		})
+	}
+}